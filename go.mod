@@ -65,6 +65,7 @@ require (
 	k8s.io/component-base v0.28.5
 	k8s.io/klog/v2 v2.110.1
 	k8s.io/kube-aggregator v0.28.5
+	k8s.io/kube-openapi v0.0.0-20230717233707-2695361300d9
 	k8s.io/kube-scheduler v0.28.5
 	k8s.io/kubectl v0.28.5
 	k8s.io/kubelet v0.28.5
@@ -213,7 +214,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/klog v1.0.0 // indirect
 	k8s.io/kms v0.28.5 // indirect
-	k8s.io/kube-openapi v0.0.0-20230717233707-2695361300d9 // indirect
 	sigs.k8s.io/apiserver-network-proxy/konnectivity-client v0.1.2 // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/kustomize/api v0.13.5-0.20230601165947-6ce0bf390ce3 // indirect
@@ -46,6 +46,9 @@ const (
 	StoreIDLabelKey string = "tidb.pingcap.com/store-id"
 	// MemberIDLabelKey is member id label key
 	MemberIDLabelKey string = "tidb.pingcap.com/member-id"
+	// PDLeaderLabelKey is label key used in PD pods to mark the current PD leader,
+	// it is used as the selector of the PD leader service
+	PDLeaderLabelKey string = "tidb.pingcap.com/pd-leader"
 
 	// InitLabelKey is the key for TiDB initializer
 	InitLabelKey string = "tidb.pingcap.com/initializer"
@@ -70,6 +73,9 @@ const (
 	// VolumeRestoreFederationFinalizer is the name of finalizer on federation restores
 	VolumeRestoreFederationFinalizer string = "tidb.pingcap.com/restore-protection"
 
+	// PDPVCProtectionFinalizer is the name of finalizer on the PVCs of PD when the deletion protection is enabled
+	PDPVCProtectionFinalizer string = "tidb.pingcap.com/pd-pvc-protection"
+
 	// AutoScalingGroupLabelKey describes the autoscaling group of the TiDB
 	AutoScalingGroupLabelKey = "tidb.pingcap.com/autoscaling-group"
 	// AutoInstanceLabelKey is label key used in autoscaling, it represents the autoscaler name
@@ -99,6 +105,34 @@ const (
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
 	AnnPDDeferDeleting = "tidb.pingcap.com/pd-defer-deleting"
+	// AnnPDPVCDeletionConfirmed is tc annotation key to confirm that the PVCs of PD are safe to be deleted,
+	// the protection finalizer of the PVCs is removed if it's set to "true"
+	AnnPDPVCDeletionConfirmed = "tidb.pingcap.com/pd-pvc-deletion-confirmed"
+	// AnnPDPinnedRevision is tc annotation key to pin PD to the given ControllerRevision of the PD StatefulSet.
+	// The StatefulSet is switched to OnDelete and pods adopt the pinned revision only when deleted manually.
+	AnnPDPinnedRevision = "pingcap.com/pd-pinned-revision"
+	// AnnPDSkipVersionCheck is tc annotation key to skip rejecting the unsupported upgrade paths of PD if it's set to "true"
+	AnnPDSkipVersionCheck = "pingcap.com/pd-skip-version-check"
+	// AnnPDAllowDisablePlacementRules is tc annotation key to allow disabling the placement rules of PD if it's set to "true"
+	AnnPDAllowDisablePlacementRules = "pingcap.com/pd-allow-disable-placement-rules"
+	// AnnPDDisableFailover is tc annotation key to skip the failover of PD while it's present, e.g. during a planned maintenance
+	AnnPDDisableFailover = "pingcap.com/pd-disable-failover"
+	// AnnPDForceNewCluster is tc annotation key to designate the name of a surviving PD pod to be started with
+	// `--force-new-cluster` once to recover PD from the loss of quorum, it's removed by the operator once consumed
+	AnnPDForceNewCluster = "pingcap.com/pd-force-new-cluster"
+	// AnnPDResourceSummary is sts annotation key to record the total CPU and memory of all PD replicas for cost tracking,
+	// it's recomputed by the operator on the change of the resources or the replicas
+	AnnPDResourceSummary = "pingcap.com/pd-resource-summary"
+	// AnnPVCClusterName is pvc annotation key to record the name of the cluster owning the PVC for auditing
+	AnnPVCClusterName = "tidb.pingcap.com/cluster-name"
+	// AnnPVCComponent is pvc annotation key to record the component owning the PVC for auditing
+	AnnPVCComponent = "tidb.pingcap.com/component"
+	// AnnPVCPodOrdinal is pvc annotation key to record the ordinal of the pod owning the PVC for auditing
+	AnnPVCPodOrdinal = "tidb.pingcap.com/pod-ordinal"
+	// AnnServiceTopologyMode is svc annotation key to enable the topology aware routing of the service since Kubernetes v1.27
+	AnnServiceTopologyMode = "service.kubernetes.io/topology-mode"
+	// AnnServiceTopologyAwareHints is svc annotation key to enable the topology aware hints of the service before Kubernetes v1.27
+	AnnServiceTopologyAwareHints = "service.kubernetes.io/topology-aware-hints"
 	// AnnSysctlInit is pod annotation key to indicate whether configuring sysctls with init container
 	AnnSysctlInit = "tidb.pingcap.com/sysctl-init"
 	// AnnEvictLeaderBeginTime is pod annotation key to indicate the begin time for evicting region leader
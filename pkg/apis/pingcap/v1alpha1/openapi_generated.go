@@ -84,18 +84,32 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing":                   schema_pkg_apis_pingcap_v1alpha1_OpenTracing(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter":           schema_pkg_apis_pingcap_v1alpha1_OpenTracingReporter(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler":            schema_pkg_apis_pingcap_v1alpha1_OpenTracingSampler(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDAuditConfig":                 schema_pkg_apis_pingcap_v1alpha1_PDAuditConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfig":                      schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDFlushMetricsConfig":          schema_pkg_apis_pingcap_v1alpha1_PDFlushMetricsConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDKeyRange":                    schema_pkg_apis_pingcap_v1alpha1_PDKeyRange(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLabelProperty":               schema_pkg_apis_pingcap_v1alpha1_PDLabelProperty(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig":                   schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogTailerSpec":               schema_pkg_apis_pingcap_v1alpha1_PDLogTailerSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMSSpec":                      schema_pkg_apis_pingcap_v1alpha1_PDMSSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMaintenanceToleration":       schema_pkg_apis_pingcap_v1alpha1_PDMaintenanceToleration(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig":                schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig":             schema_pkg_apis_pingcap_v1alpha1_PDNamespaceConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNetworkPolicy":               schema_pkg_apis_pingcap_v1alpha1_PDNetworkPolicy(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReadinessSidecar":            schema_pkg_apis_pingcap_v1alpha1_PDReadinessSidecar(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabel":                 schema_pkg_apis_pingcap_v1alpha1_PDRegionLabel(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabelRule":             schema_pkg_apis_pingcap_v1alpha1_PDRegionLabelRule(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionMergeConfig":           schema_pkg_apis_pingcap_v1alpha1_PDRegionMergeConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig":           schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationModeConfig":       schema_pkg_apis_pingcap_v1alpha1_PDReplicationModeConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig":              schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig":             schema_pkg_apis_pingcap_v1alpha1_PDSchedulerConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulers":                  schema_pkg_apis_pingcap_v1alpha1_PDSchedulers(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig":              schema_pkg_apis_pingcap_v1alpha1_PDSecurityConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig":                schema_pkg_apis_pingcap_v1alpha1_PDServerConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSpec":                        schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel":                  schema_pkg_apis_pingcap_v1alpha1_PDStoreLabel(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLimits":                 schema_pkg_apis_pingcap_v1alpha1_PDStoreLimits(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance":                   schema_pkg_apis_pingcap_v1alpha1_Performance(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn":                schema_pkg_apis_pingcap_v1alpha1_PessimisticTxn(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PlanCache":                     schema_pkg_apis_pingcap_v1alpha1_PlanCache(ref),
@@ -5321,6 +5335,33 @@ func schema_pkg_apis_pingcap_v1alpha1_OpenTracingSampler(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDAuditConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDAuditConfig is the audit config of PD",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled controls whether PD records the audit log of its HTTP API calls, which is mapped to `pd-server.enable-audit`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"redactInfoLog": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RedactInfoLog controls whether the user data in the log of PD is redacted, which is mapped to `security.redact-info-log`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5511,6 +5552,109 @@ func schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDFlushMetricsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDFlushMetricsConfig is the config to push the final metrics of PD to a Prometheus Pushgateway on shutdown",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pushgatewayURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PushgatewayURL is the URL of the Prometheus Pushgateway, e.g. http://pushgateway:9091.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"job": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Job is the job label of the pushed metrics, the instance label is the name of the pod. Optional: Defaults to pd",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds is the timeout of scraping and pushing the metrics respectively. A failure to flush the metrics never blocks the shutdown of PD. Optional: Defaults to 5",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"pushgatewayURL"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDKeyRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDKeyRange is a key range of regions, the keys are hex encoded",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartKey is the hex encoded start key of the range, inclusive. An empty key means the start of all keys.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndKey is the hex encoded end key of the range, exclusive. An empty key means the end of all keys.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDLabelProperty(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDLabelProperty is a label property of PD, which applies the property to the stores with the label",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the property, e.g. `reject-leader`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the key of the store label.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the value of the store label.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "key", "value"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5581,6 +5725,87 @@ func schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDLogTailerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDLogTailerSpec represents an optional log tailer sidecar with PD",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. Requests cannot exceed Limits. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"claims": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Claims lists the names of resources, defined in spec.resourceClaims, that are used by this container.\n\nThis is an alpha field and requires enabling the DynamicResourceAllocation feature gate.\n\nThis field is immutable. It can only be set for containers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.ResourceClaim"),
+									},
+								},
+							},
+						},
+					},
+					"maxSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSize is the maximum size in megabytes of the PD log file before it gets rotated. Optional: Defaults to 300",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxBackups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxBackups is the maximum number of rotated PD log files to retain. Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ResourceClaim", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_PDMSSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -6031,6 +6256,42 @@ func schema_pkg_apis_pingcap_v1alpha1_PDMSSpec(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDMaintenanceToleration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDMaintenanceToleration is the taint added to the nodes during the node maintenance which PD tolerates",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the key of the taint.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the value of the taint, any value of the key is tolerated if it's empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tolerationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TolerationSeconds is how long PD stays on the node after the taint is added, PD is never evicted by the taint if it's not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -6116,63 +6377,321 @@ func schema_pkg_apis_pingcap_v1alpha1_PDNamespaceConfig(ref common.ReferenceCall
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_PDNetworkPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PDReplicationConfig is the replication configuration.",
+				Description: "PDNetworkPolicy describes the NetworkPolicy generated for PD",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"max-replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MaxReplicas is the number of replicas for each region. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"strictly-match-label": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StrictlyMatchLabel strictly checks if the label of TiKV is matched with LocaltionLabels. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
+							Description: "Enabled controls whether the NetworkPolicy is generated. Optional: Defaults to false",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"enable-placement-rules": {
+					"from": {
 						SchemaProps: spec.SchemaProps{
-							Description: "When PlacementRules feature is enabled. MaxReplicas and LocationLabels are not used anymore.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "From is the list of additional peers allowed to reach PD besides the members of the cluster, e.g. the operator, TidbMonitor and backup jobs, which may live in other namespaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/networking/v1.NetworkPolicyPeer"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/api/networking/v1.NetworkPolicyPeer"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_PDReadinessSidecar(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ScheduleConfig is the schedule configuration.",
+				Description: "PDReadinessSidecar is the HTTP endpoint of the health aggregator sidecar of PD",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"max-snapshot-count": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If the snapshot count of one store is greater than this value, it will never be used as a source or target store. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
+							Description: "Port is the port of the HTTP endpoint of the sidecar.",
+							Default:     0,
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"max-pending-peer-count": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 16",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Path is the path of the HTTP endpoint of the sidecar, a status code between 200 and 399 means ready. Optional: Defaults to /health",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"max-merge-region-size": {
-						SchemaProps: spec.SchemaProps{
+				},
+				Required: []string{"port"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRegionLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRegionLabel is a label of regions",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"key", "value"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRegionLabelRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRegionLabelRule is a region label rule of PD, which sets the labels on the regions in the key ranges",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the unique ID of the rule in PD.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"index": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Index is the priority of the rule, the labels of the rule with a larger index win on conflicts.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels are the labels set on the regions in the key ranges.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabel"),
+									},
+								},
+							},
+						},
+					},
+					"keyRanges": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyRanges are the key ranges the rule applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDKeyRange"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"id", "labels", "keyRanges"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDKeyRange", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabel"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRegionMergeConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRegionMergeConfig is the region merge config of PD",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enableCrossTableMerge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableCrossTableMerge is mapped to `schedule.enable-cross-table-merge` of PD, which allows the regions of different tables to be merged.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxMergeRegionSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxMergeRegionSize is mapped to `schedule.max-merge-region-size` of PD in MiB, a region smaller than it is merged with the adjacent region. Region merge is disabled if it's 0.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxMergeRegionKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxMergeRegionKeys is mapped to `schedule.max-merge-region-keys` of PD, a region with fewer keys than it is merged with the adjacent region.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"hotReload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HotReload makes the operator apply the items to the running PD cluster via PD on each sync, so that a change takes effect without restarting PD. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDReplicationConfig is the replication configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"max-replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxReplicas is the number of replicas for each region. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"strictly-match-label": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StrictlyMatchLabel strictly checks if the label of TiKV is matched with LocaltionLabels. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-placement-rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "When PlacementRules feature is enabled. MaxReplicas and LocationLabels are not used anymore.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDReplicationModeConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDReplicationModeConfig is the replication mode config of PD",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the replication mode of PD, which is mapped to `replication-mode.replication-mode`. The other fields are only allowed and the label key, primary and DR are required if it's `dr-auto-sync`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"labelKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LabelKey is the label key of the stores distinguishing the data centers, e.g. `zone`, which is mapped to `replication-mode.dr-auto-sync.label-key`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"primary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Primary is the label value of the primary data center, which is mapped to `replication-mode.dr-auto-sync.primary`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dr": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DR is the label value of the DR data center, which is mapped to `replication-mode.dr-auto-sync.dr`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"primaryReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrimaryReplicas is the number of voter replicas in the primary data center, which is mapped to `replication-mode.dr-auto-sync.primary-replicas`.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"drReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DRReplicas is the number of voter replicas in the DR data center, which is mapped to `replication-mode.dr-auto-sync.dr-replicas`.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"waitStoreTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitStoreTimeout is the time to wait before switching to the async replication when the stores of a data center are down, e.g. `1m`, which is mapped to `replication-mode.dr-auto-sync.wait-store-timeout`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"mode"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScheduleConfig is the schedule configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"max-snapshot-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If the snapshot count of one store is greater than this value, it will never be used as a source or target store. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"max-pending-peer-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 16",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"max-merge-region-size": {
+						SchemaProps: spec.SchemaProps{
 							Description: "If both the size of region is smaller than MaxMergeRegionSize and the number of rows in region is smaller than MaxMergeRegionKeys, it will try to merge with adjacent regions. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 20",
 							Type:        []string{"integer"},
 							Format:      "int64",
@@ -6412,6 +6931,49 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSchedulerConfig(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDSchedulers(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDSchedulers is the schedulers of PD to add or remove",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"add": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Add is the names of the schedulers to add, which must not take arguments.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"remove": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Remove is the names of the schedulers to remove.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_PDSecurityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -6695,259 +7257,748 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) commo
 							},
 						},
 					},
-					"dnsConfig": {
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.\n\nPossible enum values:\n - `\"ClusterFirst\"` indicates that the pod should use cluster DNS first unless hostNetwork is true, if it is available, then fall back on the default (as determined by kubelet) DNS settings.\n - `\"ClusterFirstWithHostNet\"` indicates that the pod should use cluster DNS first, if it is available, then fall back on the default (as determined by kubelet) DNS settings.\n - `\"Default\"` indicates that the pod should use the default (as determined by kubelet) DNS settings.\n - `\"None\"` indicates that the pod should use empty DNS settings. DNS parameters such as nameservers and search paths should be defined via DNSConfig.",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"ClusterFirst", "ClusterFirstWithHostNet", "Default", "None"},
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"statefulSetUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podManagementPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"suspendAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe describes actions that probe the components' readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. Requests cannot exceed Limits. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"claims": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Claims lists the names of resources, defined in spec.resourceClaims, that are used by this container.\n\nThis is an alpha field and requires enabling the DynamicResourceAllocation feature gate.\n\nThis field is immutable. It can only be set for containers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.ResourceClaim"),
+									},
+								},
+							},
+						},
+					},
+					"serviceAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify a Service Account for pd",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"baseImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service defines a Kubernetes service of PD cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec"),
+						},
+					},
+					"maxFailoverCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The storageClassName of the persistent volume for PD data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageVolumes configure additional storage for PD pods.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+									},
+								},
+							},
+						},
+					},
+					"dataSubDir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subdirectory within the volume to store PD Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/pd. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/pd/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the Configuration of pd-servers",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper"),
+						},
+					},
+					"tlsClientSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate which used by Dashboard.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enableDashboardInternalProxy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "(Deprecated) EnableDashboardInternalProxy would directly set `internal-proxy` in the `PdConfig`. Note that this is deprecated, we should just set `dashboard.internal-proxy` in `pd.config`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"mountClusterClientSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountClusterClientSecret indicates whether to mount `cluster-client-secret` to the Pod",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"startUpScriptVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start up script version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout threshold when pd get started",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"initWaitTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Wait time before pd get started. This wait time is to allow the new DNS record to propagate, ensuring that the PD DNS resolves to the same IP address as the pod.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the mode of PD cluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spareVolReplaceReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The default number of spare replicas to scale up when using VolumeReplace feature. In multi-az deployments with topology spread constraints you may need to set this to number of zones to avoid zone skew after volume replace (total replicas always whole multiples of zones). Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"fsGroupChangePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FSGroupChangePolicy defines how the ownership and permission of the PD data volume is changed when `fsGroup` is set in the pod security context. It only takes effect when `podSecurityContext.fsGroup` is set and `podSecurityContext.fsGroupChangePolicy` is not set. Optional: Defaults to nil, which leaves it to the default of Kubernetes, i.e. Always\n\nPossible enum values:\n - `\"Always\"` indicates that volume's ownership and permissions should always be changed whenever volume is mounted inside a Pod. This the default behavior.\n - `\"OnRootMismatch\"` indicates that volume's ownership and permissions will be changed only when permission and ownership of root directory does not match with expected permissions on the volume. This can help shorten the time it takes to change ownership and permissions of a volume.",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"Always", "OnRootMismatch"},
+						},
+					},
+					"prometheusScrapeAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrometheusScrapeAnnotations are merged into the annotations of PD pods to configure how Prometheus scrapes PD, e.g. `prometheus.io/path` overrides the default `/metrics` path. They take precedence over the default `prometheus.io/*` annotations.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"runAsUser": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunAsUser is the UID to run the entrypoint of the PD container. It is set in the security context of the PD container and takes precedence over `podSecurityContext.runAsUser` for the PD container only.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"runAsGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunAsGroup is the GID to run the entrypoint of the PD container. It is set in the security context of the PD container and takes precedence over `podSecurityContext.runAsGroup` for the PD container only.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enableLeaderService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableLeaderService makes the operator maintain a `<cluster>-pd-leader` service whose endpoints only contain the current PD leader. The leader pod is labeled by the operator and the label is used as the selector of the service. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"configFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigFrom references a key of an existing ConfigMap in the same namespace whose content is used as the TOML configuration of pd-servers. It is mutually exclusive with `config`. Changes of the referenced content are picked up on the next sync and roll PD like changes of `config`.",
+							Ref:         ref("k8s.io/api/core/v1.ConfigMapKeySelector"),
+						},
+					},
+					"logTailer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogTailer enables a log tailer sidecar for PD. When set, PD writes its log to a file in a shared emptyDir volume and rotates it by size, and the sidecar tails the file to stdout. It only takes effect when `config` or `configFrom` is set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogTailerSpec"),
+						},
+					},
+					"storeLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StoreLimits is the store limits applied to all stores via PD. The operator compares them with the limits in PD on each sync and applies them on drift.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLimits"),
+						},
+					},
+					"waitForDNS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitForDNS adds an init container using the helper image, which blocks the start of PD until the DNS record of the pod in the peer service can be resolved. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"preferredLeader": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreferredLeader is the PD member, specified by the member name or the ordinal of the pod, that PD prefers as the leader. The operator sets the leader priority of the member above all other members, and PD transfers the leader to it whenever it is healthy. The priority is kept after the field is unset.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ordinalsStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OrdinalsStart is the start index of the ordinals of PD pods, which sets `.spec.ordinals.start` of the StatefulSet. It requires Kubernetes v1.27+ and is not supported by the advanced StatefulSet. It can't be changed after the cluster is created. Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"auditConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AuditConfig is mapped into the audit related config of PD, i.e. `security.redact-info-log` and the audit of the HTTP API in `pd-server`. An item is left as is if it's already set in the config of PD. It's rendered into the config file, so nothing happens unless `config` or `configFrom` is set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDAuditConfig"),
+						},
+					},
+					"deletionProtection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionProtection adds a finalizer to the PVCs of PD to prevent them from being deleted, e.g. before a backup completes. The finalizer is removed after the deletion is confirmed by setting the annotation `tidb.pingcap.com/pd-pvc-deletion-confirmed` to `true` on the TidbCluster, or the protection is disabled. The PVCs deleted by the failover or the scale-in of PD aren't protected, and the finalizer is also added to the TidbCluster to release the PVCs when it's deleted. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"probePort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProbePort is the port targeted by the tcp readiness probe of PD, e.g. the health port of a sidecar proxy fronting PD. Optional: Defaults to the client port of PD",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"locationLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocationLabels is mapped to `replication.location-labels` of PD, which drives the topology-aware replication. The store labels of TiKV and TiFlash are set from the node labels with the same keys, and the short names `region`, `zone` and `host` are also matched with the well-known node labels. The store labels are set in any case, while the PD side is only rendered with `config` or `configFrom`, and `replication.location-labels` in the config of PD is kept if it's there.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeClassName is the name of the RuntimeClass used to run PD pods. The overhead defined by the RuntimeClass is set to the pods by the RuntimeClass admission controller.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"overhead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overhead is the resource overhead of PD pods, which is accounted by the scheduler in addition to the requests of the containers. It must be identical to the overhead defined by the RuntimeClass if the RuntimeClass admission controller is enabled.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"exportEffectiveConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExportEffectiveConfig exports the config file of PD rendered by the operator, which includes the injected items such as the TLS paths, to the ConfigMap `<cluster>-pd-effective-config` for debugging. The ConfigMap is overwritten on each sync and deleted when it's disabled. It only takes effect when `config` or `configFrom` is set. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"checkVolumeWritable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CheckVolumeWritable adds an init container using the helper image, which writes to the data volume once before PD starts, and fails within 30 seconds with a clear message if the write fails or hangs, e.g. when a PD pod recreated during failover gets a volume not writable by the user of PD. It doesn't wait for the volume, which is always mounted before the init containers start. The check runs as `runAsUser` and `runAsGroup` of PD if they're set, otherwise as the user of the pod or the helper image. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"initialClusterToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialClusterToken is mapped to `initial-cluster-token` of PD, which prevents the PD members from joining the PD cluster of another TidbCluster by mistake. PD only reads it when the PD cluster is bootstrapped, so changing it later has no effect on a running cluster. It needs `config` or `configFrom` to be set, and `initial-cluster-token` in the config of PD wins over it. Optional: Defaults to `<cluster>-<uid of the cluster>`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"autoTuneSchedulerLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoTuneSchedulerLimits makes the operator compute `leader-schedule-limit` and `region-schedule-limit` of PD from the number of up stores, and apply them via PD on each sync, so that the limits grow with the cluster. The limits set in `.spec.pd.config` or via pd-ctl are overridden when it's enabled. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"regionLabelRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegionLabelRules are the key-range based region label rules reconciled via PD, which can be used to schedule the regions of a key range by the labels, e.g. to isolate the data of tenants. The operator only updates or deletes the rules it applied, the rules created by others are kept.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabelRule"),
+									},
+								},
+							},
+						},
+					},
+					"containerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerName is the name of the PD container in PD pods, which can be used by the tooling keying off container names. Changing it triggers a rolling update of PD, and the additional containers patching the PD container must use the same name. Optional: Defaults to `pd`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metricStorage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetricStorage is mapped to `pd-server.metric-storage` of PD, which is the address of the Prometheus compatible storage of the cluster metrics, e.g. `http://prometheus:9090`, used by TiDB Dashboard. It's ignored if `pd-server.metric-storage` is in the config of PD, or if neither `config` nor `configFrom` is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dashboardAddress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DashboardAddress is mapped to `pd-server.dashboard-address` of PD, which is the address TiDB Dashboard is served on, it can be `auto`, `none` or a URL of a PD member. Pinning it to a member keeps the dashboard on that member across the leader changes. It wins over `disableDashboard`, but not over `pd-server.dashboard-address` in the config of PD, and it's rendered only with `config` or `configFrom`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"diskPressureThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiskPressureThreshold is the percentage of the used capacity of the volumes of a PD member, the `PDDiskPressure` condition is set if it is reached by any member. The usage is fetched from the kubelet through the node proxy of the API server on a best-effort basis, which is only done if the operator is started with `--pd-disk-usage-check` and has the `get` permission of `nodes/proxy`. Optional: Defaults to nil, which disables the check",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxGRPCMessageSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxGRPCMessageSize is the max size of the gRPC messages sent and received by PD, e.g. `16Mi`, which is mapped to `pd-server.max-grpc-send-msg-size` and `pd-server.max-grpc-recv-msg-size` of PD in bytes. Raise it if the clients fail with `ResourceExhausted` on large responses, e.g. in a cluster with a huge number of regions. Each of the two keys set in the config of PD is kept, and nothing is rendered without `config` or `configFrom`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dashboardTiDBCAProjected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DashboardTiDBCAProjected is a projected volume which combines the CA trust bundle used by TiDB Dashboard to verify TiDB, e.g. from several ConfigMaps and Secrets. The bundle must be projected to `ca.crt`. It overrides the CA in the TiDB client secret and only takes effect when TLS between TiDB and its clients is enabled and `.spec.tidb.tlsClient.skipInternalClientCA` is false.",
+							Ref:         ref("k8s.io/api/core/v1.ProjectedVolumeSource"),
+						},
+					},
+					"upgradeStabilizationChecks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpgradeStabilizationChecks is the number of consecutive syncs in which all PD members must be healthy after the rollout of an upgrade completes, before the phase of PD leaves `Upgrade`. Optional: Defaults to 0, which means the phase leaves `Upgrade` once the rollout completes",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"networkPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Description: "NetworkPolicy describes the NetworkPolicy generated for PD, which only allows the members of the cluster and the configured peers to reach PD.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNetworkPolicy"),
 						},
 					},
-					"dnsPolicy": {
+					"enablePlacementRules": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.\n\nPossible enum values:\n - `\"ClusterFirst\"` indicates that the pod should use cluster DNS first unless hostNetwork is true, if it is available, then fall back on the default (as determined by kubelet) DNS settings.\n - `\"ClusterFirstWithHostNet\"` indicates that the pod should use cluster DNS first, if it is available, then fall back on the default (as determined by kubelet) DNS settings.\n - `\"Default\"` indicates that the pod should use the default (as determined by kubelet) DNS settings.\n - `\"None\"` indicates that the pod should use empty DNS settings. DNS parameters such as nameservers and search paths should be defined via DNSConfig.",
-							Type:        []string{"string"},
+							Description: "EnablePlacementRules is reconciled to `replication.enable-placement-rules` of the running PD cluster. Enabling is always applied, while disabling may drop the placement rules and is only applied when the annotation `pingcap.com/pd-allow-disable-placement-rules` of the cluster is \"true\". Optional: Defaults to nil, which means the operator does not reconcile it",
+							Type:        []string{"boolean"},
 							Format:      "",
-							Enum:        []interface{}{"ClusterFirst", "ClusterFirstWithHostNet", "Default", "None"},
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"pprofPort": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "PprofPort is the port of the internal-only `<cluster>-pd-pprof` service of PD, which forwards to the client port where PD serves pprof under `/debug/pprof`. PD can't serve pprof on another port, so pprof is still reachable through the client service of PD.",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"statefulSetUpdateStrategy": {
+					"enablePprofService": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
-							Type:        []string{"string"},
+							Description: "EnablePprofService makes the operator maintain the `<cluster>-pd-pprof` ClusterIP service on `.spec.pd.pprofPort`, so that pprof is reachable inside Kubernetes even if the client service of PD is exposed outside with another type. The service requires `.spec.pd.pprofPort`, and it's deleted when it's disabled. Optional: Defaults to false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"podManagementPolicy": {
+					"schedulers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Schedulers are the schedulers of PD reconciled via PD, e.g. `balance-leader-scheduler`. The schedulers in `add` are added if absent and the ones in `remove` are removed if present, the others are left untouched.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulers"),
 						},
 					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
+					"parallelBootstrap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParallelBootstrap makes the StatefulSet of PD use the `Parallel` pod management policy only when it's created for a cluster not bootstrapped yet, and `OrderedReady` otherwise. It overrides `podManagementPolicy`. The policy of an existing StatefulSet is immutable, so it's kept as is. To switch a bootstrapped cluster to `OrderedReady`, delete the StatefulSet of PD with `--cascade=orphan` and the operator recreates it. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
+					},
+					"disableTelemetry": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
-									},
-								},
-							},
+							Description: "DisableTelemetry disables the telemetry of TiDB Dashboard by setting `dashboard.enable-telemetry` to false and `dashboard.disable-telemetry` to true in the config of PD, unless they are set explicitly. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"suspendAction": {
+					"topologyAwareRouting": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+							Description: "TopologyAwareRouting enables the topology aware routing of the PD service, so that the clients prefer the PD members in the same zone. The annotations set in `.spec.pd.service.annotations` take precedence. Disabling it does not remove the annotations from the existing service. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"readinessProbe": {
+					"seccompProfile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the components' readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Description: "SeccompProfile is the seccomp profile of the PD pods, e.g. `RuntimeDefault`. It's set in the pod security context and applies to all containers of the pod. `podSecurityContext.seccompProfile` takes precedence if it's set.",
+							Ref:         ref("k8s.io/api/core/v1.SeccompProfile"),
 						},
 					},
-					"limits": {
+					"pvcAuditAnnotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "PVCAuditAnnotations adds the annotations identifying the cluster, the component and the pod ordinal to the PVCs of PD for auditing, e.g. when recovering from a disaster. The cluster and component annotations are set in the volume claim templates, which only take effect on a new StatefulSet, so all of them are patched to the bound PVCs too. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"requests": {
+					"safeScaleOut": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. Requests cannot exceed Limits. More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "SafeScaleOut defers adding a new PD member until all existing members of the cluster are healthy, since a new member joining an unstable PD cluster may worsen it. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"claims": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"name",
-								},
-								"x-kubernetes-list-type": "map",
-							},
+					"replicationMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicationMode is mapped into the `replication-mode` config of PD, which is required by the DR auto-sync deployment across two data centers. PD switches the state of the DR auto-sync mode by itself, so this mostly matters for the initial deployment. The items in the config of PD are kept, and it needs `config` or `configFrom` to be set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationModeConfig"),
 						},
+					},
+					"readinessSidecar": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Claims lists the names of resources, defined in spec.resourceClaims, that are used by this container.\n\nThis is an alpha field and requires enabling the DynamicResourceAllocation feature gate.\n\nThis field is immutable. It can only be set for containers.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.ResourceClaim"),
-									},
-								},
-							},
+							Description: "ReadinessSidecar is the HTTP endpoint of a health aggregator sidecar. If it is set, the readiness probe of PD requests the endpoint instead of checking PD, whatever the type of `.spec.pd.readinessProbe` is, so that the readiness of PD follows the verdict of the sidecar. The sidecar is added by `.spec.pd.additionalContainers`.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReadinessSidecar"),
 						},
 					},
-					"serviceAccount": {
+					"regionMerge": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specify a Service Account for pd",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "RegionMerge is mapped into the region merge config in `schedule` of PD. The items are rendered into the config file of PD when `config` or `configFrom` is set, without overriding the ones already there. With `hotReload`, they're also set on the running PD cluster, so tuning the merge needs no restart.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionMergeConfig"),
 						},
 					},
-					"replicas": {
+					"maintenanceToleration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The desired ready replicas",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "MaintenanceToleration makes PD tolerate the taint added to the nodes during the node maintenance with the effect `NoExecute`, in addition to `tolerations`, so that PD isn't evicted in a planned window.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMaintenanceToleration"),
 						},
 					},
-					"baseImage": {
+					"disableDashboard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
-							Default:     "",
-							Type:        []string{"string"},
+							Description: "DisableDashboard disables TiDB Dashboard on all PD members by setting `pd-server.dashboard-address` of PD to `none`, e.g. when the dashboard is deployed separately. PD before v4.0.0 has no dashboard, so it's ignored for them. An address from `dashboardAddress` or the config of PD is kept, and nothing is rendered unless `config` or `configFrom` is set. Optional: Defaults to false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"service": {
+					"minResolvedTSPersistenceInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Service defines a Kubernetes service of PD cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec"),
+							Description: "MinResolvedTSPersistenceInterval is mapped to `pd-server.min-resolved-ts-persistence-interval` of PD, which is the interval to persist the min resolved ts of the cluster, e.g. `1s`, `0s` disables it. The persisted value is what the stale reads and TiCDC see, so a shorter interval keeps it fresher. It's written to the config file of PD and follows `configUpdateStrategy` when it changes. The item in `config` or `configFrom` is kept if present, and it's not rendered if neither of them is set.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"maxFailoverCount": {
+					"clockSkewThreshold": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ClockSkewThreshold is the max tolerated offset between the clock of a PD member and the one of the operator, e.g. `1s`, the `PDClockSkew` condition is set if it is exceeded by any healthy member. The clock of PD is read from the `Date` header of its HTTP API on a best-effort basis, so the offset is only accurate to about one second. Optional: Defaults to nil, which disables the check",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"storageClassName": {
+					"preferIPv6": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for PD data storage. Defaults to Kubernetes default storage class.",
-							Type:        []string{"string"},
+							Description: "PreferIPv6 indicates whether to prefer IPv6 addresses for PD, which takes precedence over `.spec.preferIPv6` for the services of PD and the listen addresses in the start script of PD. Optional: Defaults to `.spec.preferIPv6`",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"storageVolumes": {
+					"labelProperties": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageVolumes configure additional storage for PD pods.",
+							Description: "LabelProperties are the label properties of PD reconciled via PD, e.g. the stores with a label are rejected to have leaders by the property of type `reject-leader`. The operator only deletes the properties it applied, the ones set by others are kept.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLabelProperty"),
 									},
 								},
 							},
 						},
 					},
-					"dataSubDir": {
+					"postStartWaitForJoin": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Subdirectory within the volume to store PD Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/pd. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/pd/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
+							Description: "PostStartWaitForJoin indicates whether to add a postStart hook to the PD container which blocks until the pod appears in the member list of PD, so the container is reported started only after PD joins the cluster. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"peerURLScheme": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PeerURLScheme overrides the scheme of the peer URLs of PD in the start script, which is derived from `.spec.tlsCluster.enabled` by default. It allows to switch the scheme of the peer URLs independently of the client URLs during a staged TLS rollout. Optional: Defaults to the scheme of the cluster",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"config": {
+					"tsoSaveInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Config is the Configuration of pd-servers",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper"),
+							Description: "TSOSaveInterval is mapped to `tso-save-interval` of PD, which is the interval to save the timestamp window of TSO to etcd, e.g. `3s`. A new PD leader starts allocating after the saved window, so a longer interval saves writes to etcd but skips more timestamps on a leader change. `tso-save-interval` in the config of PD is used instead if present, and it requires `config` or `configFrom`.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"tlsClientSecretName": {
+					"tsoUpdatePhysicalInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate which used by Dashboard.",
+							Description: "TSOUpdatePhysicalInterval is mapped to `tso-update-physical-interval` of PD, which is the interval to update the physical part of TSO, e.g. `50ms`, it must be between `1ms` and `10s`. A shorter interval lets PD allocate more timestamps per second at the cost of more CPU. Like the other TSO items, it's only rendered with `config` or `configFrom`, and the key in the config of PD is left untouched.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enableDashboardInternalProxy": {
+					"projectedVolumeMounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "(Deprecated) EnableDashboardInternalProxy would directly set `internal-proxy` in the `PdConfig`. Note that this is deprecated, we should just set `dashboard.internal-proxy` in `pd.config`.",
+							Description: "ProjectedVolumeMounts indicates whether to combine the config, startup script and TLS volumes of PD into one projected volume to reduce the number of volumes of the pod, e.g. on nodes with volume limits. The projected volume is mounted at `/var/lib/pd-projected`, and the files of each volume are in the directory named after the volume instead of the original mount path, e.g. `/var/lib/pd-projected/pd-tls`. The config and the TLS paths of PD are set to them, and the updates of the sources are seen by PD. Optional: Defaults to false",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"mountClusterClientSecret": {
+					"checkMemberClusterID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MountClusterClientSecret indicates whether to mount `cluster-client-secret` to the Pod",
+							Description: "CheckMemberClusterID indicates whether to check the cluster ID reported by each healthy PD member against the one of the cluster, the members with a different cluster ID are recorded in `.status.pd.mismatchedClusterIDMembers` and reported with a Warning event. Optional: Defaults to false",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"startUpScriptVersion": {
+					"enableGRPCGateway": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Start up script version",
-							Type:        []string{"string"},
+							Description: "EnableGRPCGateway is mapped to `enable-grpc-gateway` of PD, which enables the HTTP gateway of the gRPC API of PD. A Warning event is emitted if it's disabled, as the operator checks the health of PD via its HTTP API. `enable-grpc-gateway` in the config of PD overrides it, also for the warning, and it's only rendered when `config` or `configFrom` is set.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"startTimeout": {
+					"startupStaggerSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Timeout threshold when pd get started",
+							Description: "StartupStaggerSeconds staggers the startup of the PD pods to avoid DNS and discovery storms when many pods are restarted at once, each pod sleeps `ordinal % startupStaggerSeconds` seconds in the start script before waiting for its domain. Optional: Defaults to 0, which disables the stagger",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
-					"initWaitTime": {
+					"hotRegionScheduleLimit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Wait time before pd get started. This wait time is to allow the new DNS record to propagate, ensuring that the PD DNS resolves to the same IP address as the pod.",
+							Description: "HotRegionScheduleLimit is mapped to `schedule.hot-region-schedule-limit` of PD, which limits the number of the coexisting hot region schedules. The operator sets it on the running PD cluster via the config API of PD on each sync, so raising it to speed up the hot region scheduling needs no restart. It's left alone if `schedule.hot-region-schedule-limit` is in `config` or `configFrom`.",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
-					"mode": {
+					"splitMergeInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Mode is the mode of PD cluster",
+							Description: "SplitMergeInterval is mapped to `schedule.split-merge-interval` of PD, which is the minimum interval to permit merging a region after it's split, e.g. 1h. Like `hotRegionScheduleLimit`, it's pushed to the running PD cluster on each sync rather than only rendered into the config file, unless `schedule.split-merge-interval` is in the config of PD, which PD then reads at startup instead.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"spareVolReplaceReplicas": {
+					"validateConfigSchema": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The default number of spare replicas to scale up when using VolumeReplace feature. In multi-az deployments with topology spread constraints you may need to set this to number of zones to avoid zone skew after volume replace (total replicas always whole multiples of zones). Optional: Defaults to 1",
+							Description: "ValidateConfigSchema makes the operator validate the config of PD against a JSON schema listing the keys known to PD v6 to v8 before rolling it out, which catches the unknown keys such as `replication.max-replica`. The schema is shared by these versions, so a key added in a later version is not rejected for an earlier one. An invalid config is rejected with a Warning event. Nothing is validated for other versions of PD. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"serviceAccountTokenAudience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountTokenAudience adds a projected service account token with the audience into the PD pods, which is mounted at /var/run/secrets/tokens/token for the workload identity integrations. The token is rotated by the kubelet before it expires.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"flushMetricsOnShutdown": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlushMetricsOnShutdown adds a step to the preStop hook of PD which pushes the final metrics of PD to a Prometheus Pushgateway before PD is terminated, so that the metrics of the last moment since the last scrape are not lost. It runs after the other preStop steps, if any.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDFlushMetricsConfig"),
+						},
+					},
+					"dashboardPublicPathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DashboardPublicPathPrefix is mapped to `dashboard.public-path-prefix` of PD, which is the path prefix of the dashboard when it is served behind a reverse proxy or an ingress at a subpath, e.g. `/pd-dashboard`. It must match the path forwarded by the proxy. `dashboard.public-path-prefix` in the config of PD is used instead if it's set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failoverReadinessInitialDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailoverReadinessInitialDelaySeconds is the initial delay of readiness of the failover pods of PD, which bootstrap into the existing cluster and may need more time to be ready than the other pods. The probes of the pods can't be changed once they are created, so a readiness gate of the condition `pingcap.com/PDFailoverReady` is added to the pods instead, which is set by the operator once the PD container of a failover pod has been running for the seconds, and at once for the other pods. Changing it between set and unset rolls the pods of PD.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxStorageUtilizationForScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxStorageUtilizationForScale is the percentage of the used capacity of all stores reported by PD, the scale-out of PD is refused with an event if it is exceeded, so that no members are added to a cluster whose storage is about to run out. Optional: Defaults to nil, which disables the check",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
+					"enableDiagnostic": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableDiagnostic is mapped to `schedule.enable-diagnostic` of PD, which enables the diagnostic of the schedulers of PD, e.g. the result of `pd-ctl scheduler describe`, to help troubleshoot the scheduling. Toggling it renders a new config file of PD, which is picked up per `configUpdateStrategy`. It can be overridden by `schedule.enable-diagnostic` in the config of PD, and needs `config` or `configFrom`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceClaim", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDAuditConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDFlushMetricsConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLabelProperty", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMaintenanceToleration", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNetworkPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReadinessSidecar", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionLabelRule", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRegionMergeConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationModeConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulers", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLimits", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ConfigMapKeySelector", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ProjectedVolumeSource", "k8s.io/api/core/v1.ResourceClaim", "k8s.io/api/core/v1.SeccompProfile", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -6976,6 +8027,33 @@ func schema_pkg_apis_pingcap_v1alpha1_PDStoreLabel(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_PDStoreLimits(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDStoreLimits is the store limits of all stores in the cluster",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"addPeer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddPeer is the rate limit of adding peers of each store, in peers per minute. Not reconciled if not set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"removePeer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovePeer is the rate limit of removing peers of each store, in peers per minute. Not reconciled if not set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_Performance(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -7249,6 +8327,13 @@ func schema_pkg_apis_pingcap_v1alpha1_Probe(ref common.ReferenceCallback) common
 							Format:      "int32",
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of seconds after which the probe times out. Default to Kubernetes default (1 second). Minimum value is 1. Only PD supports it for now.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -8734,6 +9819,29 @@ func schema_pkg_apis_pingcap_v1alpha1_ServiceSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"externalIPs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalIPs is the externalIPs of service, the traffic ingressing into the cluster with these IPs as the destination will be routed to the service endpoints. It's usually used to expose the service in bare-metal environments without load balancers. Optional: Defaults to omitted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"internalTrafficPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InternalTrafficPolicy is the internalTrafficPolicy of service, `Local` routes the traffic from inside the cluster only to the endpoints on the same node as the client.\n\nNOTE: only used for PD\n\nOptional: Defaults to omitted\n\nPossible enum values:\n - `\"Cluster\"` routes traffic to all endpoints.\n - `\"Local\"` routes traffic only to endpoints on the same node as the client pod (dropping the traffic if there are no local endpoints).",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"Cluster", "Local"},
+						},
+					},
 				},
 			},
 		},
@@ -13436,6 +14544,20 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 							Format:      "int32",
 						},
 					},
+					"pdHeartbeatInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PDHeartbeatInterval is mapped to `raftstore.pd-heartbeat-tick-interval` of TiKV, which is the interval of the region heartbeats reported to PD, e.g. `1m`. A longer interval reduces the load of PD in a large cluster at the cost of the freshness of the region info in PD. TiKV reads it from its config file, so it needs `.spec.tikv.config` to be set, doesn't replace the same item there, and reaches TiKV the way the other config changes of TiKV do.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pdStoreHeartbeatInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PDStoreHeartbeatInterval is mapped to `raftstore.pd-store-heartbeat-tick-interval` of TiKV, which is the interval of the store heartbeats reported to PD, e.g. `10s`. PD tells the stores down by their heartbeats, so a long interval delays that. `raftstore.pd-store-heartbeat-tick-interval` in `.spec.tikv.config` wins over it, and nothing is rendered unless `.spec.tikv.config` is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"replicas"},
 			},
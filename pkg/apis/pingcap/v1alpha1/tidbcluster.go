@@ -47,6 +47,8 @@ const (
 	defaultTiCDCGracefulShutdownTimeout = 10 * time.Minute
 	defaultPDStartTimeout               = 30
 	defaultPDInitWaitTime               = 0
+	defaultPDLogMaxSize                 = 300
+	defaultPDLogMaxBackups              = 3
 
 	// the latest version
 	versionLatest = "latest"
@@ -653,7 +655,15 @@ func (tc *TidbCluster) PDStsDesiredOrdinals(excludeFailover bool) sets.Int32 {
 	if !excludeFailover {
 		replicas = tc.PDStsDesiredReplicas()
 	}
-	return GetPodOrdinalsFromReplicasAndDeleteSlots(replicas, tc.getDeleteSlots(label.PDLabelVal))
+	ordinals := GetPodOrdinalsFromReplicasAndDeleteSlots(replicas, tc.getDeleteSlots(label.PDLabelVal))
+	if start := tc.PDOrdinalsStart(); start > 0 {
+		shifted := sets.NewInt32()
+		for ordinal := range ordinals {
+			shifted.Insert(ordinal + start)
+		}
+		return shifted
+	}
+	return ordinals
 }
 
 func (tc *TidbCluster) PDMSStsDesiredReplicas(componentName string) int32 {
@@ -1394,3 +1404,156 @@ func (tc *TidbCluster) PDInitWaitTime() int {
 	}
 	return defaultPDInitWaitTime
 }
+
+// PDFSGroupChangePolicy returns the fsGroupChangePolicy applied to PD pods when fsGroup is set,
+// nil is returned if it's not set so that the default policy of Kubernetes applies.
+func (tc *TidbCluster) PDFSGroupChangePolicy() *corev1.PodFSGroupChangePolicy {
+	if tc.Spec.PD == nil || tc.Spec.PD.FSGroupChangePolicy == nil {
+		return nil
+	}
+	policy := *tc.Spec.PD.FSGroupChangePolicy
+	return &policy
+}
+
+// PDLeaderServiceEnabled returns whether the PD leader service is enabled
+func (tc *TidbCluster) PDLeaderServiceEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.EnableLeaderService != nil && *tc.Spec.PD.EnableLeaderService
+}
+
+// GetMaxSize returns the maximum size in megabytes of the PD log file before it gets rotated
+func (s *PDLogTailerSpec) GetMaxSize() int32 {
+	if s.MaxSize == nil {
+		return defaultPDLogMaxSize
+	}
+	return *s.MaxSize
+}
+
+// GetMaxBackups returns the maximum number of rotated PD log files to retain
+func (s *PDLogTailerSpec) GetMaxBackups() int32 {
+	if s.MaxBackups == nil {
+		return defaultPDLogMaxBackups
+	}
+	return *s.MaxBackups
+}
+
+// PDWaitForDNSEnabled returns whether PD waits for its DNS record to be resolvable before starting
+func (tc *TidbCluster) PDWaitForDNSEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.WaitForDNS != nil && *tc.Spec.PD.WaitForDNS
+}
+
+// PDOrdinalsStart returns the start index of the ordinals of PD pods
+func (tc *TidbCluster) PDOrdinalsStart() int32 {
+	if tc.Spec.PD == nil || tc.Spec.PD.OrdinalsStart == nil {
+		return 0
+	}
+	return *tc.Spec.PD.OrdinalsStart
+}
+
+// PDDeletionProtectionEnabled returns whether the PVCs of PD are protected from deletion
+func (tc *TidbCluster) PDDeletionProtectionEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.DeletionProtection != nil && *tc.Spec.PD.DeletionProtection
+}
+
+// PDExportEffectiveConfigEnabled returns whether the effective config of PD is exported to a ConfigMap
+func (tc *TidbCluster) PDExportEffectiveConfigEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.ExportEffectiveConfig != nil && *tc.Spec.PD.ExportEffectiveConfig
+}
+
+// PDCheckVolumeWritableEnabled returns whether the data volume of PD is checked to be writable before PD starts
+func (tc *TidbCluster) PDCheckVolumeWritableEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.CheckVolumeWritable != nil && *tc.Spec.PD.CheckVolumeWritable
+}
+
+// PDInitialClusterToken returns the initial cluster token of PD, which is derived from the UID of
+// the cluster if it's not set. An empty string is returned if the UID is unknown.
+func (tc *TidbCluster) PDInitialClusterToken() string {
+	if tc.Spec.PD != nil && tc.Spec.PD.InitialClusterToken != nil {
+		return *tc.Spec.PD.InitialClusterToken
+	}
+	if tc.UID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", tc.Name, tc.UID)
+}
+
+// PDAutoTuneSchedulerLimitsEnabled returns whether the scheduler limits of PD are computed from the number of stores
+func (tc *TidbCluster) PDAutoTuneSchedulerLimitsEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.AutoTuneSchedulerLimits != nil && *tc.Spec.PD.AutoTuneSchedulerLimits
+}
+
+// PDContainerName returns the name of the PD container in PD pods
+func (tc *TidbCluster) PDContainerName() string {
+	if tc.Spec.PD != nil && tc.Spec.PD.ContainerName != nil && *tc.Spec.PD.ContainerName != "" {
+		return *tc.Spec.PD.ContainerName
+	}
+	return PDMemberType.String()
+}
+
+// PDUpgradeStabilizationChecks returns the number of consecutive syncs in which all PD members must be
+// healthy after the rollout of an upgrade completes.
+func (tc *TidbCluster) PDUpgradeStabilizationChecks() int32 {
+	if tc.Spec.PD == nil || tc.Spec.PD.UpgradeStabilizationChecks == nil {
+		return 0
+	}
+	return *tc.Spec.PD.UpgradeStabilizationChecks
+}
+
+// PDNetworkPolicyEnabled returns whether the NetworkPolicy of PD is generated
+func (tc *TidbCluster) PDNetworkPolicyEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.NetworkPolicy != nil &&
+		tc.Spec.PD.NetworkPolicy.Enabled != nil && *tc.Spec.PD.NetworkPolicy.Enabled
+}
+
+// PDPprofServiceEnabled returns whether the pprof service of PD is enabled
+func (tc *TidbCluster) PDPprofServiceEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.PprofPort != nil &&
+		tc.Spec.PD.EnablePprofService != nil && *tc.Spec.PD.EnablePprofService
+}
+
+// PDProjectedVolumeMountsEnabled returns whether the config, startup script and TLS volumes of PD are combined
+// into one projected volume
+func (tc *TidbCluster) PDProjectedVolumeMountsEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.ProjectedVolumeMounts != nil && *tc.Spec.PD.ProjectedVolumeMounts
+}
+
+// PDParallelBootstrapEnabled returns whether the Parallel pod management policy of PD is only used for bootstrap
+func (tc *TidbCluster) PDParallelBootstrapEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.ParallelBootstrap != nil && *tc.Spec.PD.ParallelBootstrap
+}
+
+// PDTopologyAwareRoutingEnabled returns whether the topology aware routing of the PD service is enabled
+func (tc *TidbCluster) PDTopologyAwareRoutingEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.TopologyAwareRouting != nil && *tc.Spec.PD.TopologyAwareRouting
+}
+
+// PDPVCAuditAnnotationsEnabled returns whether the PVCs of PD are annotated with the cluster, component and pod ordinal
+func (tc *TidbCluster) PDPVCAuditAnnotationsEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.PVCAuditAnnotations != nil && *tc.Spec.PD.PVCAuditAnnotations
+}
+
+// PDSafeScaleOutEnabled returns whether scaling out PD is deferred until all existing members are healthy
+func (tc *TidbCluster) PDSafeScaleOutEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.SafeScaleOut != nil && *tc.Spec.PD.SafeScaleOut
+}
+
+// PDRegionMergeHotReloadEnabled returns whether the region merge config of PD is applied to the running PD cluster
+func (tc *TidbCluster) PDRegionMergeHotReloadEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.RegionMerge != nil &&
+		tc.Spec.PD.RegionMerge.HotReload != nil && *tc.Spec.PD.RegionMerge.HotReload
+}
+
+// PDPreferIPv6 returns whether to prefer IPv6 addresses for PD, `.spec.pd.preferIPv6` takes precedence over `.spec.preferIPv6`
+func (tc *TidbCluster) PDPreferIPv6() bool {
+	if tc.Spec.PD != nil && tc.Spec.PD.PreferIPv6 != nil {
+		return *tc.Spec.PD.PreferIPv6
+	}
+	return tc.Spec.PreferIPv6
+}
+
+// PDPeerScheme returns the scheme of the peer URLs of PD, `.spec.pd.peerURLScheme` takes precedence over the scheme of the cluster
+func (tc *TidbCluster) PDPeerScheme() string {
+	if tc.Spec.PD != nil && tc.Spec.PD.PeerURLScheme != nil {
+		return *tc.Spec.PD.PeerURLScheme
+	}
+	return tc.Scheme()
+}
@@ -60,6 +60,7 @@ const (
 	ContainerSlowLogTailer    ContainerName = "slowlog"
 	ContainerRocksDBLogTailer ContainerName = "rocksdblog"
 	ContainerRaftLogTailer    ContainerName = "raftlog"
+	ContainerPDLogTailer      ContainerName = "pdlog"
 )
 
 // MemberType represents member type
@@ -478,8 +479,22 @@ const (
 const (
 	// ComponentVolumeResizing indicates that any volume of this component is resizing.
 	ComponentVolumeResizing string = "ComponentVolumeResizing"
+	// PDDiskPressure indicates that the disk usage of any PD member exceeds the threshold.
+	PDDiskPressure string = "PDDiskPressure"
+	// PDClockSkew indicates that the clock offset of any PD member exceeds the threshold.
+	PDClockSkew string = "PDClockSkew"
+	// PDMaxReplicasExceeded indicates that the max-replicas of PD exceeds the failure domains of the up TiKV stores.
+	PDMaxReplicasExceeded string = "PDMaxReplicasExceeded"
 )
 
+// PDMemberHealthy is the type of the pod condition set by the operator on the pods of PD,
+// which reflects the health of the PD member in `.status.pd.members`.
+const PDMemberHealthy corev1.PodConditionType = "pingcap.com/PDMemberHealthy"
+
+// PDFailoverReady is the type of the pod condition set by the operator on the pods of PD, which is used as
+// a readiness gate to delay the readiness of the failover pods of PD.
+const PDFailoverReady corev1.PodConditionType = "pingcap.com/PDFailoverReady"
+
 // +k8s:openapi-gen=true
 // DiscoverySpec contains details of Discovery members
 type DiscoverySpec struct {
@@ -589,6 +604,551 @@ type PDSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	SpareVolReplaceReplicas *int32 `json:"spareVolReplaceReplicas,omitempty"`
+
+	// FSGroupChangePolicy defines how the ownership and permission of the PD data volume
+	// is changed when `fsGroup` is set in the pod security context.
+	// It only takes effect when `podSecurityContext.fsGroup` is set and
+	// `podSecurityContext.fsGroupChangePolicy` is not set.
+	// Optional: Defaults to nil, which leaves it to the default of Kubernetes, i.e. Always
+	// +kubebuilder:validation:Enum:="OnRootMismatch";"Always"
+	// +optional
+	FSGroupChangePolicy *corev1.PodFSGroupChangePolicy `json:"fsGroupChangePolicy,omitempty"`
+
+	// PrometheusScrapeAnnotations are merged into the annotations of PD pods to configure
+	// how Prometheus scrapes PD, e.g. `prometheus.io/path` overrides the default `/metrics` path.
+	// They take precedence over the default `prometheus.io/*` annotations.
+	// +optional
+	PrometheusScrapeAnnotations map[string]string `json:"prometheusScrapeAnnotations,omitempty"`
+
+	// RunAsUser is the UID to run the entrypoint of the PD container.
+	// It is set in the security context of the PD container and takes precedence over
+	// `podSecurityContext.runAsUser` for the PD container only.
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// RunAsGroup is the GID to run the entrypoint of the PD container.
+	// It is set in the security context of the PD container and takes precedence over
+	// `podSecurityContext.runAsGroup` for the PD container only.
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// EnableLeaderService makes the operator maintain a `<cluster>-pd-leader` service whose
+	// endpoints only contain the current PD leader. The leader pod is labeled by the operator
+	// and the label is used as the selector of the service.
+	// Optional: Defaults to false
+	// +optional
+	EnableLeaderService *bool `json:"enableLeaderService,omitempty"`
+
+	// ConfigFrom references a key of an existing ConfigMap in the same namespace whose content
+	// is used as the TOML configuration of pd-servers. It is mutually exclusive with `config`.
+	// Changes of the referenced content are picked up on the next sync and roll PD like changes of `config`.
+	// +optional
+	ConfigFrom *corev1.ConfigMapKeySelector `json:"configFrom,omitempty"`
+
+	// LogTailer enables a log tailer sidecar for PD. When set, PD writes its log to a file
+	// in a shared emptyDir volume and rotates it by size, and the sidecar tails the file to stdout.
+	// It only takes effect when `config` or `configFrom` is set.
+	// +optional
+	LogTailer *PDLogTailerSpec `json:"logTailer,omitempty"`
+
+	// StoreLimits is the store limits applied to all stores via PD.
+	// The operator compares them with the limits in PD on each sync and applies them on drift.
+	// +optional
+	StoreLimits *PDStoreLimits `json:"storeLimits,omitempty"`
+
+	// WaitForDNS adds an init container using the helper image, which blocks the start of PD
+	// until the DNS record of the pod in the peer service can be resolved.
+	// Optional: Defaults to false
+	// +optional
+	WaitForDNS *bool `json:"waitForDNS,omitempty"`
+
+	// PreferredLeader is the PD member, specified by the member name or the ordinal of the pod,
+	// that PD prefers as the leader. The operator sets the leader priority of the member above
+	// all other members, and PD transfers the leader to it whenever it is healthy. The priority is kept
+	// after the field is unset.
+	// +optional
+	PreferredLeader string `json:"preferredLeader,omitempty"`
+
+	// OrdinalsStart is the start index of the ordinals of PD pods, which sets `.spec.ordinals.start`
+	// of the StatefulSet. It requires Kubernetes v1.27+ and is not supported by the advanced StatefulSet.
+	// It can't be changed after the cluster is created.
+	// Optional: Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OrdinalsStart *int32 `json:"ordinalsStart,omitempty"`
+
+	// AuditConfig is mapped into the audit related config of PD, i.e. `security.redact-info-log` and the
+	// audit of the HTTP API in `pd-server`. An item is left as is if it's already set in the config of PD.
+	// It's rendered into the config file, so nothing happens unless `config` or `configFrom` is set.
+	// +optional
+	AuditConfig *PDAuditConfig `json:"auditConfig,omitempty"`
+
+	// DeletionProtection adds a finalizer to the PVCs of PD to prevent them from being deleted,
+	// e.g. before a backup completes. The finalizer is removed after the deletion is confirmed by
+	// setting the annotation `tidb.pingcap.com/pd-pvc-deletion-confirmed` to `true` on the TidbCluster,
+	// or the protection is disabled. The PVCs deleted by the failover or the scale-in of PD aren't
+	// protected, and the finalizer is also added to the TidbCluster to release the PVCs when it's deleted.
+	// Optional: Defaults to false
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// ProbePort is the port targeted by the tcp readiness probe of PD, e.g. the health port of
+	// a sidecar proxy fronting PD.
+	// Optional: Defaults to the client port of PD
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ProbePort *int32 `json:"probePort,omitempty"`
+
+	// LocationLabels is mapped to `replication.location-labels` of PD, which drives the topology-aware
+	// replication. The store labels of TiKV and TiFlash are set from the node labels with the same keys,
+	// and the short names `region`, `zone` and `host` are also matched with the well-known node labels.
+	// The store labels are set in any case, while the PD side is only rendered with `config` or `configFrom`,
+	// and `replication.location-labels` in the config of PD is kept if it's there.
+	// +optional
+	LocationLabels []string `json:"locationLabels,omitempty"`
+
+	// RuntimeClassName is the name of the RuntimeClass used to run PD pods. The overhead defined
+	// by the RuntimeClass is set to the pods by the RuntimeClass admission controller.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Overhead is the resource overhead of PD pods, which is accounted by the scheduler
+	// in addition to the requests of the containers. It must be identical to the overhead
+	// defined by the RuntimeClass if the RuntimeClass admission controller is enabled.
+	// +optional
+	Overhead corev1.ResourceList `json:"overhead,omitempty"`
+
+	// ExportEffectiveConfig exports the config file of PD rendered by the operator, which includes
+	// the injected items such as the TLS paths, to the ConfigMap `<cluster>-pd-effective-config`
+	// for debugging. The ConfigMap is overwritten on each sync and deleted when it's disabled.
+	// It only takes effect when `config` or `configFrom` is set.
+	// Optional: Defaults to false
+	// +optional
+	ExportEffectiveConfig *bool `json:"exportEffectiveConfig,omitempty"`
+
+	// CheckVolumeWritable adds an init container using the helper image, which writes to the data volume
+	// once before PD starts, and fails within 30 seconds with a clear message if the write fails or hangs,
+	// e.g. when a PD pod recreated during failover gets a volume not writable by the user of PD. It doesn't
+	// wait for the volume, which is always mounted before the init containers start. The check runs as
+	// `runAsUser` and `runAsGroup` of PD if they're set, otherwise as the user of the pod or the helper image.
+	// Optional: Defaults to false
+	// +optional
+	CheckVolumeWritable *bool `json:"checkVolumeWritable,omitempty"`
+
+	// InitialClusterToken is mapped to `initial-cluster-token` of PD, which prevents the PD members from
+	// joining the PD cluster of another TidbCluster by mistake. PD only reads it when the PD cluster is
+	// bootstrapped, so changing it later has no effect on a running cluster. It needs `config` or `configFrom`
+	// to be set, and `initial-cluster-token` in the config of PD wins over it.
+	// Optional: Defaults to `<cluster>-<uid of the cluster>`
+	// +optional
+	InitialClusterToken *string `json:"initialClusterToken,omitempty"`
+
+	// AutoTuneSchedulerLimits makes the operator compute `leader-schedule-limit` and `region-schedule-limit`
+	// of PD from the number of up stores, and apply them via PD on each sync, so that the limits grow with
+	// the cluster. The limits set in `.spec.pd.config` or via pd-ctl are overridden when it's enabled.
+	// Optional: Defaults to false
+	// +optional
+	AutoTuneSchedulerLimits *bool `json:"autoTuneSchedulerLimits,omitempty"`
+
+	// RegionLabelRules are the key-range based region label rules reconciled via PD, which can be used to
+	// schedule the regions of a key range by the labels, e.g. to isolate the data of tenants.
+	// The operator only updates or deletes the rules it applied, the rules created by others are kept.
+	// +optional
+	RegionLabelRules []PDRegionLabelRule `json:"regionLabelRules,omitempty"`
+
+	// ContainerName is the name of the PD container in PD pods, which can be used by the tooling
+	// keying off container names. Changing it triggers a rolling update of PD, and the additional
+	// containers patching the PD container must use the same name.
+	// Optional: Defaults to `pd`
+	// +optional
+	ContainerName *string `json:"containerName,omitempty"`
+
+	// MetricStorage is mapped to `pd-server.metric-storage` of PD, which is the address of the Prometheus
+	// compatible storage of the cluster metrics, e.g. `http://prometheus:9090`, used by TiDB Dashboard.
+	// It's ignored if `pd-server.metric-storage` is in the config of PD, or if neither `config` nor
+	// `configFrom` is set.
+	// +optional
+	MetricStorage *string `json:"metricStorage,omitempty"`
+
+	// DashboardAddress is mapped to `pd-server.dashboard-address` of PD, which is the address TiDB Dashboard
+	// is served on, it can be `auto`, `none` or a URL of a PD member. Pinning it to a member keeps the
+	// dashboard on that member across the leader changes. It wins over `disableDashboard`, but not over
+	// `pd-server.dashboard-address` in the config of PD, and it's rendered only with `config` or `configFrom`.
+	// +optional
+	DashboardAddress *string `json:"dashboardAddress,omitempty"`
+
+	// DiskPressureThreshold is the percentage of the used capacity of the volumes of a PD member, the
+	// `PDDiskPressure` condition is set if it is reached by any member. The usage is fetched from the
+	// kubelet through the node proxy of the API server on a best-effort basis, which is only done if the
+	// operator is started with `--pd-disk-usage-check` and has the `get` permission of `nodes/proxy`.
+	// Optional: Defaults to nil, which disables the check
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	DiskPressureThreshold *int32 `json:"diskPressureThreshold,omitempty"`
+
+	// MaxGRPCMessageSize is the max size of the gRPC messages sent and received by PD, e.g. `16Mi`, which is mapped to
+	// `pd-server.max-grpc-send-msg-size` and `pd-server.max-grpc-recv-msg-size` of PD in bytes. Raise it if the
+	// clients fail with `ResourceExhausted` on large responses, e.g. in a cluster with a huge number of regions.
+	// Each of the two keys set in the config of PD is kept, and nothing is rendered without `config` or `configFrom`.
+	// +optional
+	MaxGRPCMessageSize *string `json:"maxGRPCMessageSize,omitempty"`
+
+	// DashboardTiDBCAProjected is a projected volume which combines the CA trust bundle used by TiDB Dashboard
+	// to verify TiDB, e.g. from several ConfigMaps and Secrets. The bundle must be projected to `ca.crt`.
+	// It overrides the CA in the TiDB client secret and only takes effect when TLS between TiDB and its
+	// clients is enabled and `.spec.tidb.tlsClient.skipInternalClientCA` is false.
+	// +optional
+	DashboardTiDBCAProjected *corev1.ProjectedVolumeSource `json:"dashboardTiDBCAProjected,omitempty"`
+
+	// UpgradeStabilizationChecks is the number of consecutive syncs in which all PD members must be healthy
+	// after the rollout of an upgrade completes, before the phase of PD leaves `Upgrade`.
+	// Optional: Defaults to 0, which means the phase leaves `Upgrade` once the rollout completes
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UpgradeStabilizationChecks *int32 `json:"upgradeStabilizationChecks,omitempty"`
+
+	// NetworkPolicy describes the NetworkPolicy generated for PD, which only allows the members of the
+	// cluster and the configured peers to reach PD.
+	// +optional
+	NetworkPolicy *PDNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// EnablePlacementRules is reconciled to `replication.enable-placement-rules` of the running PD cluster.
+	// Enabling is always applied, while disabling may drop the placement rules and is only applied when
+	// the annotation `pingcap.com/pd-allow-disable-placement-rules` of the cluster is "true".
+	// Optional: Defaults to nil, which means the operator does not reconcile it
+	// +optional
+	EnablePlacementRules *bool `json:"enablePlacementRules,omitempty"`
+
+	// PprofPort is the port of the internal-only `<cluster>-pd-pprof` service of PD, which forwards to the
+	// client port where PD serves pprof under `/debug/pprof`. PD can't serve pprof on another port, so
+	// pprof is still reachable through the client service of PD.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	PprofPort *int32 `json:"pprofPort,omitempty"`
+
+	// EnablePprofService makes the operator maintain the `<cluster>-pd-pprof` ClusterIP service on
+	// `.spec.pd.pprofPort`, so that pprof is reachable inside Kubernetes even if the client service of PD
+	// is exposed outside with another type. The service requires `.spec.pd.pprofPort`, and it's deleted
+	// when it's disabled.
+	// Optional: Defaults to false
+	// +optional
+	EnablePprofService *bool `json:"enablePprofService,omitempty"`
+
+	// Schedulers are the schedulers of PD reconciled via PD, e.g. `balance-leader-scheduler`.
+	// The schedulers in `add` are added if absent and the ones in `remove` are removed if present,
+	// the others are left untouched.
+	// +optional
+	Schedulers *PDSchedulers `json:"schedulers,omitempty"`
+
+	// ParallelBootstrap makes the StatefulSet of PD use the `Parallel` pod management policy only when it's
+	// created for a cluster not bootstrapped yet, and `OrderedReady` otherwise. It overrides `podManagementPolicy`.
+	// The policy of an existing StatefulSet is immutable, so it's kept as is. To switch a bootstrapped cluster to
+	// `OrderedReady`, delete the StatefulSet of PD with `--cascade=orphan` and the operator recreates it.
+	// Optional: Defaults to false
+	// +optional
+	ParallelBootstrap *bool `json:"parallelBootstrap,omitempty"`
+
+	// DisableTelemetry disables the telemetry of TiDB Dashboard by setting `dashboard.enable-telemetry` to false
+	// and `dashboard.disable-telemetry` to true in the config of PD, unless they are set explicitly.
+	// Optional: Defaults to false
+	// +optional
+	DisableTelemetry *bool `json:"disableTelemetry,omitempty"`
+
+	// TopologyAwareRouting enables the topology aware routing of the PD service, so that the clients prefer
+	// the PD members in the same zone. The annotations set in `.spec.pd.service.annotations` take precedence.
+	// Disabling it does not remove the annotations from the existing service.
+	// Optional: Defaults to false
+	// +optional
+	TopologyAwareRouting *bool `json:"topologyAwareRouting,omitempty"`
+
+	// SeccompProfile is the seccomp profile of the PD pods, e.g. `RuntimeDefault`.
+	// It's set in the pod security context and applies to all containers of the pod.
+	// `podSecurityContext.seccompProfile` takes precedence if it's set.
+	// +optional
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+
+	// PVCAuditAnnotations adds the annotations identifying the cluster, the component and the pod ordinal
+	// to the PVCs of PD for auditing, e.g. when recovering from a disaster. The cluster and component
+	// annotations are set in the volume claim templates, which only take effect on a new StatefulSet,
+	// so all of them are patched to the bound PVCs too.
+	// Optional: Defaults to false
+	// +optional
+	PVCAuditAnnotations *bool `json:"pvcAuditAnnotations,omitempty"`
+
+	// SafeScaleOut defers adding a new PD member until all existing members of the cluster are healthy,
+	// since a new member joining an unstable PD cluster may worsen it.
+	// Optional: Defaults to false
+	// +optional
+	SafeScaleOut *bool `json:"safeScaleOut,omitempty"`
+
+	// ReplicationMode is mapped into the `replication-mode` config of PD, which is required by the DR auto-sync
+	// deployment across two data centers. PD switches the state of the DR auto-sync mode by itself, so this
+	// mostly matters for the initial deployment. The items in the config of PD are kept, and it needs `config`
+	// or `configFrom` to be set.
+	// +optional
+	ReplicationMode *PDReplicationModeConfig `json:"replicationMode,omitempty"`
+
+	// ReadinessSidecar is the HTTP endpoint of a health aggregator sidecar. If it is set, the readiness probe
+	// of PD requests the endpoint instead of checking PD, whatever the type of `.spec.pd.readinessProbe` is,
+	// so that the readiness of PD follows the verdict of the sidecar. The sidecar is added by
+	// `.spec.pd.additionalContainers`.
+	// +optional
+	ReadinessSidecar *PDReadinessSidecar `json:"readinessSidecar,omitempty"`
+
+	// RegionMerge is mapped into the region merge config in `schedule` of PD. The items are rendered into
+	// the config file of PD when `config` or `configFrom` is set, without overriding the ones already there.
+	// With `hotReload`, they're also set on the running PD cluster, so tuning the merge needs no restart.
+	// +optional
+	RegionMerge *PDRegionMergeConfig `json:"regionMerge,omitempty"`
+
+	// MaintenanceToleration makes PD tolerate the taint added to the nodes during the node maintenance with
+	// the effect `NoExecute`, in addition to `tolerations`, so that PD isn't evicted in a planned window.
+	// +optional
+	MaintenanceToleration *PDMaintenanceToleration `json:"maintenanceToleration,omitempty"`
+
+	// DisableDashboard disables TiDB Dashboard on all PD members by setting `pd-server.dashboard-address`
+	// of PD to `none`, e.g. when the dashboard is deployed separately. PD before v4.0.0 has no dashboard, so
+	// it's ignored for them. An address from `dashboardAddress` or the config of PD is kept, and nothing is
+	// rendered unless `config` or `configFrom` is set.
+	// Optional: Defaults to false
+	// +optional
+	DisableDashboard *bool `json:"disableDashboard,omitempty"`
+
+	// MinResolvedTSPersistenceInterval is mapped to `pd-server.min-resolved-ts-persistence-interval` of PD,
+	// which is the interval to persist the min resolved ts of the cluster, e.g. `1s`, `0s` disables it.
+	// The persisted value is what the stale reads and TiCDC see, so a shorter interval keeps it fresher.
+	// It's written to the config file of PD and follows `configUpdateStrategy` when it changes. The item in
+	// `config` or `configFrom` is kept if present, and it's not rendered if neither of them is set.
+	// +optional
+	MinResolvedTSPersistenceInterval *string `json:"minResolvedTSPersistenceInterval,omitempty"`
+
+	// ClockSkewThreshold is the max tolerated offset between the clock of a PD member and the one of the
+	// operator, e.g. `1s`, the `PDClockSkew` condition is set if it is exceeded by any healthy member.
+	// The clock of PD is read from the `Date` header of its HTTP API on a best-effort basis, so the
+	// offset is only accurate to about one second.
+	// Optional: Defaults to nil, which disables the check
+	// +optional
+	ClockSkewThreshold *string `json:"clockSkewThreshold,omitempty"`
+
+	// PreferIPv6 indicates whether to prefer IPv6 addresses for PD, which takes precedence over
+	// `.spec.preferIPv6` for the services of PD and the listen addresses in the start script of PD.
+	// Optional: Defaults to `.spec.preferIPv6`
+	// +optional
+	PreferIPv6 *bool `json:"preferIPv6,omitempty"`
+
+	// LabelProperties are the label properties of PD reconciled via PD, e.g. the stores with a label are
+	// rejected to have leaders by the property of type `reject-leader`.
+	// The operator only deletes the properties it applied, the ones set by others are kept.
+	// +optional
+	LabelProperties []PDLabelProperty `json:"labelProperties,omitempty"`
+
+	// PostStartWaitForJoin indicates whether to add a postStart hook to the PD container which blocks
+	// until the pod appears in the member list of PD, so the container is reported started only after
+	// PD joins the cluster.
+	// Optional: Defaults to false
+	// +optional
+	PostStartWaitForJoin *bool `json:"postStartWaitForJoin,omitempty"`
+
+	// PeerURLScheme overrides the scheme of the peer URLs of PD in the start script, which is
+	// derived from `.spec.tlsCluster.enabled` by default. It allows to switch the scheme of the
+	// peer URLs independently of the client URLs during a staged TLS rollout.
+	// Optional: Defaults to the scheme of the cluster
+	// +optional
+	// +kubebuilder:validation:Enum:="http";"https"
+	PeerURLScheme *string `json:"peerURLScheme,omitempty"`
+
+	// TSOSaveInterval is mapped to `tso-save-interval` of PD, which is the interval to save the
+	// timestamp window of TSO to etcd, e.g. `3s`. A new PD leader starts allocating after the saved
+	// window, so a longer interval saves writes to etcd but skips more timestamps on a leader change.
+	// `tso-save-interval` in the config of PD is used instead if present, and it requires `config` or `configFrom`.
+	// +optional
+	TSOSaveInterval *string `json:"tsoSaveInterval,omitempty"`
+
+	// TSOUpdatePhysicalInterval is mapped to `tso-update-physical-interval` of PD, which is the interval
+	// to update the physical part of TSO, e.g. `50ms`, it must be between `1ms` and `10s`. A shorter interval
+	// lets PD allocate more timestamps per second at the cost of more CPU. Like the other TSO items, it's
+	// only rendered with `config` or `configFrom`, and the key in the config of PD is left untouched.
+	// +optional
+	TSOUpdatePhysicalInterval *string `json:"tsoUpdatePhysicalInterval,omitempty"`
+
+	// ProjectedVolumeMounts indicates whether to combine the config, startup script and TLS volumes of PD
+	// into one projected volume to reduce the number of volumes of the pod, e.g. on nodes with volume limits.
+	// The projected volume is mounted at `/var/lib/pd-projected`, and the files of each volume are in the
+	// directory named after the volume instead of the original mount path, e.g. `/var/lib/pd-projected/pd-tls`.
+	// The config and the TLS paths of PD are set to them, and the updates of the sources are seen by PD.
+	// Optional: Defaults to false
+	// +optional
+	ProjectedVolumeMounts *bool `json:"projectedVolumeMounts,omitempty"`
+
+	// CheckMemberClusterID indicates whether to check the cluster ID reported by each healthy PD member
+	// against the one of the cluster, the members with a different cluster ID are recorded in
+	// `.status.pd.mismatchedClusterIDMembers` and reported with a Warning event.
+	// Optional: Defaults to false
+	// +optional
+	CheckMemberClusterID *bool `json:"checkMemberClusterID,omitempty"`
+
+	// EnableGRPCGateway is mapped to `enable-grpc-gateway` of PD, which enables the HTTP gateway of
+	// the gRPC API of PD. A Warning event is emitted if it's disabled, as the operator checks the
+	// health of PD via its HTTP API. `enable-grpc-gateway` in the config of PD overrides it, also for the
+	// warning, and it's only rendered when `config` or `configFrom` is set.
+	// +optional
+	EnableGRPCGateway *bool `json:"enableGRPCGateway,omitempty"`
+
+	// StartupStaggerSeconds staggers the startup of the PD pods to avoid DNS and discovery storms when
+	// many pods are restarted at once, each pod sleeps `ordinal % startupStaggerSeconds` seconds in the
+	// start script before waiting for its domain.
+	// Optional: Defaults to 0, which disables the stagger
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartupStaggerSeconds *int32 `json:"startupStaggerSeconds,omitempty"`
+
+	// HotRegionScheduleLimit is mapped to `schedule.hot-region-schedule-limit` of PD, which limits the
+	// number of the coexisting hot region schedules. The operator sets it on the running PD cluster via
+	// the config API of PD on each sync, so raising it to speed up the hot region scheduling needs no restart.
+	// It's left alone if `schedule.hot-region-schedule-limit` is in `config` or `configFrom`.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HotRegionScheduleLimit *int64 `json:"hotRegionScheduleLimit,omitempty"`
+
+	// SplitMergeInterval is mapped to `schedule.split-merge-interval` of PD, which is the minimum
+	// interval to permit merging a region after it's split, e.g. 1h. Like `hotRegionScheduleLimit`, it's
+	// pushed to the running PD cluster on each sync rather than only rendered into the config file, unless
+	// `schedule.split-merge-interval` is in the config of PD, which PD then reads at startup instead.
+	// +optional
+	SplitMergeInterval *string `json:"splitMergeInterval,omitempty"`
+
+	// ValidateConfigSchema makes the operator validate the config of PD against a JSON schema listing the keys
+	// known to PD v6 to v8 before rolling it out, which catches the unknown keys such as `replication.max-replica`.
+	// The schema is shared by these versions, so a key added in a later version is not rejected for an earlier one.
+	// An invalid config is rejected with a Warning event. Nothing is validated for other versions of PD.
+	// Optional: Defaults to false
+	// +optional
+	ValidateConfigSchema *bool `json:"validateConfigSchema,omitempty"`
+
+	// ServiceAccountTokenAudience adds a projected service account token with the audience into the
+	// PD pods, which is mounted at /var/run/secrets/tokens/token for the workload identity integrations.
+	// The token is rotated by the kubelet before it expires.
+	// +optional
+	ServiceAccountTokenAudience *string `json:"serviceAccountTokenAudience,omitempty"`
+
+	// FlushMetricsOnShutdown adds a step to the preStop hook of PD which pushes the final metrics of PD
+	// to a Prometheus Pushgateway before PD is terminated, so that the metrics of the last moment since
+	// the last scrape are not lost. It runs after the other preStop steps, if any.
+	// +optional
+	FlushMetricsOnShutdown *PDFlushMetricsConfig `json:"flushMetricsOnShutdown,omitempty"`
+
+	// DashboardPublicPathPrefix is mapped to `dashboard.public-path-prefix` of PD, which is the path prefix
+	// of the dashboard when it is served behind a reverse proxy or an ingress at a subpath, e.g. `/pd-dashboard`.
+	// It must match the path forwarded by the proxy. `dashboard.public-path-prefix` in the config of PD is
+	// used instead if it's set.
+	// +optional
+	DashboardPublicPathPrefix *string `json:"dashboardPublicPathPrefix,omitempty"`
+
+	// FailoverReadinessInitialDelaySeconds is the initial delay of readiness of the failover pods of PD,
+	// which bootstrap into the existing cluster and may need more time to be ready than the other pods.
+	// The probes of the pods can't be changed once they are created, so a readiness gate of the condition
+	// `pingcap.com/PDFailoverReady` is added to the pods instead, which is set by the operator once the PD
+	// container of a failover pod has been running for the seconds, and at once for the other pods.
+	// Changing it between set and unset rolls the pods of PD.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailoverReadinessInitialDelaySeconds *int32 `json:"failoverReadinessInitialDelaySeconds,omitempty"`
+
+	// MaxStorageUtilizationForScale is the percentage of the used capacity of all stores reported by PD,
+	// the scale-out of PD is refused with an event if it is exceeded, so that no members are added to a
+	// cluster whose storage is about to run out.
+	// Optional: Defaults to nil, which disables the check
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxStorageUtilizationForScale *int32 `json:"maxStorageUtilizationForScale,omitempty"`
+
+	// EnableDiagnostic is mapped to `schedule.enable-diagnostic` of PD, which enables the diagnostic of the
+	// schedulers of PD, e.g. the result of `pd-ctl scheduler describe`, to help troubleshoot the scheduling.
+	// Toggling it renders a new config file of PD, which is picked up per `configUpdateStrategy`. It can be
+	// overridden by `schedule.enable-diagnostic` in the config of PD, and needs `config` or `configFrom`.
+	// +optional
+	EnableDiagnostic *bool `json:"enableDiagnostic,omitempty"`
+}
+
+// PDReadinessSidecar is the HTTP endpoint of the health aggregator sidecar of PD
+// +k8s:openapi-gen=true
+type PDReadinessSidecar struct {
+	// Port is the port of the HTTP endpoint of the sidecar.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path is the path of the HTTP endpoint of the sidecar, a status code between 200 and 399 means ready.
+	// Optional: Defaults to /health
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// PDRegionMergeConfig is the region merge config of PD
+// +k8s:openapi-gen=true
+type PDRegionMergeConfig struct {
+	// EnableCrossTableMerge is mapped to `schedule.enable-cross-table-merge` of PD, which allows
+	// the regions of different tables to be merged.
+	// +optional
+	EnableCrossTableMerge *bool `json:"enableCrossTableMerge,omitempty"`
+
+	// MaxMergeRegionSize is mapped to `schedule.max-merge-region-size` of PD in MiB, a region smaller
+	// than it is merged with the adjacent region. Region merge is disabled if it's 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMergeRegionSize *int64 `json:"maxMergeRegionSize,omitempty"`
+
+	// MaxMergeRegionKeys is mapped to `schedule.max-merge-region-keys` of PD, a region with fewer keys
+	// than it is merged with the adjacent region.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMergeRegionKeys *int64 `json:"maxMergeRegionKeys,omitempty"`
+
+	// HotReload makes the operator apply the items to the running PD cluster via PD on each sync,
+	// so that a change takes effect without restarting PD.
+	// Optional: Defaults to false
+	// +optional
+	HotReload *bool `json:"hotReload,omitempty"`
+}
+
+// PDFlushMetricsConfig is the config to push the final metrics of PD to a Prometheus Pushgateway on shutdown
+// +k8s:openapi-gen=true
+type PDFlushMetricsConfig struct {
+	// PushgatewayURL is the URL of the Prometheus Pushgateway, e.g. http://pushgateway:9091.
+	PushgatewayURL string `json:"pushgatewayURL"`
+
+	// Job is the job label of the pushed metrics, the instance label is the name of the pod.
+	// Optional: Defaults to pd
+	// +optional
+	Job *string `json:"job,omitempty"`
+
+	// TimeoutSeconds is the timeout of scraping and pushing the metrics respectively. A failure to flush
+	// the metrics never blocks the shutdown of PD.
+	// Optional: Defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// PDMaintenanceToleration is the taint added to the nodes during the node maintenance which PD tolerates
+// +k8s:openapi-gen=true
+type PDMaintenanceToleration struct {
+	// Key is the key of the taint.
+	Key string `json:"key"`
+
+	// Value is the value of the taint, any value of the key is tolerated if it's empty.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// TolerationSeconds is how long PD stays on the node after the taint is added,
+	// PD is never evicted by the taint if it's not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -779,6 +1339,21 @@ type TiKVSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	SpareVolReplaceReplicas *int32 `json:"spareVolReplaceReplicas,omitempty"`
+
+	// PDHeartbeatInterval is mapped to `raftstore.pd-heartbeat-tick-interval` of TiKV, which is the interval
+	// of the region heartbeats reported to PD, e.g. `1m`. A longer interval reduces the load of PD in a large
+	// cluster at the cost of the freshness of the region info in PD.
+	// TiKV reads it from its config file, so it needs `.spec.tikv.config` to be set, doesn't replace the same
+	// item there, and reaches TiKV the way the other config changes of TiKV do.
+	// +optional
+	PDHeartbeatInterval *string `json:"pdHeartbeatInterval,omitempty"`
+
+	// PDStoreHeartbeatInterval is mapped to `raftstore.pd-store-heartbeat-tick-interval` of TiKV, which is
+	// the interval of the store heartbeats reported to PD, e.g. `10s`. PD tells the stores down by their
+	// heartbeats, so a long interval delays that. `raftstore.pd-store-heartbeat-tick-interval` in
+	// `.spec.tikv.config` wins over it, and nothing is rendered unless `.spec.tikv.config` is set.
+	// +optional
+	PDStoreHeartbeatInterval *string `json:"pdStoreHeartbeatInterval,omitempty"`
 }
 
 // TiFlashSpec contains details of TiFlash members
@@ -959,6 +1534,169 @@ type LogTailerSpec struct {
 	corev1.ResourceRequirements `json:",inline"`
 }
 
+// PDLogTailerSpec represents an optional log tailer sidecar with PD
+// +k8s:openapi-gen=true
+type PDLogTailerSpec struct {
+	corev1.ResourceRequirements `json:",inline"`
+
+	// MaxSize is the maximum size in megabytes of the PD log file before it gets rotated.
+	// Optional: Defaults to 300
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+
+	// MaxBackups is the maximum number of rotated PD log files to retain.
+	// Optional: Defaults to 3
+	// +optional
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+// PDStoreLimits is the store limits of all stores in the cluster
+// +k8s:openapi-gen=true
+type PDStoreLimits struct {
+	// AddPeer is the rate limit of adding peers of each store, in peers per minute.
+	// Not reconciled if not set.
+	// +optional
+	AddPeer *int32 `json:"addPeer,omitempty"`
+
+	// RemovePeer is the rate limit of removing peers of each store, in peers per minute.
+	// Not reconciled if not set.
+	// +optional
+	RemovePeer *int32 `json:"removePeer,omitempty"`
+}
+
+// PDSchedulers is the schedulers of PD to add or remove
+// +k8s:openapi-gen=true
+type PDSchedulers struct {
+	// Add is the names of the schedulers to add, which must not take arguments.
+	// +optional
+	Add []string `json:"add,omitempty"`
+
+	// Remove is the names of the schedulers to remove.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// PDRegionLabelRule is a region label rule of PD, which sets the labels on the regions in the key ranges
+// +k8s:openapi-gen=true
+type PDRegionLabelRule struct {
+	// ID is the unique ID of the rule in PD.
+	ID string `json:"id"`
+
+	// Index is the priority of the rule, the labels of the rule with a larger index win on conflicts.
+	// +optional
+	Index int32 `json:"index,omitempty"`
+
+	// Labels are the labels set on the regions in the key ranges.
+	Labels []PDRegionLabel `json:"labels"`
+
+	// KeyRanges are the key ranges the rule applies to.
+	KeyRanges []PDKeyRange `json:"keyRanges"`
+}
+
+// PDRegionLabel is a label of regions
+// +k8s:openapi-gen=true
+type PDRegionLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PDKeyRange is a key range of regions, the keys are hex encoded
+// +k8s:openapi-gen=true
+type PDKeyRange struct {
+	// StartKey is the hex encoded start key of the range, inclusive.
+	// An empty key means the start of all keys.
+	// +optional
+	StartKey string `json:"startKey,omitempty"`
+
+	// EndKey is the hex encoded end key of the range, exclusive.
+	// An empty key means the end of all keys.
+	// +optional
+	EndKey string `json:"endKey,omitempty"`
+}
+
+// PDLabelProperty is a label property of PD, which applies the property to the stores with the label
+// +k8s:openapi-gen=true
+type PDLabelProperty struct {
+	// Type is the type of the property, e.g. `reject-leader`.
+	Type string `json:"type"`
+	// Key is the key of the store label.
+	Key string `json:"key"`
+	// Value is the value of the store label.
+	Value string `json:"value"`
+}
+
+// PDAuditConfig is the audit config of PD
+// +k8s:openapi-gen=true
+type PDAuditConfig struct {
+	// Enabled controls whether PD records the audit log of its HTTP API calls, which is mapped to `pd-server.enable-audit`.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RedactInfoLog controls whether the user data in the log of PD is redacted, which is mapped to `security.redact-info-log`.
+	// +optional
+	RedactInfoLog *bool `json:"redactInfoLog,omitempty"`
+}
+
+const (
+	// PDReplicationModeMajority is the default replication mode of PD, which replicates the data by the Raft majority
+	PDReplicationModeMajority = "majority"
+	// PDReplicationModeDRAutoSync is the replication mode of PD which replicates the data synchronously across two data centers
+	PDReplicationModeDRAutoSync = "dr-auto-sync"
+)
+
+// PDReplicationModeConfig is the replication mode config of PD
+// +k8s:openapi-gen=true
+type PDReplicationModeConfig struct {
+	// Mode is the replication mode of PD, which is mapped to `replication-mode.replication-mode`.
+	// The other fields are only allowed and the label key, primary and DR are required if it's `dr-auto-sync`.
+	// +kubebuilder:validation:Enum=majority;dr-auto-sync
+	Mode string `json:"mode"`
+
+	// LabelKey is the label key of the stores distinguishing the data centers, e.g. `zone`,
+	// which is mapped to `replication-mode.dr-auto-sync.label-key`.
+	// +optional
+	LabelKey *string `json:"labelKey,omitempty"`
+
+	// Primary is the label value of the primary data center, which is mapped to `replication-mode.dr-auto-sync.primary`.
+	// +optional
+	Primary *string `json:"primary,omitempty"`
+
+	// DR is the label value of the DR data center, which is mapped to `replication-mode.dr-auto-sync.dr`.
+	// +optional
+	DR *string `json:"dr,omitempty"`
+
+	// PrimaryReplicas is the number of voter replicas in the primary data center,
+	// which is mapped to `replication-mode.dr-auto-sync.primary-replicas`.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PrimaryReplicas *int32 `json:"primaryReplicas,omitempty"`
+
+	// DRReplicas is the number of voter replicas in the DR data center,
+	// which is mapped to `replication-mode.dr-auto-sync.dr-replicas`.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DRReplicas *int32 `json:"drReplicas,omitempty"`
+
+	// WaitStoreTimeout is the time to wait before switching to the async replication when the stores
+	// of a data center are down, e.g. `1m`, which is mapped to `replication-mode.dr-auto-sync.wait-store-timeout`.
+	// +optional
+	WaitStoreTimeout *string `json:"waitStoreTimeout,omitempty"`
+}
+
+// PDNetworkPolicy describes the NetworkPolicy generated for PD
+// +k8s:openapi-gen=true
+type PDNetworkPolicy struct {
+	// Enabled controls whether the NetworkPolicy is generated.
+	// Optional: Defaults to false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// From is the list of additional peers allowed to reach PD besides the members of the cluster,
+	// e.g. the operator, TidbMonitor and backup jobs, which may live in other namespaces.
+	// +optional
+	From []networkingv1.NetworkPolicyPeer `json:"from,omitempty"`
+}
+
 // InitContainerSpec contains basic spec about a init container
 //
 // +k8s:openapi-gen=true
@@ -1155,6 +1893,12 @@ type Probe struct {
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// Number of seconds after which the probe times out.
+	// Default to Kubernetes default (1 second). Minimum value is 1.
+	// Only PD supports it for now.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // PumpSpec contains details of Pump members
@@ -1424,6 +2168,22 @@ type ServiceSpec struct {
 	// Optional: Defaults to omitted
 	// +optional
 	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// ExternalIPs is the externalIPs of service, the traffic ingressing into the cluster
+	// with these IPs as the destination will be routed to the service endpoints.
+	// It's usually used to expose the service in bare-metal environments without load balancers.
+	// Optional: Defaults to omitted
+	// +optional
+	ExternalIPs []string `json:"externalIPs,omitempty"`
+
+	// InternalTrafficPolicy is the internalTrafficPolicy of service, `Local` routes the traffic
+	// from inside the cluster only to the endpoints on the same node as the client.
+	//
+	// NOTE: only used for PD
+	//
+	// Optional: Defaults to omitted
+	// +optional
+	InternalTrafficPolicy *corev1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
 }
 
 // TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.
@@ -1494,6 +2254,51 @@ type PDStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Indicates that a Volume replace using VolumeReplacing feature is in progress.
 	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
+	// AppliedRegionLabelRules are the IDs of the region label rules applied by the operator,
+	// which are deleted from PD when they are removed from the spec.
+	// +optional
+	AppliedRegionLabelRules []string `json:"appliedRegionLabelRules,omitempty"`
+	// UpgradeStableChecks is the number of consecutive syncs in which all PD members are healthy after
+	// the rollout of an upgrade completes, see `.spec.pd.upgradeStabilizationChecks`.
+	// +optional
+	UpgradeStableChecks int32 `json:"upgradeStableChecks,omitempty"`
+	// ConfigUpdatePending indicates the desired config of PD is not rolled out yet, i.e. the StatefulSet
+	// of PD doesn't use its ConfigMap, or not all PD pods are updated to the current revision.
+	// +optional
+	ConfigUpdatePending bool `json:"configUpdatePending,omitempty"`
+	// PendingConfigMapName is the name of the ConfigMap which the pending config change rolls out.
+	// +optional
+	PendingConfigMapName string `json:"pendingConfigMapName,omitempty"`
+	// ConfigRolloutFailures is the number of consecutive failures to update the StatefulSet of PD
+	// while a config change is pending, i.e. the new ConfigMap is not used by any running PD yet.
+	// +optional
+	ConfigRolloutFailures int32 `json:"configRolloutFailures,omitempty"`
+	// MemberRemoval is the progress of removing a PD member via PD when scaling in,
+	// it's cleared once the member is removed.
+	// +optional
+	MemberRemoval *PDMemberRemoval `json:"memberRemoval,omitempty"`
+	// UpdatePartition is the partition of the rolling update of the StatefulSet of PD,
+	// the pods with an ordinal greater than or equal to it are updated during a rollout.
+	// It's not set if the StatefulSet has no partition.
+	// +optional
+	UpdatePartition *int32 `json:"updatePartition,omitempty"`
+	// ForceNewCluster is the PD member designated to be started with `--force-new-cluster`
+	// via the annotation `pingcap.com/pd-force-new-cluster`, it's cleared once PD recovers.
+	// +optional
+	ForceNewCluster *PDForceNewCluster `json:"forceNewCluster,omitempty"`
+	// AppliedLabelProperties are the label properties applied by the operator,
+	// which are deleted from PD when they are removed from the spec.
+	// +optional
+	AppliedLabelProperties []PDLabelProperty `json:"appliedLabelProperties,omitempty"`
+	// MismatchedClusterIDMembers are the PD members reporting a cluster ID different from the one of
+	// the cluster, keyed by the name of the member with the reported cluster ID as value.
+	// It's only maintained when `.spec.pd.checkMemberClusterID` is enabled.
+	// +optional
+	MismatchedClusterIDMembers map[string]string `json:"mismatchedClusterIDMembers,omitempty"`
+	// LeaderHistory is the history of the changes of the PD leader observed by the operator, the oldest
+	// first. Only the last 10 changes are kept.
+	// +optional
+	LeaderHistory []PDLeaderChange `json:"leaderHistory,omitempty"`
 }
 
 // PDMSStatus is PD Micro Service Status
@@ -1526,6 +2331,43 @@ type PDMember struct {
 	// TODO: remove nullable, https://github.com/kubernetes/kubernetes/issues/86811
 	// +nullable
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Zone is the zone of the node where the pod of the member is scheduled, which is read from the zone label of the node.
+	// It's only set for the members of the current cluster.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// PDLeaderChange is a change of the PD leader observed by the operator
+type PDLeaderChange struct {
+	// Name is the name of the new PD leader.
+	Name string `json:"name"`
+	// ID is the member ID of the new PD leader.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Time is the time when the change is observed by the operator.
+	Time metav1.Time `json:"time"`
+}
+
+// PDMemberRemoval is the progress of removing a PD member via PD
+type PDMemberRemoval struct {
+	// Name is the name of the PD member being removed.
+	Name string `json:"name"`
+	// Attempts is the number of attempts to remove the member.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// LastError is the error of the last failed attempt.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// PDForceNewCluster is the PD member designated to be started with `--force-new-cluster`
+// to recover PD from the loss of quorum
+type PDForceNewCluster struct {
+	// Member is the name of the PD pod to be started with `--force-new-cluster`.
+	Member string `json:"member"`
+	// RequestTime is the time when the recovery is requested, the flag is applied
+	// at most once for each request.
+	RequestTime metav1.Time `json:"requestTime"`
 }
 
 // EmptyStruct is defined to delight controller-gen tools
@@ -1542,6 +2384,9 @@ type PDFailureMember struct {
 	HostDown      bool                      `json:"hostDown,omitempty"`
 	// +nullable
 	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+	// Zone is the zone of the failure member when it's marked as failure.
+	// +optional
+	Zone string `json:"zone,omitempty"`
 }
 
 // UnjoinedMember is the pd unjoin cluster member information
@@ -14,6 +14,8 @@
 package validation
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -27,6 +29,7 @@ import (
 	"github.com/Masterminds/semver"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -171,12 +174,339 @@ func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
 	allErrs = append(allErrs, validateRequestsStorage(spec.ResourceRequirements.Requests, fldPath)...)
+	// the data directory of PD must be under the mount path of the data volume
+	if len(spec.DataSubDir) > 0 {
+		allErrs = append(allErrs, validateLocalDescendingPath(spec.DataSubDir, fldPath.Child("dataSubDir"))...)
+	}
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
 	}
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(spec.Service, fldPath)...)
 	}
+	if spec.ConfigFrom != nil {
+		if spec.Config != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("configFrom"), spec.ConfigFrom.Name, "configFrom and config are mutually exclusive"))
+		}
+		if spec.ConfigFrom.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("configFrom", "name"), "name of the referenced ConfigMap must not be empty"))
+		}
+		if spec.ConfigFrom.Key == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("configFrom", "key"), "key of the referenced ConfigMap must not be empty"))
+		}
+	}
+	if spec.LogTailer != nil {
+		if spec.LogTailer.MaxSize != nil && *spec.LogTailer.MaxSize <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logTailer", "maxSize"), *spec.LogTailer.MaxSize, "must be greater than 0"))
+		}
+		if spec.LogTailer.MaxBackups != nil && *spec.LogTailer.MaxBackups < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logTailer", "maxBackups"), *spec.LogTailer.MaxBackups, "must be greater than or equal to 0"))
+		}
+	}
+	if spec.OrdinalsStart != nil && *spec.OrdinalsStart < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ordinalsStart"), *spec.OrdinalsStart, "must be greater than or equal to 0"))
+	}
+	// the advanced StatefulSet ignores `.spec.ordinals`, so the pods would be created from ordinal 0
+	if spec.OrdinalsStart != nil && *spec.OrdinalsStart > 0 && features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ordinalsStart"), "is not supported when the AdvancedStatefulSet feature is enabled"))
+	}
+	if spec.ProbePort != nil {
+		for _, msg := range validation.IsValidPortNum(int(*spec.ProbePort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("probePort"), *spec.ProbePort, msg))
+		}
+	}
+	allErrs = append(allErrs, validatePDLocationLabels(spec.LocationLabels, fldPath.Child("locationLabels"))...)
+	if sidecar := spec.ReadinessSidecar; sidecar != nil {
+		for _, msg := range validation.IsValidPortNum(int(sidecar.Port)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("readinessSidecar", "port"), sidecar.Port, msg))
+		}
+		if sidecar.Path != "" && !strings.HasPrefix(sidecar.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("readinessSidecar", "path"), sidecar.Path, "must be an absolute path"))
+		}
+	}
+	if probe := spec.ReadinessProbe; probe != nil && probe.TimeoutSeconds != nil && *probe.TimeoutSeconds < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("readinessProbe", "timeoutSeconds"), *probe.TimeoutSeconds, "must be greater than or equal to 1"))
+	}
+	if spec.InitialClusterToken != nil && *spec.InitialClusterToken == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialClusterToken"), *spec.InitialClusterToken, "must not be empty"))
+	}
+	// the annotations are set on PD pods as is, e.g. `sidecar.istio.io/inject` to control the sidecar injection of service meshes
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(spec.Annotations, fldPath.Child("annotations"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(spec.PrometheusScrapeAnnotations, fldPath.Child("prometheusScrapeAnnotations"))...)
+	allErrs = append(allErrs, validatePDRegionLabelRules(spec.RegionLabelRules, fldPath.Child("regionLabelRules"))...)
+	allErrs = append(allErrs, validatePDLabelProperties(spec.LabelProperties, fldPath.Child("labelProperties"))...)
+	if spec.Schedulers != nil {
+		allErrs = append(allErrs, validatePDSchedulers(spec.Schedulers, fldPath.Child("schedulers"))...)
+	}
+	if spec.ContainerName != nil {
+		for _, msg := range validation.IsDNS1123Label(*spec.ContainerName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("containerName"), *spec.ContainerName, msg))
+		}
+	}
+	if spec.MetricStorage != nil && !isHTTPURL(*spec.MetricStorage) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("metricStorage"), *spec.MetricStorage, "must be a http or https URL"))
+	}
+	if addr := spec.DashboardAddress; addr != nil && *addr != "auto" && *addr != "none" && !isHTTPURL(*addr) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dashboardAddress"), *addr, "must be auto, none or a http or https URL"))
+	}
+	if spec.DisableDashboard != nil && *spec.DisableDashboard && spec.DashboardAddress != nil && *spec.DashboardAddress != "none" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("disableDashboard"), *spec.DisableDashboard, "must not be true when dashboardAddress is not none"))
+	}
+	if interval := spec.MinResolvedTSPersistenceInterval; interval != nil {
+		if d, err := time.ParseDuration(*interval); err != nil || d < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minResolvedTSPersistenceInterval"), *interval, "must be a non-negative duration, e.g. 1s"))
+		}
+	}
+	if interval := spec.TSOSaveInterval; interval != nil {
+		if d, err := time.ParseDuration(*interval); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tsoSaveInterval"), *interval, "must be a positive duration, e.g. 3s"))
+		}
+	}
+	if interval := spec.TSOUpdatePhysicalInterval; interval != nil {
+		if d, err := time.ParseDuration(*interval); err != nil || d < time.Millisecond || d > 10*time.Second {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tsoUpdatePhysicalInterval"), *interval, "must be a duration between 1ms and 10s, e.g. 50ms"))
+		}
+	}
+	if limit := spec.HotRegionScheduleLimit; limit != nil && *limit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hotRegionScheduleLimit"), *limit, "must be greater than or equal to 0"))
+	}
+	if interval := spec.SplitMergeInterval; interval != nil {
+		if d, err := time.ParseDuration(*interval); err != nil || d < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("splitMergeInterval"), *interval, "must be a non-negative duration, e.g. 1h"))
+		}
+	}
+	if s := spec.FailoverReadinessInitialDelaySeconds; s != nil && *s < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failoverReadinessInitialDelaySeconds"), *s, "must be greater than or equal to 0"))
+	}
+	if prefix := spec.DashboardPublicPathPrefix; prefix != nil && !strings.HasPrefix(*prefix, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dashboardPublicPathPrefix"), *prefix, "must be an absolute path, e.g. /dashboard"))
+	}
+	if flush := spec.FlushMetricsOnShutdown; flush != nil {
+		if !isHTTPURL(flush.PushgatewayURL) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("flushMetricsOnShutdown", "pushgatewayURL"), flush.PushgatewayURL, "must be a http or https URL, e.g. http://pushgateway:9091"))
+		}
+		if flush.Job != nil {
+			for _, msg := range validation.IsValidLabelValue(*flush.Job) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("flushMetricsOnShutdown", "job"), *flush.Job, msg))
+			}
+		}
+		if flush.TimeoutSeconds != nil && *flush.TimeoutSeconds < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("flushMetricsOnShutdown", "timeoutSeconds"), *flush.TimeoutSeconds, "must be greater than 0"))
+		}
+	}
+	if audience := spec.ServiceAccountTokenAudience; audience != nil && *audience == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccountTokenAudience"), *audience, "must not be empty"))
+	}
+	if s := spec.StartupStaggerSeconds; s != nil && *s < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("startupStaggerSeconds"), *s, "must be greater than or equal to 0"))
+	}
+	if scheme := spec.PeerURLScheme; scheme != nil && *scheme != "http" && *scheme != "https" {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("peerURLScheme"), *scheme, []string{"http", "https"}))
+	}
+	if threshold := spec.ClockSkewThreshold; threshold != nil {
+		if d, err := time.ParseDuration(*threshold); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clockSkewThreshold"), *threshold, "must be a positive duration, e.g. 1s"))
+		}
+	}
+	if t := spec.DiskPressureThreshold; t != nil && (*t < 1 || *t > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskPressureThreshold"), *t, "must be between 1 and 100"))
+	}
+	if t := spec.MaxStorageUtilizationForScale; t != nil && (*t < 1 || *t > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxStorageUtilizationForScale"), *t, "must be between 1 and 100"))
+	}
+	if spec.ReplicationMode != nil {
+		allErrs = append(allErrs, validatePDReplicationMode(spec.ReplicationMode, fldPath.Child("replicationMode"))...)
+	}
+	if spec.MaxGRPCMessageSize != nil {
+		if size, err := resource.ParseQuantity(*spec.MaxGRPCMessageSize); err != nil || size.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxGRPCMessageSize"), *spec.MaxGRPCMessageSize, "must be a positive quantity, e.g. 16Mi"))
+		}
+	}
+	if spec.UpgradeStabilizationChecks != nil && *spec.UpgradeStabilizationChecks < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("upgradeStabilizationChecks"), *spec.UpgradeStabilizationChecks, "must be greater than or equal to 0"))
+	}
+	if spec.PprofPort != nil {
+		for _, msg := range validation.IsValidPortNum(int(*spec.PprofPort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pprofPort"), *spec.PprofPort, msg))
+		}
+		if *spec.PprofPort == v1alpha1.DefaultPDClientPort || *spec.PprofPort == v1alpha1.DefaultPDPeerPort {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pprofPort"), *spec.PprofPort, "must not be the client or peer port of PD"))
+		}
+	}
+	if merge := spec.RegionMerge; merge != nil {
+		if merge.MaxMergeRegionSize != nil && *merge.MaxMergeRegionSize < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("regionMerge", "maxMergeRegionSize"), *merge.MaxMergeRegionSize, "must be greater than or equal to 0"))
+		}
+		if merge.MaxMergeRegionKeys != nil && *merge.MaxMergeRegionKeys < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("regionMerge", "maxMergeRegionKeys"), *merge.MaxMergeRegionKeys, "must be greater than or equal to 0"))
+		}
+	}
+	if mt := spec.MaintenanceToleration; mt != nil {
+		for _, msg := range validation.IsQualifiedName(mt.Key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maintenanceToleration", "key"), mt.Key, msg))
+		}
+		if mt.Value != "" {
+			for _, msg := range validation.IsValidLabelValue(mt.Value) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("maintenanceToleration", "value"), mt.Value, msg))
+			}
+		}
+		if mt.TolerationSeconds != nil && *mt.TolerationSeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maintenanceToleration", "tolerationSeconds"), *mt.TolerationSeconds, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+// validatePDLocationLabels validates that the location labels are valid keys of node labels without duplication,
+// because the store labels are set from the node labels with the same keys.
+func validatePDLocationLabels(locationLabels []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]struct{}{}
+	for i, l := range locationLabels {
+		for _, msg := range validation.IsQualifiedName(l) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), l, msg))
+		}
+		if _, ok := seen[l]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), l))
+		}
+		seen[l] = struct{}{}
+	}
+	return allErrs
+}
+
+// validatePDReplicationMode validates that the DR auto-sync fields are set if and only if the mode is `dr-auto-sync`,
+// and the primary and DR data centers are different.
+func validatePDReplicationMode(rm *v1alpha1.PDReplicationModeConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch rm.Mode {
+	case v1alpha1.PDReplicationModeDRAutoSync:
+		if rm.LabelKey == nil || *rm.LabelKey == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("labelKey"), "must be set if mode is dr-auto-sync"))
+		} else {
+			for _, msg := range validation.IsQualifiedName(*rm.LabelKey) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("labelKey"), *rm.LabelKey, msg))
+			}
+		}
+		if rm.Primary == nil || *rm.Primary == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("primary"), "must be set if mode is dr-auto-sync"))
+		}
+		if rm.DR == nil || *rm.DR == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("dr"), "must be set if mode is dr-auto-sync"))
+		}
+		if rm.Primary != nil && rm.DR != nil && *rm.Primary != "" && *rm.Primary == *rm.DR {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dr"), *rm.DR, "must be different from primary"))
+		}
+		if rm.PrimaryReplicas != nil && *rm.PrimaryReplicas < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("primaryReplicas"), *rm.PrimaryReplicas, "must be greater than or equal to 1"))
+		}
+		if rm.DRReplicas != nil && *rm.DRReplicas < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("drReplicas"), *rm.DRReplicas, "must be greater than or equal to 1"))
+		}
+		if rm.WaitStoreTimeout != nil {
+			if d, err := time.ParseDuration(*rm.WaitStoreTimeout); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("waitStoreTimeout"), *rm.WaitStoreTimeout, "must be a positive duration, e.g. 1m"))
+			}
+		}
+	case v1alpha1.PDReplicationModeMajority:
+		if rm.LabelKey != nil || rm.Primary != nil || rm.DR != nil || rm.PrimaryReplicas != nil || rm.DRReplicas != nil || rm.WaitStoreTimeout != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "the dr-auto-sync fields must not be set if mode is majority"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), rm.Mode, []string{v1alpha1.PDReplicationModeMajority, v1alpha1.PDReplicationModeDRAutoSync}))
+	}
+	return allErrs
+}
+
+// isHTTPURL returns whether the address is a http or https URL with a host
+func isHTTPURL(addr string) bool {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validatePDSchedulers validates that the names of schedulers are not empty and not both added and removed
+func validatePDSchedulers(schedulers *v1alpha1.PDSchedulers, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	added := map[string]struct{}{}
+	for i, name := range schedulers.Add {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("add").Index(i), "scheduler name must not be empty"))
+		}
+		added[name] = struct{}{}
+	}
+	for i, name := range schedulers.Remove {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("remove").Index(i), "scheduler name must not be empty"))
+		} else if _, ok := added[name]; ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("remove").Index(i), name, "scheduler must not be both added and removed"))
+		}
+	}
+	return allErrs
+}
+
+// validatePDRegionLabelRules validates that the region label rules have unique IDs, labels and valid hex encoded key ranges
+func validatePDRegionLabelRules(rules []v1alpha1.PDRegionLabelRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]struct{}{}
+	for i, rule := range rules {
+		idxPath := fldPath.Index(i)
+		if rule.ID == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("id"), "id must not be empty"))
+		} else if _, ok := seen[rule.ID]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("id"), rule.ID))
+		}
+		seen[rule.ID] = struct{}{}
+		if len(rule.Labels) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("labels"), "labels must not be empty"))
+		}
+		for j, l := range rule.Labels {
+			if l.Key == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("labels").Index(j).Child("key"), "key must not be empty"))
+			}
+		}
+		if len(rule.KeyRanges) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("keyRanges"), "keyRanges must not be empty"))
+		}
+		for j, r := range rule.KeyRanges {
+			rangePath := idxPath.Child("keyRanges").Index(j)
+			startKey, err := hex.DecodeString(r.StartKey)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(rangePath.Child("startKey"), r.StartKey, "must be hex encoded"))
+			}
+			endKey, err2 := hex.DecodeString(r.EndKey)
+			if err2 != nil {
+				allErrs = append(allErrs, field.Invalid(rangePath.Child("endKey"), r.EndKey, "must be hex encoded"))
+			}
+			if err == nil && err2 == nil && len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0 {
+				allErrs = append(allErrs, field.Invalid(rangePath.Child("endKey"), r.EndKey, "must be greater than startKey"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePDLabelProperties validates that the label properties are unique and have the type, key and value
+func validatePDLabelProperties(properties []v1alpha1.PDLabelProperty, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[v1alpha1.PDLabelProperty]struct{}{}
+	for i, p := range properties {
+		idxPath := fldPath.Index(i)
+		if p.Type == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("type"), "type must not be empty"))
+		}
+		if p.Key == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("key"), "key must not be empty"))
+		}
+		if p.Value == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("value"), "value must not be empty"))
+		}
+		if _, ok := seen[p]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath, p))
+		}
+		seen[p] = struct{}{}
+	}
 	return allErrs
 }
 
@@ -234,6 +564,8 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 		allErrs = append(allErrs, validateVolumeName(spec.RocksDBLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
 	allErrs = append(allErrs, validateTimeDurationStr(spec.EvictLeaderTimeout, fldPath.Child("evictLeaderTimeout"))...)
+	allErrs = append(allErrs, validateTimeDurationStr(spec.PDHeartbeatInterval, fldPath.Child("pdHeartbeatInterval"))...)
+	allErrs = append(allErrs, validateTimeDurationStr(spec.PDStoreHeartbeatInterval, fldPath.Child("pdStoreHeartbeatInterval"))...)
 	return allErrs
 }
 
@@ -412,6 +744,37 @@ func validateComponentSpec(spec *v1alpha1.ComponentSpec, fldPath *field.Path) fi
 	// TODO validate other fields
 	allErrs = append(allErrs, validateEnv(spec.Env, fldPath.Child("env"))...)
 	allErrs = append(allErrs, validateAdditionalContainers(spec.AdditionalContainers, fldPath.Child("additionalContainers"))...)
+	if spec.ImagePullPolicy != nil {
+		allErrs = append(allErrs, validateImagePullPolicy(*spec.ImagePullPolicy, fldPath.Child("imagePullPolicy"))...)
+	}
+	if spec.ConfigUpdateStrategy != nil {
+		allErrs = append(allErrs, validateConfigUpdateStrategy(*spec.ConfigUpdateStrategy, fldPath.Child("configUpdateStrategy"))...)
+	}
+	return allErrs
+}
+
+// validateConfigUpdateStrategy validates the config update strategy is one of the supported values,
+// an empty one falls back to InPlace
+func validateConfigUpdateStrategy(strategy v1alpha1.ConfigUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supported := []string{string(v1alpha1.ConfigUpdateStrategyInPlace), string(v1alpha1.ConfigUpdateStrategyRollingUpdate)}
+	switch strategy {
+	case "", v1alpha1.ConfigUpdateStrategyInPlace, v1alpha1.ConfigUpdateStrategyRollingUpdate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, strategy, supported))
+	}
+	return allErrs
+}
+
+// validateImagePullPolicy validates the image pull policy is one of the supported values
+func validateImagePullPolicy(policy corev1.PullPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supported := []string{string(corev1.PullAlways), string(corev1.PullNever), string(corev1.PullIfNotPresent)}
+	switch policy {
+	case corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, supported))
+	}
 	return allErrs
 }
 
@@ -575,6 +938,10 @@ func ValidateUpdateTidbCluster(old, tc *v1alpha1.TidbCluster) field.ErrorList {
 			"The instance must not be mutate or set value other than the cluster name"))
 	}
 	allErrs = append(allErrs, validateUpdatePDConfig(old.Spec.PD, tc.Spec.PD, field.NewPath("spec.pd.config"))...)
+	if old.Spec.PD != nil && tc.Spec.PD != nil && old.PDOrdinalsStart() != tc.PDOrdinalsStart() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec.pd.ordinalsStart"), tc.PDOrdinalsStart(),
+			"The ordinals start of PD must not be changed after the cluster is created"))
+	}
 	allErrs = append(allErrs, disallowMutateBootstrapSQLConfigMapName(old.Spec.TiDB, tc.Spec.TiDB, field.NewPath("spec.tidb.bootstrapSQLConfigMapName"))...)
 	allErrs = append(allErrs, disallowUsingLegacyAPIInNewCluster(old, tc)...)
 
@@ -640,7 +1007,7 @@ func disallowUsingLegacyAPIInNewCluster(old, tc *v1alpha1.TidbCluster) field.Err
 	if tikvSpecified && old.Spec.TiKV.Config != nil && tc.Spec.TiKV.Config == nil {
 		allErrs = append(allErrs, field.Invalid(path.Child("tikv.config"), tc.Spec.TiKV.Config, "TiKV.config must not be nil"))
 	}
-	if pdSpecified && old.Spec.PD.Config != nil && tc.Spec.PD.Config == nil {
+	if pdSpecified && old.Spec.PD.Config != nil && tc.Spec.PD.Config == nil && tc.Spec.PD.ConfigFrom == nil {
 		allErrs = append(allErrs, field.Invalid(path.Child("pd.config"), tc.Spec.PD.Config, "PD.config must not be nil"))
 	}
 	return allErrs
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -648,6 +649,48 @@ func TestValidateEvictLeaderTimeout(t *testing.T) {
 	}
 }
 
+func TestValidateTiKVPDHeartbeatIntervals(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                   string
+		heartbeatInterval      *string
+		storeHeartbeatInterval *string
+		expectedErrors         int
+	}{
+		{
+			name: "heartbeat intervals are not set",
+		},
+		{
+			name:                   "valid heartbeat intervals",
+			heartbeatInterval:      pointer.StringPtr("1m"),
+			storeHeartbeatInterval: pointer.StringPtr("10s"),
+		},
+		{
+			name:                   "invalid heartbeat intervals",
+			heartbeatInterval:      pointer.StringPtr("1 minute"),
+			storeHeartbeatInterval: pointer.StringPtr("0s"),
+			expectedErrors:         2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TiKVSpec{
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10G"),
+					},
+				},
+				PDHeartbeatInterval:      tt.heartbeatInterval,
+				PDStoreHeartbeatInterval: tt.storeHeartbeatInterval,
+			}
+			errs := validateTiKVSpec(spec, field.NewPath("spec", "tikv"))
+			g.Expect(errs).To(HaveLen(tt.expectedErrors), "%v", errs)
+		})
+	}
+}
+
 func TestValidatePromDurationStr(t *testing.T) {
 	successCases := []*string{
 		nil,
@@ -752,10 +795,49 @@ func TestValidateStartScriptFeatureFlags(t *testing.T) {
 func TestValidatePDSpec(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
-		name                     string
-		LoadBalancerSourceRanges []string
-		resourceRequirements     corev1.ResourceRequirements
-		expectedErrors           int
+		name                       string
+		LoadBalancerSourceRanges   []string
+		resourceRequirements       corev1.ResourceRequirements
+		config                     *v1alpha1.PDConfigWraper
+		configFrom                 *corev1.ConfigMapKeySelector
+		logTailer                  *v1alpha1.PDLogTailerSpec
+		ordinalsStart              *int32
+		probePort                  *int32
+		locationLabels             []string
+		initialClusterToken        *string
+		annotations                map[string]string
+		regionLabelRules           []v1alpha1.PDRegionLabelRule
+		containerName              *string
+		metricStorage              *string
+		dashboardAddress           *string
+		diskPressureThreshold      *int32
+		maxGRPCMessageSize         *string
+		upgradeStabilizationChecks *int32
+		imagePullPolicy            corev1.PullPolicy
+		pprofPort                  *int32
+		schedulers                 *v1alpha1.PDSchedulers
+		dataSubDir                 string
+		readinessProbeTimeout      *int32
+		readinessSidecar           *v1alpha1.PDReadinessSidecar
+		regionMerge                *v1alpha1.PDRegionMergeConfig
+		maintenanceToleration      *v1alpha1.PDMaintenanceToleration
+		disableDashboard           *bool
+		minResolvedTSInterval      *string
+		clockSkewThreshold         *string
+		labelProperties            []v1alpha1.PDLabelProperty
+		peerURLScheme              *string
+		tsoSaveInterval            *string
+		tsoUpdatePhysicalInterval  *string
+		startupStaggerSeconds      *int32
+		hotRegionScheduleLimit     *int64
+		splitMergeInterval         *string
+		saTokenAudience            *string
+		flushMetricsOnShutdown     *v1alpha1.PDFlushMetricsConfig
+		dashboardPublicPathPrefix  *string
+		failoverReadinessDelay     *int32
+		maxStorageUtilization      *int32
+		configUpdateStrategy       *v1alpha1.ConfigUpdateStrategy
+		expectedErrors             int
 	}{
 		{
 			name: "has valid LoadBalancerSourceRanges",
@@ -781,19 +863,991 @@ func TestValidatePDSpec(t *testing.T) {
 			},
 			expectedErrors: 1,
 		},
+		{
+			name: "has valid configFrom",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			configFrom: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"},
+				Key:                  "config-file",
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has both config and configFrom",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			config: v1alpha1.NewPDConfig(),
+			configFrom: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"},
+				Key:                  "config-file",
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "has configFrom without name and key",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			configFrom:     &corev1.ConfigMapKeySelector{},
+			expectedErrors: 2,
+		},
+		{
+			name: "has invalid log rotation settings",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			logTailer: &v1alpha1.PDLogTailerSpec{
+				MaxSize:    pointer.Int32Ptr(0),
+				MaxBackups: pointer.Int32Ptr(-1),
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "has valid ordinals start",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			ordinalsStart:  pointer.Int32Ptr(3),
+			expectedErrors: 0,
+		},
+		{
+			name: "has negative ordinals start",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			ordinalsStart:  pointer.Int32Ptr(-1),
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid probe port",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			probePort:      pointer.Int32Ptr(15021),
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid probe port",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			probePort:      pointer.Int32Ptr(0),
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid location labels",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			locationLabels: []string{"zone", "topology.kubernetes.io/zone", "kubernetes.io/hostname"},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid and duplicated location labels",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			locationLabels: []string{"zone", "zone", "invalid label"},
+			expectedErrors: 2,
+		},
+		{
+			name: "has valid initial cluster token",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			initialClusterToken: pointer.StringPtr("pd-cluster-a"),
+			expectedErrors:      0,
+		},
+		{
+			name: "has empty initial cluster token",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			initialClusterToken: pointer.StringPtr(""),
+			expectedErrors:      1,
+		},
+		{
+			name: "has service mesh annotations",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			annotations: map[string]string{
+				"sidecar.istio.io/inject":      "false",
+				"linkerd.io/inject":            "disabled",
+				"traffic.sidecar.istio.io/foo": "bar",
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid annotation key",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			annotations: map[string]string{
+				"sidecar.istio.io/inject/": "false",
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid region label rules",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			regionLabelRules: []v1alpha1.PDRegionLabelRule{
+				{
+					ID:        "tenant-a",
+					Labels:    []v1alpha1.PDRegionLabel{{Key: "tenant", Value: "a"}},
+					KeyRanges: []v1alpha1.PDKeyRange{{StartKey: "7480", EndKey: "7490"}, {StartKey: "7500"}},
+				},
+				{
+					ID:        "tenant-b",
+					Labels:    []v1alpha1.PDRegionLabel{{Key: "tenant", Value: "b"}},
+					KeyRanges: []v1alpha1.PDKeyRange{{EndKey: "7480"}},
+				},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid region label rules",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			regionLabelRules: []v1alpha1.PDRegionLabelRule{
+				{
+					ID:        "tenant-a",
+					Labels:    []v1alpha1.PDRegionLabel{{Key: "tenant", Value: "a"}},
+					KeyRanges: []v1alpha1.PDKeyRange{{StartKey: "7490", EndKey: "7480"}},
+				},
+				{
+					ID:        "tenant-a",
+					Labels:    []v1alpha1.PDRegionLabel{{Value: "b"}},
+					KeyRanges: []v1alpha1.PDKeyRange{{StartKey: "not-hex"}},
+				},
+				{
+					Labels: []v1alpha1.PDRegionLabel{},
+				},
+			},
+			// reversed range, duplicated id, empty key, invalid hex, empty id, no labels and no key ranges
+			expectedErrors: 7,
+		},
+		{
+			name: "has valid container name",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			containerName:  pointer.StringPtr("placement-driver"),
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid container name",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			containerName:  pointer.StringPtr("PD_Server"),
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid observability endpoints",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			metricStorage:    pointer.StringPtr("http://prometheus.monitoring:9090"),
+			dashboardAddress: pointer.StringPtr("auto"),
+			expectedErrors:   0,
+		},
+		{
+			name: "has invalid observability endpoints",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			metricStorage:    pointer.StringPtr("prometheus:9090"),
+			dashboardAddress: pointer.StringPtr("tcp://basic-pd-0:2379"),
+			expectedErrors:   2,
+		},
+		{
+			name: "has valid disk pressure threshold",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			diskPressureThreshold: pointer.Int32Ptr(85),
+			expectedErrors:        0,
+		},
+		{
+			name: "has invalid disk pressure threshold",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			diskPressureThreshold: pointer.Int32Ptr(101),
+			expectedErrors:        1,
+		},
+		{
+			name: "has valid max gRPC message size",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maxGRPCMessageSize: pointer.StringPtr("16Mi"),
+			expectedErrors:     0,
+		},
+		{
+			name: "has invalid max gRPC message size",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maxGRPCMessageSize: pointer.StringPtr("16 MiB"),
+			expectedErrors:     1,
+		},
+		{
+			name: "has invalid upgrade stabilization checks",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			upgradeStabilizationChecks: pointer.Int32Ptr(-1),
+			expectedErrors:             1,
+		},
+		{
+			name: "has valid image pull policy",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			imagePullPolicy: corev1.PullIfNotPresent,
+			expectedErrors:  0,
+		},
+		{
+			name: "has invalid image pull policy",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			imagePullPolicy: corev1.PullPolicy("IfMissing"),
+			expectedErrors:  1,
+		},
+		{
+			name: "has valid pprof port",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			pprofPort:      pointer.Int32Ptr(6060),
+			expectedErrors: 0,
+		},
+		{
+			name: "has pprof port conflicting with the client port",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			pprofPort:      pointer.Int32Ptr(2379),
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid schedulers",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			schedulers: &v1alpha1.PDSchedulers{
+				Add:    []string{"balance-hot-region-scheduler"},
+				Remove: []string{"balance-leader-scheduler"},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid schedulers",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			schedulers: &v1alpha1.PDSchedulers{
+				Add:    []string{"", "balance-leader-scheduler"},
+				Remove: []string{"balance-leader-scheduler"},
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "has valid dataSubDir",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			dataSubDir:     "pd/data",
+			expectedErrors: 0,
+		},
+		{
+			name: "has absolute dataSubDir",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			dataSubDir:     "/var/lib/pd/data",
+			expectedErrors: 1,
+		},
+		{
+			name: "has dataSubDir out of the mount path",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			dataSubDir:     "../data",
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid readiness sidecar",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			readinessSidecar: &v1alpha1.PDReadinessSidecar{Port: 8080, Path: "/ready"},
+			expectedErrors:   0,
+		},
+		{
+			name: "has valid readiness probe timeout",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			readinessProbeTimeout: pointer.Int32Ptr(3),
+			expectedErrors:        0,
+		},
+		{
+			name: "has invalid readiness probe timeout",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			readinessProbeTimeout: pointer.Int32Ptr(0),
+			expectedErrors:        1,
+		},
+		{
+			name: "has invalid readiness sidecar",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			readinessSidecar: &v1alpha1.PDReadinessSidecar{Port: 0, Path: "ready"},
+			expectedErrors:   2,
+		},
+		{
+			name: "has valid region merge",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			regionMerge: &v1alpha1.PDRegionMergeConfig{
+				MaxMergeRegionSize: pointer.Int64Ptr(0),
+				MaxMergeRegionKeys: pointer.Int64Ptr(200000),
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid region merge",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			regionMerge: &v1alpha1.PDRegionMergeConfig{
+				MaxMergeRegionSize: pointer.Int64Ptr(-1),
+				MaxMergeRegionKeys: pointer.Int64Ptr(-1),
+			},
+			expectedErrors: 2,
+		},
+		{
+			name: "has valid maintenance toleration",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maintenanceToleration: &v1alpha1.PDMaintenanceToleration{
+				Key:               "example.com/maintenance",
+				Value:             "planned",
+				TolerationSeconds: pointer.Int64Ptr(3600),
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid maintenance toleration",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maintenanceToleration: &v1alpha1.PDMaintenanceToleration{
+				Key:               "-maintenance",
+				Value:             "planned window",
+				TolerationSeconds: pointer.Int64Ptr(-1),
+			},
+			expectedErrors: 3,
+		},
+		{
+			name: "has dashboard disabled with dashboard address none",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			disableDashboard: pointer.BoolPtr(true),
+			dashboardAddress: pointer.StringPtr("none"),
+			expectedErrors:   0,
+		},
+		{
+			name: "has dashboard disabled with dashboard address auto",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			disableDashboard: pointer.BoolPtr(true),
+			dashboardAddress: pointer.StringPtr("auto"),
+			expectedErrors:   1,
+		},
+		{
+			name: "has valid min resolved ts persistence interval",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			minResolvedTSInterval: pointer.StringPtr("0s"),
+			expectedErrors:        0,
+		},
+		{
+			name: "has invalid min resolved ts persistence interval",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			minResolvedTSInterval: pointer.StringPtr("-1s"),
+			expectedErrors:        1,
+		},
+		{
+			name: "has valid clock skew threshold",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			clockSkewThreshold: pointer.StringPtr("2s"),
+			expectedErrors:     0,
+		},
+		{
+			name: "has invalid clock skew threshold",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			clockSkewThreshold: pointer.StringPtr("0s"),
+			expectedErrors:     1,
+		},
+		{
+			name: "has valid label properties",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			labelProperties: []v1alpha1.PDLabelProperty{
+				{Type: "reject-leader", Key: "zone", Value: "z1"},
+				{Type: "reject-leader", Key: "zone", Value: "z2"},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid label properties",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			labelProperties: []v1alpha1.PDLabelProperty{
+				{Type: "reject-leader", Key: "zone", Value: "z1"},
+				{Type: "reject-leader", Key: "zone", Value: "z1"},
+				{Type: "", Key: "zone", Value: ""},
+			},
+			expectedErrors: 3,
+		},
+		{
+			name: "has valid peer url scheme",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			peerURLScheme:  pointer.StringPtr("https"),
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid peer url scheme",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			peerURLScheme:  pointer.StringPtr("tcp"),
+			expectedErrors: 1,
+		},
+		{
+			name: "has valid tso intervals",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			tsoSaveInterval:           pointer.StringPtr("3s"),
+			tsoUpdatePhysicalInterval: pointer.StringPtr("50ms"),
+			expectedErrors:            0,
+		},
+		{
+			name: "has invalid tso intervals",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			tsoSaveInterval:           pointer.StringPtr("0s"),
+			tsoUpdatePhysicalInterval: pointer.StringPtr("20s"),
+			expectedErrors:            2,
+		},
+		{
+			name: "has invalid startup stagger",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			startupStaggerSeconds: pointer.Int32Ptr(-1),
+			expectedErrors:        1,
+		},
+		{
+			name: "has valid schedule config",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			splitMergeInterval:     pointer.StringPtr("30m"),
+			expectedErrors:         0,
+		},
+		{
+			name: "has invalid schedule config",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			hotRegionScheduleLimit: pointer.Int64Ptr(-1),
+			splitMergeInterval:     pointer.StringPtr("1 hour"),
+			expectedErrors:         2,
+		},
+		{
+			name: "has valid service account token audience",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			saTokenAudience: pointer.StringPtr("sts.amazonaws.com"),
+			expectedErrors:  0,
+		},
+		{
+			name: "has empty service account token audience",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			saTokenAudience: pointer.StringPtr(""),
+			expectedErrors:  1,
+		},
+		{
+			name: "has valid flush metrics on shutdown",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			flushMetricsOnShutdown: &v1alpha1.PDFlushMetricsConfig{
+				PushgatewayURL: "http://pushgateway:9091",
+				Job:            pointer.StringPtr("pd-final"),
+				TimeoutSeconds: pointer.Int32Ptr(3),
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid flush metrics on shutdown",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			flushMetricsOnShutdown: &v1alpha1.PDFlushMetricsConfig{
+				PushgatewayURL: "pushgateway:9091",
+				Job:            pointer.StringPtr("pd/final"),
+				TimeoutSeconds: pointer.Int32Ptr(0),
+			},
+			expectedErrors: 3,
+		},
+		{
+			name: "has valid dashboard public path prefix",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			dashboardPublicPathPrefix: pointer.StringPtr("/pd-dashboard"),
+			expectedErrors:            0,
+		},
+		{
+			name: "has relative dashboard public path prefix",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			dashboardPublicPathPrefix: pointer.StringPtr("pd-dashboard"),
+			expectedErrors:            1,
+		},
+		{
+			name: "has negative failover readiness initial delay",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			failoverReadinessDelay: pointer.Int32Ptr(-1),
+			expectedErrors:         1,
+		},
+		{
+			name: "has valid max storage utilization for scale",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maxStorageUtilization: pointer.Int32Ptr(80),
+			expectedErrors:        0,
+		},
+		{
+			name: "has invalid max storage utilization for scale",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			maxStorageUtilization: pointer.Int32Ptr(101),
+			expectedErrors:        1,
+		},
+		{
+			name: "has valid config update strategy",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			configUpdateStrategy: func(s v1alpha1.ConfigUpdateStrategy) *v1alpha1.ConfigUpdateStrategy {
+				return &s
+			}(v1alpha1.ConfigUpdateStrategyRollingUpdate),
+			expectedErrors: 0,
+		},
+		{
+			name: "has invalid config update strategy",
+			resourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+			configUpdateStrategy: func(s v1alpha1.ConfigUpdateStrategy) *v1alpha1.ConfigUpdateStrategy {
+				return &s
+			}("Rolling"),
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbCluster()
+			tc.Spec.PD.ResourceRequirements = tt.resourceRequirements
+			tc.Spec.PD.Service = &v1alpha1.ServiceSpec{
+				LoadBalancerSourceRanges: tt.LoadBalancerSourceRanges,
+			}
+			tc.Spec.PD.Config = tt.config
+			tc.Spec.PD.ConfigFrom = tt.configFrom
+			tc.Spec.PD.LogTailer = tt.logTailer
+			tc.Spec.PD.OrdinalsStart = tt.ordinalsStart
+			tc.Spec.PD.ProbePort = tt.probePort
+			tc.Spec.PD.LocationLabels = tt.locationLabels
+			tc.Spec.PD.InitialClusterToken = tt.initialClusterToken
+			tc.Spec.PD.Annotations = tt.annotations
+			tc.Spec.PD.RegionLabelRules = tt.regionLabelRules
+			tc.Spec.PD.ContainerName = tt.containerName
+			tc.Spec.PD.MetricStorage = tt.metricStorage
+			tc.Spec.PD.DashboardAddress = tt.dashboardAddress
+			tc.Spec.PD.DiskPressureThreshold = tt.diskPressureThreshold
+			tc.Spec.PD.MaxGRPCMessageSize = tt.maxGRPCMessageSize
+			tc.Spec.PD.UpgradeStabilizationChecks = tt.upgradeStabilizationChecks
+			tc.Spec.PD.PprofPort = tt.pprofPort
+			tc.Spec.PD.Schedulers = tt.schedulers
+			tc.Spec.PD.DataSubDir = tt.dataSubDir
+			if tt.readinessProbeTimeout != nil {
+				tc.Spec.PD.ReadinessProbe = &v1alpha1.Probe{TimeoutSeconds: tt.readinessProbeTimeout}
+			}
+			tc.Spec.PD.ReadinessSidecar = tt.readinessSidecar
+			tc.Spec.PD.RegionMerge = tt.regionMerge
+			tc.Spec.PD.MaintenanceToleration = tt.maintenanceToleration
+			tc.Spec.PD.DisableDashboard = tt.disableDashboard
+			tc.Spec.PD.MinResolvedTSPersistenceInterval = tt.minResolvedTSInterval
+			tc.Spec.PD.ClockSkewThreshold = tt.clockSkewThreshold
+			tc.Spec.PD.LabelProperties = tt.labelProperties
+			tc.Spec.PD.PeerURLScheme = tt.peerURLScheme
+			tc.Spec.PD.TSOSaveInterval = tt.tsoSaveInterval
+			tc.Spec.PD.TSOUpdatePhysicalInterval = tt.tsoUpdatePhysicalInterval
+			tc.Spec.PD.StartupStaggerSeconds = tt.startupStaggerSeconds
+			tc.Spec.PD.HotRegionScheduleLimit = tt.hotRegionScheduleLimit
+			tc.Spec.PD.SplitMergeInterval = tt.splitMergeInterval
+			tc.Spec.PD.ServiceAccountTokenAudience = tt.saTokenAudience
+			tc.Spec.PD.FlushMetricsOnShutdown = tt.flushMetricsOnShutdown
+			tc.Spec.PD.DashboardPublicPathPrefix = tt.dashboardPublicPathPrefix
+			tc.Spec.PD.FailoverReadinessInitialDelaySeconds = tt.failoverReadinessDelay
+			tc.Spec.PD.MaxStorageUtilizationForScale = tt.maxStorageUtilization
+			tc.Spec.PD.ConfigUpdateStrategy = tt.configUpdateStrategy
+			if tt.imagePullPolicy != "" {
+				tc.Spec.PD.ImagePullPolicy = &tt.imagePullPolicy
+			}
+			err := validatePDSpec(tc.Spec.PD, field.NewPath("pd"))
+			r := len(err)
+			g.Expect(r).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidatePDReplicationMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+	drAutoSync := func() *v1alpha1.PDReplicationModeConfig {
+		return &v1alpha1.PDReplicationModeConfig{
+			Mode:             v1alpha1.PDReplicationModeDRAutoSync,
+			LabelKey:         pointer.StringPtr("zone"),
+			Primary:          pointer.StringPtr("east"),
+			DR:               pointer.StringPtr("west"),
+			PrimaryReplicas:  pointer.Int32Ptr(2),
+			DRReplicas:       pointer.Int32Ptr(1),
+			WaitStoreTimeout: pointer.StringPtr("1m"),
+		}
+	}
+	tests := []struct {
+		name           string
+		update         func(rm *v1alpha1.PDReplicationModeConfig)
+		expectedFields []string
+	}{
+		{
+			name: "valid dr-auto-sync",
+		},
+		{
+			name: "valid majority",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				*rm = v1alpha1.PDReplicationModeConfig{Mode: v1alpha1.PDReplicationModeMajority}
+			},
+		},
+		{
+			name: "unsupported mode",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				rm.Mode = "async"
+			},
+			expectedFields: []string{"pd.replicationMode.mode"},
+		},
+		{
+			name: "dr-auto-sync fields are set with majority",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				rm.Mode = v1alpha1.PDReplicationModeMajority
+			},
+			expectedFields: []string{"pd.replicationMode"},
+		},
+		{
+			name: "dr-auto-sync without label key, primary and dr",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				rm.LabelKey = nil
+				rm.Primary = pointer.StringPtr("")
+				rm.DR = nil
+			},
+			expectedFields: []string{"pd.replicationMode.labelKey", "pd.replicationMode.primary", "pd.replicationMode.dr"},
+		},
+		{
+			name: "dr-auto-sync with the same primary and dr",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				rm.DR = pointer.StringPtr("east")
+			},
+			expectedFields: []string{"pd.replicationMode.dr"},
+		},
+		{
+			name: "dr-auto-sync with invalid label key, replicas and timeout",
+			update: func(rm *v1alpha1.PDReplicationModeConfig) {
+				rm.LabelKey = pointer.StringPtr("invalid label")
+				rm.PrimaryReplicas = pointer.Int32Ptr(0)
+				rm.DRReplicas = pointer.Int32Ptr(-1)
+				rm.WaitStoreTimeout = pointer.StringPtr("one minute")
+			},
+			expectedFields: []string{
+				"pd.replicationMode.labelKey",
+				"pd.replicationMode.primaryReplicas",
+				"pd.replicationMode.drReplicas",
+				"pd.replicationMode.waitStoreTimeout",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := drAutoSync()
+			if tt.update != nil {
+				tt.update(rm)
+			}
+			var fields []string
+			for _, err := range validatePDReplicationMode(rm, field.NewPath("pd", "replicationMode")) {
+				fields = append(fields, err.Field)
+			}
+			if len(tt.expectedFields) == 0 {
+				g.Expect(fields).To(BeEmpty())
+			} else {
+				g.Expect(fields).To(Equal(tt.expectedFields))
+			}
+		})
+	}
+}
+
+func TestValidateUpdatePDOrdinalsStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name        string
+		oldStart    *int32
+		newStart    *int32
+		expectError bool
+	}{
+		{
+			name:        "ordinals start is not set",
+			expectError: false,
+		},
+		{
+			name:        "ordinals start is unchanged",
+			oldStart:    pointer.Int32Ptr(3),
+			newStart:    pointer.Int32Ptr(3),
+			expectError: false,
+		},
+		{
+			name:        "ordinals start is set after creation",
+			newStart:    pointer.Int32Ptr(3),
+			expectError: true,
+		},
+		{
+			name:        "ordinals start is changed",
+			oldStart:    pointer.Int32Ptr(3),
+			newStart:    pointer.Int32Ptr(0),
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newTidbCluster()
+			old.Spec.PD.OrdinalsStart = tt.oldStart
+			tc := old.DeepCopy()
+			tc.Spec.PD.OrdinalsStart = tt.newStart
+			found := false
+			for _, err := range ValidateUpdateTidbCluster(old, tc) {
+				if err.Field == "spec.pd.ordinalsStart" {
+					found = true
+				}
+			}
+			g.Expect(found).To(Equal(tt.expectError))
+		})
+	}
+}
+
+func TestValidatePDOrdinalsStartWithAdvancedStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+	saved := features.DefaultFeatureGate.String()
+	features.DefaultFeatureGate.Set("AdvancedStatefulSet=true")
+	defer features.DefaultFeatureGate.Set(saved) // reset features on exit
+
+	ordinalsStartErrs := func(start int32) int {
+		tc := newTidbCluster()
+		tc.Spec.PD.OrdinalsStart = pointer.Int32Ptr(start)
+		n := 0
+		for _, err := range validatePDSpec(tc.Spec.PD, field.NewPath("pd")) {
+			if err.Field == "pd.ordinalsStart" {
+				n++
+			}
+		}
+		return n
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tc := newTidbCluster()
-			tc.Spec.PD.ResourceRequirements = tt.resourceRequirements
-			tc.Spec.PD.Service = &v1alpha1.ServiceSpec{
-				LoadBalancerSourceRanges: tt.LoadBalancerSourceRanges,
-			}
-			err := validatePDSpec(tc.Spec.PD, field.NewPath("pd"))
-			r := len(err)
-			g.Expect(r).Should(Equal(tt.expectedErrors))
-		})
-	}
+	g.Expect(ordinalsStartErrs(0)).To(Equal(0))
+	g.Expect(ordinalsStartErrs(3)).To(Equal(1))
 }
 
 func TestValidateTiFlashSpec(t *testing.T) {
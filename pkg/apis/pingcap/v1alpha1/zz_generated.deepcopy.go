@@ -3139,6 +3139,32 @@ func (in *OpenTracingSampler) DeepCopy() *OpenTracingSampler {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDAuditConfig) DeepCopyInto(out *PDAuditConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RedactInfoLog != nil {
+		in, out := &in.RedactInfoLog, &out.RedactInfoLog
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDAuditConfig.
+func (in *PDAuditConfig) DeepCopy() *PDAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDConfig) DeepCopyInto(out *PDConfig) {
 	*out = *in
@@ -3333,6 +3359,81 @@ func (in *PDFailureMember) DeepCopy() *PDFailureMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDFlushMetricsConfig) DeepCopyInto(out *PDFlushMetricsConfig) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDFlushMetricsConfig.
+func (in *PDFlushMetricsConfig) DeepCopy() *PDFlushMetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDFlushMetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDForceNewCluster) DeepCopyInto(out *PDForceNewCluster) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDForceNewCluster.
+func (in *PDForceNewCluster) DeepCopy() *PDForceNewCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(PDForceNewCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDKeyRange) DeepCopyInto(out *PDKeyRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDKeyRange.
+func (in *PDKeyRange) DeepCopy() *PDKeyRange {
+	if in == nil {
+		return nil
+	}
+	out := new(PDKeyRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDLabelProperty) DeepCopyInto(out *PDLabelProperty) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLabelProperty.
+func (in *PDLabelProperty) DeepCopy() *PDLabelProperty {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLabelProperty)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PDLabelPropertyConfig) DeepCopyInto(out *PDLabelPropertyConfig) {
 	{
@@ -3365,6 +3466,23 @@ func (in PDLabelPropertyConfig) DeepCopy() PDLabelPropertyConfig {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDLeaderChange) DeepCopyInto(out *PDLeaderChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLeaderChange.
+func (in *PDLeaderChange) DeepCopy() *PDLeaderChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLeaderChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDLogConfig) DeepCopyInto(out *PDLogConfig) {
 	*out = *in
@@ -3421,6 +3539,33 @@ func (in *PDLogConfig) DeepCopy() *PDLogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDLogTailerSpec) DeepCopyInto(out *PDLogTailerSpec) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLogTailerSpec.
+func (in *PDLogTailerSpec) DeepCopy() *PDLogTailerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLogTailerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDMSSpec) DeepCopyInto(out *PDMSSpec) {
 	*out = *in
@@ -3529,6 +3674,27 @@ func (in *PDMSStatus) DeepCopy() *PDMSStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDMaintenanceToleration) DeepCopyInto(out *PDMaintenanceToleration) {
+	*out = *in
+	if in.TolerationSeconds != nil {
+		in, out := &in.TolerationSeconds, &out.TolerationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDMaintenanceToleration.
+func (in *PDMaintenanceToleration) DeepCopy() *PDMaintenanceToleration {
+	if in == nil {
+		return nil
+	}
+	out := new(PDMaintenanceToleration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDMember) DeepCopyInto(out *PDMember) {
 	*out = *in
@@ -3546,6 +3712,22 @@ func (in *PDMember) DeepCopy() *PDMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDMemberRemoval) DeepCopyInto(out *PDMemberRemoval) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDMemberRemoval.
+func (in *PDMemberRemoval) DeepCopy() *PDMemberRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(PDMemberRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDMetricConfig) DeepCopyInto(out *PDMetricConfig) {
 	*out = *in
@@ -3577,6 +3759,34 @@ func (in *PDMetricConfig) DeepCopy() *PDMetricConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDNetworkPolicy) DeepCopyInto(out *PDNetworkPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDNetworkPolicy.
+func (in *PDNetworkPolicy) DeepCopy() *PDNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PDNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDNamespaceConfig) DeepCopyInto(out *PDNamespaceConfig) {
 	*out = *in
@@ -3623,6 +3833,100 @@ func (in *PDNamespaceConfig) DeepCopy() *PDNamespaceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDReadinessSidecar) DeepCopyInto(out *PDReadinessSidecar) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDReadinessSidecar.
+func (in *PDReadinessSidecar) DeepCopy() *PDReadinessSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(PDReadinessSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRegionLabel) DeepCopyInto(out *PDRegionLabel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRegionLabel.
+func (in *PDRegionLabel) DeepCopy() *PDRegionLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRegionLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRegionLabelRule) DeepCopyInto(out *PDRegionLabelRule) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]PDRegionLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyRanges != nil {
+		in, out := &in.KeyRanges, &out.KeyRanges
+		*out = make([]PDKeyRange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRegionLabelRule.
+func (in *PDRegionLabelRule) DeepCopy() *PDRegionLabelRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRegionLabelRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRegionMergeConfig) DeepCopyInto(out *PDRegionMergeConfig) {
+	*out = *in
+	if in.EnableCrossTableMerge != nil {
+		in, out := &in.EnableCrossTableMerge, &out.EnableCrossTableMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxMergeRegionSize != nil {
+		in, out := &in.MaxMergeRegionSize, &out.MaxMergeRegionSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMergeRegionKeys != nil {
+		in, out := &in.MaxMergeRegionKeys, &out.MaxMergeRegionKeys
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HotReload != nil {
+		in, out := &in.HotReload, &out.HotReload
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRegionMergeConfig.
+func (in *PDRegionMergeConfig) DeepCopy() *PDRegionMergeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRegionMergeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDReplicationConfig) DeepCopyInto(out *PDReplicationConfig) {
 	*out = *in
@@ -3660,35 +3964,81 @@ func (in *PDReplicationConfig) DeepCopy() *PDReplicationConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDScheduleConfig) DeepCopyInto(out *PDScheduleConfig) {
+func (in *PDReplicationModeConfig) DeepCopyInto(out *PDReplicationModeConfig) {
 	*out = *in
-	if in.MaxSnapshotCount != nil {
-		in, out := &in.MaxSnapshotCount, &out.MaxSnapshotCount
-		*out = new(uint64)
+	if in.LabelKey != nil {
+		in, out := &in.LabelKey, &out.LabelKey
+		*out = new(string)
 		**out = **in
 	}
-	if in.MaxPendingPeerCount != nil {
-		in, out := &in.MaxPendingPeerCount, &out.MaxPendingPeerCount
-		*out = new(uint64)
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(string)
 		**out = **in
 	}
-	if in.MaxMergeRegionSize != nil {
-		in, out := &in.MaxMergeRegionSize, &out.MaxMergeRegionSize
-		*out = new(uint64)
+	if in.DR != nil {
+		in, out := &in.DR, &out.DR
+		*out = new(string)
 		**out = **in
 	}
-	if in.MaxMergeRegionKeys != nil {
-		in, out := &in.MaxMergeRegionKeys, &out.MaxMergeRegionKeys
-		*out = new(uint64)
+	if in.PrimaryReplicas != nil {
+		in, out := &in.PrimaryReplicas, &out.PrimaryReplicas
+		*out = new(int32)
 		**out = **in
 	}
-	if in.SplitMergeInterval != nil {
-		in, out := &in.SplitMergeInterval, &out.SplitMergeInterval
-		*out = new(string)
+	if in.DRReplicas != nil {
+		in, out := &in.DRReplicas, &out.DRReplicas
+		*out = new(int32)
 		**out = **in
 	}
-	if in.PatrolRegionInterval != nil {
-		in, out := &in.PatrolRegionInterval, &out.PatrolRegionInterval
+	if in.WaitStoreTimeout != nil {
+		in, out := &in.WaitStoreTimeout, &out.WaitStoreTimeout
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDReplicationModeConfig.
+func (in *PDReplicationModeConfig) DeepCopy() *PDReplicationModeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDReplicationModeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDScheduleConfig) DeepCopyInto(out *PDScheduleConfig) {
+	*out = *in
+	if in.MaxSnapshotCount != nil {
+		in, out := &in.MaxSnapshotCount, &out.MaxSnapshotCount
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaxPendingPeerCount != nil {
+		in, out := &in.MaxPendingPeerCount, &out.MaxPendingPeerCount
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaxMergeRegionSize != nil {
+		in, out := &in.MaxMergeRegionSize, &out.MaxMergeRegionSize
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaxMergeRegionKeys != nil {
+		in, out := &in.MaxMergeRegionKeys, &out.MaxMergeRegionKeys
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.SplitMergeInterval != nil {
+		in, out := &in.SplitMergeInterval, &out.SplitMergeInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.PatrolRegionInterval != nil {
+		in, out := &in.PatrolRegionInterval, &out.PatrolRegionInterval
 		*out = new(string)
 		**out = **in
 	}
@@ -3747,238 +4097,595 @@ func (in *PDScheduleConfig) DeepCopyInto(out *PDScheduleConfig) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableRemoveDownReplica != nil {
-		in, out := &in.DisableRemoveDownReplica, &out.DisableRemoveDownReplica
+	if in.DisableRemoveDownReplica != nil {
+		in, out := &in.DisableRemoveDownReplica, &out.DisableRemoveDownReplica
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableReplaceOfflineReplica != nil {
+		in, out := &in.DisableReplaceOfflineReplica, &out.DisableReplaceOfflineReplica
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableMakeUpReplica != nil {
+		in, out := &in.DisableMakeUpReplica, &out.DisableMakeUpReplica
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableRemoveExtraReplica != nil {
+		in, out := &in.DisableRemoveExtraReplica, &out.DisableRemoveExtraReplica
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableLocationReplacement != nil {
+		in, out := &in.DisableLocationReplacement, &out.DisableLocationReplacement
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableNamespaceRelocation != nil {
+		in, out := &in.DisableNamespaceRelocation, &out.DisableNamespaceRelocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Schedulers != nil {
+		in, out := &in.Schedulers, &out.Schedulers
+		*out = make(PDSchedulerConfigs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SchedulersPayload != nil {
+		in, out := &in.SchedulersPayload, &out.SchedulersPayload
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnableOneWayMerge != nil {
+		in, out := &in.EnableOneWayMerge, &out.EnableOneWayMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableCrossTableMerge != nil {
+		in, out := &in.EnableCrossTableMerge, &out.EnableCrossTableMerge
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDScheduleConfig.
+func (in *PDScheduleConfig) DeepCopy() *PDScheduleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDScheduleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDSchedulerConfig) DeepCopyInto(out *PDSchedulerConfig) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSchedulerConfig.
+func (in *PDSchedulerConfig) DeepCopy() *PDSchedulerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDSchedulerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PDSchedulerConfigs) DeepCopyInto(out *PDSchedulerConfigs) {
+	{
+		in := &in
+		*out = make(PDSchedulerConfigs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSchedulerConfigs.
+func (in PDSchedulerConfigs) DeepCopy() PDSchedulerConfigs {
+	if in == nil {
+		return nil
+	}
+	out := new(PDSchedulerConfigs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDSchedulers) DeepCopyInto(out *PDSchedulers) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSchedulers.
+func (in *PDSchedulers) DeepCopy() *PDSchedulers {
+	if in == nil {
+		return nil
+	}
+	out := new(PDSchedulers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDSecurityConfig) DeepCopyInto(out *PDSecurityConfig) {
+	*out = *in
+	if in.CAPath != nil {
+		in, out := &in.CAPath, &out.CAPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertPath != nil {
+		in, out := &in.CertPath, &out.CertPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyPath != nil {
+		in, out := &in.KeyPath, &out.KeyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertAllowedCN != nil {
+		in, out := &in.CertAllowedCN, &out.CertAllowedCN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSecurityConfig.
+func (in *PDSecurityConfig) DeepCopy() *PDSecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDSecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDServerConfig) DeepCopyInto(out *PDServerConfig) {
+	*out = *in
+	if in.UseRegionStorage != nil {
+		in, out := &in.UseRegionStorage, &out.UseRegionStorage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetricStorage != nil {
+		in, out := &in.MetricStorage, &out.MetricStorage
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDServerConfig.
+func (in *PDServerConfig) DeepCopy() *PDServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDSpec) DeepCopyInto(out *PDSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxFailoverCount != nil {
+		in, out := &in.MaxFailoverCount, &out.MaxFailoverCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageVolumes != nil {
+		in, out := &in.StorageVolumes, &out.StorageVolumes
+		*out = make([]StorageVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(PDConfigWraper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSClientSecretName != nil {
+		in, out := &in.TLSClientSecretName, &out.TLSClientSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableDashboardInternalProxy != nil {
+		in, out := &in.EnableDashboardInternalProxy, &out.EnableDashboardInternalProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MountClusterClientSecret != nil {
+		in, out := &in.MountClusterClientSecret, &out.MountClusterClientSecret
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SpareVolReplaceReplicas != nil {
+		in, out := &in.SpareVolReplaceReplicas, &out.SpareVolReplaceReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FSGroupChangePolicy != nil {
+		in, out := &in.FSGroupChangePolicy, &out.FSGroupChangePolicy
+		*out = new(v1.PodFSGroupChangePolicy)
+		**out = **in
+	}
+	if in.PrometheusScrapeAnnotations != nil {
+		in, out := &in.PrometheusScrapeAnnotations, &out.PrometheusScrapeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnableLeaderService != nil {
+		in, out := &in.EnableLeaderService, &out.EnableLeaderService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogTailer != nil {
+		in, out := &in.LogTailer, &out.LogTailer
+		*out = new(PDLogTailerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoreLimits != nil {
+		in, out := &in.StoreLimits, &out.StoreLimits
+		*out = new(PDStoreLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WaitForDNS != nil {
+		in, out := &in.WaitForDNS, &out.WaitForDNS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OrdinalsStart != nil {
+		in, out := &in.OrdinalsStart, &out.OrdinalsStart
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AuditConfig != nil {
+		in, out := &in.AuditConfig, &out.AuditConfig
+		*out = new(PDAuditConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProbePort != nil {
+		in, out := &in.ProbePort, &out.ProbePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LocationLabels != nil {
+		in, out := &in.LocationLabels, &out.LocationLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Overhead != nil {
+		in, out := &in.Overhead, &out.Overhead
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.ExportEffectiveConfig != nil {
+		in, out := &in.ExportEffectiveConfig, &out.ExportEffectiveConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CheckVolumeWritable != nil {
+		in, out := &in.CheckVolumeWritable, &out.CheckVolumeWritable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InitialClusterToken != nil {
+		in, out := &in.InitialClusterToken, &out.InitialClusterToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoTuneSchedulerLimits != nil {
+		in, out := &in.AutoTuneSchedulerLimits, &out.AutoTuneSchedulerLimits
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RegionLabelRules != nil {
+		in, out := &in.RegionLabelRules, &out.RegionLabelRules
+		*out = make([]PDRegionLabelRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainerName != nil {
+		in, out := &in.ContainerName, &out.ContainerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricStorage != nil {
+		in, out := &in.MetricStorage, &out.MetricStorage
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardAddress != nil {
+		in, out := &in.DashboardAddress, &out.DashboardAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiskPressureThreshold != nil {
+		in, out := &in.DiskPressureThreshold, &out.DiskPressureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxGRPCMessageSize != nil {
+		in, out := &in.MaxGRPCMessageSize, &out.MaxGRPCMessageSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardTiDBCAProjected != nil {
+		in, out := &in.DashboardTiDBCAProjected, &out.DashboardTiDBCAProjected
+		*out = new(v1.ProjectedVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStabilizationChecks != nil {
+		in, out := &in.UpgradeStabilizationChecks, &out.UpgradeStabilizationChecks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(PDNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnablePlacementRules != nil {
+		in, out := &in.EnablePlacementRules, &out.EnablePlacementRules
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PprofPort != nil {
+		in, out := &in.PprofPort, &out.PprofPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnablePprofService != nil {
+		in, out := &in.EnablePprofService, &out.EnablePprofService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Schedulers != nil {
+		in, out := &in.Schedulers, &out.Schedulers
+		*out = new(PDSchedulers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParallelBootstrap != nil {
+		in, out := &in.ParallelBootstrap, &out.ParallelBootstrap
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableReplaceOfflineReplica != nil {
-		in, out := &in.DisableReplaceOfflineReplica, &out.DisableReplaceOfflineReplica
+	if in.DisableTelemetry != nil {
+		in, out := &in.DisableTelemetry, &out.DisableTelemetry
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableMakeUpReplica != nil {
-		in, out := &in.DisableMakeUpReplica, &out.DisableMakeUpReplica
+	if in.TopologyAwareRouting != nil {
+		in, out := &in.TopologyAwareRouting, &out.TopologyAwareRouting
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableRemoveExtraReplica != nil {
-		in, out := &in.DisableRemoveExtraReplica, &out.DisableRemoveExtraReplica
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(v1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVCAuditAnnotations != nil {
+		in, out := &in.PVCAuditAnnotations, &out.PVCAuditAnnotations
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableLocationReplacement != nil {
-		in, out := &in.DisableLocationReplacement, &out.DisableLocationReplacement
+	if in.SafeScaleOut != nil {
+		in, out := &in.SafeScaleOut, &out.SafeScaleOut
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableNamespaceRelocation != nil {
-		in, out := &in.DisableNamespaceRelocation, &out.DisableNamespaceRelocation
-		*out = new(bool)
+	if in.ReplicationMode != nil {
+		in, out := &in.ReplicationMode, &out.ReplicationMode
+		*out = new(PDReplicationModeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessSidecar != nil {
+		in, out := &in.ReadinessSidecar, &out.ReadinessSidecar
+		*out = new(PDReadinessSidecar)
 		**out = **in
 	}
-	if in.Schedulers != nil {
-		in, out := &in.Schedulers, &out.Schedulers
-		*out = make(PDSchedulerConfigs, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RegionMerge != nil {
+		in, out := &in.RegionMerge, &out.RegionMerge
+		*out = new(PDRegionMergeConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SchedulersPayload != nil {
-		in, out := &in.SchedulersPayload, &out.SchedulersPayload
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.MaintenanceToleration != nil {
+		in, out := &in.MaintenanceToleration, &out.MaintenanceToleration
+		*out = new(PDMaintenanceToleration)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EnableOneWayMerge != nil {
-		in, out := &in.EnableOneWayMerge, &out.EnableOneWayMerge
+	if in.DisableDashboard != nil {
+		in, out := &in.DisableDashboard, &out.DisableDashboard
 		*out = new(bool)
 		**out = **in
 	}
-	if in.EnableCrossTableMerge != nil {
-		in, out := &in.EnableCrossTableMerge, &out.EnableCrossTableMerge
-		*out = new(bool)
+	if in.MinResolvedTSPersistenceInterval != nil {
+		in, out := &in.MinResolvedTSPersistenceInterval, &out.MinResolvedTSPersistenceInterval
+		*out = new(string)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDScheduleConfig.
-func (in *PDScheduleConfig) DeepCopy() *PDScheduleConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(PDScheduleConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDSchedulerConfig) DeepCopyInto(out *PDSchedulerConfig) {
-	*out = *in
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
+	if in.ClockSkewThreshold != nil {
+		in, out := &in.ClockSkewThreshold, &out.ClockSkewThreshold
 		*out = new(string)
 		**out = **in
 	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Disable != nil {
-		in, out := &in.Disable, &out.Disable
+	if in.PreferIPv6 != nil {
+		in, out := &in.PreferIPv6, &out.PreferIPv6
 		*out = new(bool)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSchedulerConfig.
-func (in *PDSchedulerConfig) DeepCopy() *PDSchedulerConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(PDSchedulerConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PDSchedulerConfigs) DeepCopyInto(out *PDSchedulerConfigs) {
-	{
-		in := &in
-		*out = make(PDSchedulerConfigs, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-		return
+	if in.LabelProperties != nil {
+		in, out := &in.LabelProperties, &out.LabelProperties
+		*out = make([]PDLabelProperty, len(*in))
+		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSchedulerConfigs.
-func (in PDSchedulerConfigs) DeepCopy() PDSchedulerConfigs {
-	if in == nil {
-		return nil
+	if in.PostStartWaitForJoin != nil {
+		in, out := &in.PostStartWaitForJoin, &out.PostStartWaitForJoin
+		*out = new(bool)
+		**out = **in
 	}
-	out := new(PDSchedulerConfigs)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDSecurityConfig) DeepCopyInto(out *PDSecurityConfig) {
-	*out = *in
-	if in.CAPath != nil {
-		in, out := &in.CAPath, &out.CAPath
+	if in.PeerURLScheme != nil {
+		in, out := &in.PeerURLScheme, &out.PeerURLScheme
 		*out = new(string)
 		**out = **in
 	}
-	if in.CertPath != nil {
-		in, out := &in.CertPath, &out.CertPath
+	if in.TSOSaveInterval != nil {
+		in, out := &in.TSOSaveInterval, &out.TSOSaveInterval
 		*out = new(string)
 		**out = **in
 	}
-	if in.KeyPath != nil {
-		in, out := &in.KeyPath, &out.KeyPath
+	if in.TSOUpdatePhysicalInterval != nil {
+		in, out := &in.TSOUpdatePhysicalInterval, &out.TSOUpdatePhysicalInterval
 		*out = new(string)
 		**out = **in
 	}
-	if in.CertAllowedCN != nil {
-		in, out := &in.CertAllowedCN, &out.CertAllowedCN
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ProjectedVolumeMounts != nil {
+		in, out := &in.ProjectedVolumeMounts, &out.ProjectedVolumeMounts
+		*out = new(bool)
+		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDSecurityConfig.
-func (in *PDSecurityConfig) DeepCopy() *PDSecurityConfig {
-	if in == nil {
-		return nil
+	if in.CheckMemberClusterID != nil {
+		in, out := &in.CheckMemberClusterID, &out.CheckMemberClusterID
+		*out = new(bool)
+		**out = **in
 	}
-	out := new(PDSecurityConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDServerConfig) DeepCopyInto(out *PDServerConfig) {
-	*out = *in
-	if in.UseRegionStorage != nil {
-		in, out := &in.UseRegionStorage, &out.UseRegionStorage
+	if in.EnableGRPCGateway != nil {
+		in, out := &in.EnableGRPCGateway, &out.EnableGRPCGateway
 		*out = new(bool)
 		**out = **in
 	}
-	if in.MetricStorage != nil {
-		in, out := &in.MetricStorage, &out.MetricStorage
-		*out = new(string)
+	if in.StartupStaggerSeconds != nil {
+		in, out := &in.StartupStaggerSeconds, &out.StartupStaggerSeconds
+		*out = new(int32)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDServerConfig.
-func (in *PDServerConfig) DeepCopy() *PDServerConfig {
-	if in == nil {
-		return nil
+	if in.HotRegionScheduleLimit != nil {
+		in, out := &in.HotRegionScheduleLimit, &out.HotRegionScheduleLimit
+		*out = new(int64)
+		**out = **in
 	}
-	out := new(PDServerConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDSpec) DeepCopyInto(out *PDSpec) {
-	*out = *in
-	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
-	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
+	if in.SplitMergeInterval != nil {
+		in, out := &in.SplitMergeInterval, &out.SplitMergeInterval
+		*out = new(string)
+		**out = **in
 	}
-	if in.MaxFailoverCount != nil {
-		in, out := &in.MaxFailoverCount, &out.MaxFailoverCount
-		*out = new(int32)
+	if in.ValidateConfigSchema != nil {
+		in, out := &in.ValidateConfigSchema, &out.ValidateConfigSchema
+		*out = new(bool)
 		**out = **in
 	}
-	if in.StorageClassName != nil {
-		in, out := &in.StorageClassName, &out.StorageClassName
+	if in.ServiceAccountTokenAudience != nil {
+		in, out := &in.ServiceAccountTokenAudience, &out.ServiceAccountTokenAudience
 		*out = new(string)
 		**out = **in
 	}
-	if in.StorageVolumes != nil {
-		in, out := &in.StorageVolumes, &out.StorageVolumes
-		*out = make([]StorageVolume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(PDConfigWraper)
+	if in.FlushMetricsOnShutdown != nil {
+		in, out := &in.FlushMetricsOnShutdown, &out.FlushMetricsOnShutdown
+		*out = new(PDFlushMetricsConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TLSClientSecretName != nil {
-		in, out := &in.TLSClientSecretName, &out.TLSClientSecretName
+	if in.DashboardPublicPathPrefix != nil {
+		in, out := &in.DashboardPublicPathPrefix, &out.DashboardPublicPathPrefix
 		*out = new(string)
 		**out = **in
 	}
-	if in.EnableDashboardInternalProxy != nil {
-		in, out := &in.EnableDashboardInternalProxy, &out.EnableDashboardInternalProxy
-		*out = new(bool)
+	if in.FailoverReadinessInitialDelaySeconds != nil {
+		in, out := &in.FailoverReadinessInitialDelaySeconds, &out.FailoverReadinessInitialDelaySeconds
+		*out = new(int32)
 		**out = **in
 	}
-	if in.MountClusterClientSecret != nil {
-		in, out := &in.MountClusterClientSecret, &out.MountClusterClientSecret
-		*out = new(bool)
+	if in.MaxStorageUtilizationForScale != nil {
+		in, out := &in.MaxStorageUtilizationForScale, &out.MaxStorageUtilizationForScale
+		*out = new(int32)
 		**out = **in
 	}
-	if in.SpareVolReplaceReplicas != nil {
-		in, out := &in.SpareVolReplaceReplicas, &out.SpareVolReplaceReplicas
-		*out = new(int32)
+	if in.EnableDiagnostic != nil {
+		in, out := &in.EnableDiagnostic, &out.EnableDiagnostic
+		*out = new(bool)
 		**out = **in
 	}
 	return
@@ -4053,6 +4760,45 @@ func (in *PDStatus) DeepCopyInto(out *PDStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AppliedRegionLabelRules != nil {
+		in, out := &in.AppliedRegionLabelRules, &out.AppliedRegionLabelRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemberRemoval != nil {
+		in, out := &in.MemberRemoval, &out.MemberRemoval
+		*out = new(PDMemberRemoval)
+		**out = **in
+	}
+	if in.UpdatePartition != nil {
+		in, out := &in.UpdatePartition, &out.UpdatePartition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ForceNewCluster != nil {
+		in, out := &in.ForceNewCluster, &out.ForceNewCluster
+		*out = new(PDForceNewCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedLabelProperties != nil {
+		in, out := &in.AppliedLabelProperties, &out.AppliedLabelProperties
+		*out = make([]PDLabelProperty, len(*in))
+		copy(*out, *in)
+	}
+	if in.MismatchedClusterIDMembers != nil {
+		in, out := &in.MismatchedClusterIDMembers, &out.MismatchedClusterIDMembers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LeaderHistory != nil {
+		in, out := &in.LeaderHistory, &out.LeaderHistory
+		*out = make([]PDLeaderChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -4114,6 +4860,32 @@ func (in PDStoreLabels) DeepCopy() PDStoreLabels {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDStoreLimits) DeepCopyInto(out *PDStoreLimits) {
+	*out = *in
+	if in.AddPeer != nil {
+		in, out := &in.AddPeer, &out.AddPeer
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RemovePeer != nil {
+		in, out := &in.RemovePeer, &out.RemovePeer
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDStoreLimits.
+func (in *PDStoreLimits) DeepCopy() *PDStoreLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(PDStoreLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Performance) DeepCopyInto(out *Performance) {
 	*out = *in
@@ -4347,6 +5119,11 @@ func (in *Probe) DeepCopyInto(out *Probe) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -5305,6 +6082,16 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExternalIPs != nil {
+		in, out := &in.ExternalIPs, &out.ExternalIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(v1.ServiceInternalTrafficPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -8356,6 +9143,16 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PDHeartbeatInterval != nil {
+		in, out := &in.PDHeartbeatInterval, &out.PDHeartbeatInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.PDStoreHeartbeatInterval != nil {
+		in, out := &in.PDStoreHeartbeatInterval, &out.PDStoreHeartbeatInterval
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
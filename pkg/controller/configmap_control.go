@@ -19,9 +19,11 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -36,6 +38,8 @@ type ConfigMapControlInterface interface {
 	CreateConfigMap(controller runtime.Object, cm *corev1.ConfigMap) (*corev1.ConfigMap, error)
 	// UpdateConfigMap continuously tries to update ConfigMap to the given state owned by the controller obejct
 	UpdateConfigMap(controller runtime.Object, cm *corev1.ConfigMap) (*corev1.ConfigMap, error)
+	// ApplyConfigMap creates or updates the ConfigMap owned by the controller object with server-side apply
+	ApplyConfigMap(controller runtime.Object, cm *corev1ac.ConfigMapApplyConfiguration) (*corev1.ConfigMap, error)
 	// DeleteConfigMap delete the given ConfigMap owned by the controller object
 	DeleteConfigMap(controller runtime.Object, cm *corev1.ConfigMap) error
 	// GetConfigMap get the ConfigMap by configMap name
@@ -90,6 +94,16 @@ func (c *realConfigMapControl) UpdateConfigMap(owner runtime.Object, cm *corev1.
 	return updatedCm, err
 }
 
+func (c *realConfigMapControl) ApplyConfigMap(owner runtime.Object, cm *corev1ac.ConfigMapApplyConfiguration) (*corev1.ConfigMap, error) {
+	applied, err := c.kubeCli.CoreV1().ConfigMaps(*cm.Namespace).Apply(context.TODO(), cm, ApplyOptions(false))
+	if err != nil {
+		c.recordConfigMapEvent("apply", owner, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: *cm.Name}}, err)
+		return nil, err
+	}
+	klog.Infof("apply ConfigMap: [%s/%s] successfully", *cm.Namespace, *cm.Name)
+	return applied, nil
+}
+
 func (c *realConfigMapControl) DeleteConfigMap(owner runtime.Object, cm *corev1.ConfigMap) error {
 	err := c.kubeCli.CoreV1().ConfigMaps(cm.Namespace).Delete(context.TODO(), cm.Name, metav1.DeleteOptions{})
 	c.recordConfigMapEvent("delete", owner, cm, err)
@@ -101,6 +115,26 @@ func (c *realConfigMapControl) GetConfigMap(owner runtime.Object, cm *corev1.Con
 	return existConfigMap, err
 }
 
+// NewConfigMapApplyConfiguration returns the apply configuration of the desired ConfigMap
+func NewConfigMapApplyConfiguration(cm *corev1.ConfigMap) (*corev1ac.ConfigMapApplyConfiguration, error) {
+	ac := corev1ac.ConfigMap(cm.Name, cm.Namespace)
+	desired := &corev1.ConfigMap{ObjectMeta: applyObjectMeta(cm.ObjectMeta), Data: cm.Data, BinaryData: cm.BinaryData}
+	if err := convertByJSON(desired, ac); err != nil {
+		return nil, fmt.Errorf("failed to convert ConfigMap %s/%s to apply configuration, error: %v", cm.Namespace, cm.Name, err)
+	}
+	return ac, nil
+}
+
+// ConfigMapApplied returns whether the fields owned by tidb-operator in the live ConfigMap are the same as the
+// apply configuration, in which case applying it again changes nothing.
+func ConfigMapApplied(ac *corev1ac.ConfigMapApplyConfiguration, live *corev1.ConfigMap) (bool, error) {
+	applied, err := corev1ac.ExtractConfigMap(live, FieldManager)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract the applied config of ConfigMap %s/%s, error: %v", live.Namespace, live.Name, err)
+	}
+	return apiequality.Semantic.DeepEqual(ac, applied), nil
+}
+
 func (c *realConfigMapControl) recordConfigMapEvent(verb string, owner runtime.Object, cm *corev1.ConfigMap, err error) {
 	kind := owner.GetObjectKind().GroupVersionKind().Kind
 	var name string
@@ -184,6 +218,22 @@ func (c *FakeConfigMapControl) UpdateConfigMap(_ runtime.Object, cm *corev1.Conf
 	return cm, c.CmIndexer.Update(cm)
 }
 
+// ApplyConfigMap adds the ConfigMap to CmIndexer if it does not exist, otherwise updates it
+func (c *FakeConfigMapControl) ApplyConfigMap(owner runtime.Object, ac *corev1ac.ConfigMapApplyConfiguration) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := convertByJSON(ac, cm); err != nil {
+		return nil, err
+	}
+	_, exist, err := c.CmIndexer.Get(cm)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return c.CreateConfigMap(owner, cm)
+	}
+	return c.UpdateConfigMap(owner, cm)
+}
+
 // DeleteConfigMap deletes the ConfigMap of CmIndexer
 func (c *FakeConfigMapControl) DeleteConfigMap(_ runtime.Object, _ *corev1.ConfigMap) error {
 	return nil
@@ -14,6 +14,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -22,6 +23,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -105,6 +107,73 @@ func TestConfigMapControlUpdateConfigMapConflictSuccess(t *testing.T) {
 	g.Expect(updatecm.Data["file"]).To(Equal("test"))
 }
 
+func TestConfigMapControlApplyConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	cm := newConfigMap()
+	cm.ResourceVersion = "1"
+	cm.Data["file"] = "test"
+	ac, err := NewConfigMapApplyConfiguration(cm)
+	g.Expect(err).To(Succeed())
+	fakeClient := &fake.Clientset{}
+	control := NewRealConfigMapControl(fakeClient, recorder)
+	fakeClient.AddReactor("patch", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		patch := action.(core.PatchAction)
+		g.Expect(patch.GetPatchType()).To(Equal(types.ApplyPatchType))
+		g.Expect(string(patch.GetPatch())).NotTo(ContainSubstring("creationTimestamp"))
+
+		applied := &corev1.ConfigMap{}
+		g.Expect(json.Unmarshal(patch.GetPatch(), applied)).To(Succeed())
+		g.Expect(applied.Kind).To(Equal("ConfigMap"))
+		g.Expect(applied.ResourceVersion).To(BeEmpty())
+		g.Expect(applied.Data).To(Equal(cm.Data))
+		return true, applied, nil
+	})
+	applied, err := control.ApplyConfigMap(tc, ac)
+	g.Expect(err).To(Succeed())
+	g.Expect(applied.Data).To(Equal(cm.Data))
+}
+
+func TestConfigMapApplied(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cm := newConfigMap()
+	cm.Data["file"] = "test"
+	live := cm.DeepCopy()
+	live.Data["other"] = "set by others"
+	live.ManagedFields = []metav1.ManagedFieldsEntry{
+		{
+			Manager:    FieldManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:file":{}}}`)},
+		},
+	}
+
+	// the fields not owned by tidb-operator are ignored
+	ac, err := NewConfigMapApplyConfiguration(cm)
+	g.Expect(err).To(Succeed())
+	applied, err := ConfigMapApplied(ac, live)
+	g.Expect(err).To(Succeed())
+	g.Expect(applied).To(BeTrue())
+
+	cm.Data["file"] = "changed"
+	ac, err = NewConfigMapApplyConfiguration(cm)
+	g.Expect(err).To(Succeed())
+	applied, err = ConfigMapApplied(ac, live)
+	g.Expect(err).To(Succeed())
+	g.Expect(applied).To(BeFalse())
+}
+
+func TestApplyOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	opts := ApplyOptions(false)
+	g.Expect(opts.FieldManager).To(Equal(FieldManager))
+	g.Expect(opts.Force).To(BeFalse())
+	g.Expect(ApplyOptions(true).Force).To(BeTrue())
+}
+
 func TestConfigMapControlDeleteConfigMap(t *testing.T) {
 	g := NewGomegaWithT(t)
 	recorder := record.NewFakeRecorder(10)
@@ -15,10 +15,12 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	stderrs "errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	fedv1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1"
@@ -79,7 +81,8 @@ var (
 
 // RequeueError is used to requeue the item, this error type should't be considered as a real error
 type RequeueError struct {
-	s string
+	s     string
+	after time.Duration
 }
 
 func (re *RequeueError) Error() string {
@@ -88,7 +91,13 @@ func (re *RequeueError) Error() string {
 
 // RequeueErrorf returns a RequeueError
 func RequeueErrorf(format string, a ...interface{}) error {
-	return &RequeueError{fmt.Sprintf(format, a...)}
+	return &RequeueError{s: fmt.Sprintf(format, a...)}
+}
+
+// RequeueAfterErrorf returns a RequeueError which requeues the item after the delay rather than the rate limited
+// backoff, it's used when the item is known to need syncing again at a given time
+func RequeueAfterErrorf(after time.Duration, format string, a ...interface{}) error {
+	return &RequeueError{s: fmt.Sprintf(format, a...), after: after}
 }
 
 // IsRequeueError returns whether err is a RequeueError
@@ -97,6 +106,16 @@ func IsRequeueError(err error) bool {
 	return stderrs.As(err, &rerr)
 }
 
+// GetRequeueAfter returns the delay of the RequeueError to requeue the item after, 0 is returned if err is not
+// a RequeueError or it has no delay
+func GetRequeueAfter(err error) time.Duration {
+	rerr := &RequeueError{}
+	if stderrs.As(err, &rerr) {
+		return rerr.after
+	}
+	return 0
+}
+
 // IgnoreError is used to ignore this item, this error type shouldn't be considered as a real error, no need to requeue
 type IgnoreError struct {
 	s string
@@ -311,6 +330,26 @@ func PDPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-pd-peer", clusterName)
 }
 
+// PDLeaderMemberName returns pd leader service name
+func PDLeaderMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-pd-leader", clusterName)
+}
+
+// PDPprofMemberName returns pd pprof service name
+func PDPprofMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-pd-pprof", clusterName)
+}
+
+// PDEffectiveConfigMapName returns the name of the configmap which the effective pd config is exported to
+func PDEffectiveConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pd-effective-config", clusterName)
+}
+
+// PDForceNewClusterConfigMapName returns the name of the configmap which delivers the force new cluster request to the pd member
+func PDForceNewClusterConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pd-force-new-cluster", clusterName)
+}
+
 // PDMSMemberName returns pd microservice member name
 func PDMSMemberName(clusterName string, serviceName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, serviceName)
@@ -478,6 +517,9 @@ func ParseStorageRequest(req corev1.ResourceList) (corev1.ResourceRequirements,
 	}, nil
 }
 
+// ContainerResource returns the resource requirements of the container from the ones of the component,
+// the storage request is trimmed and the extended resources are passed through. As the hugepages must be
+// set in the limits, a hugepages request without the limit is copied to the limits.
 func ContainerResource(req corev1.ResourceRequirements) corev1.ResourceRequirements {
 	trimmed := req.DeepCopy()
 	if trimmed.Limits != nil {
@@ -486,9 +528,25 @@ func ContainerResource(req corev1.ResourceRequirements) corev1.ResourceRequireme
 	if trimmed.Requests != nil {
 		delete(trimmed.Requests, corev1.ResourceStorage)
 	}
+	for name, q := range trimmed.Requests {
+		if !IsHugePagesResourceName(name) {
+			continue
+		}
+		if trimmed.Limits == nil {
+			trimmed.Limits = corev1.ResourceList{}
+		}
+		if _, ok := trimmed.Limits[name]; !ok {
+			trimmed.Limits[name] = q.DeepCopy()
+		}
+	}
 	return *trimmed
 }
 
+// IsHugePagesResourceName returns whether the resource is hugepages, e.g. hugepages-2Mi
+func IsHugePagesResourceName(name corev1.ResourceName) bool {
+	return strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix)
+}
+
 // MemberConfigMapName returns the default ConfigMap name of the specified member type
 // Deprecated
 // TODO: remove after helm get totally abandoned
@@ -693,3 +751,38 @@ func GuaranteedUpdate(cli client.Client, obj client.Object, updateFunc func() er
 		return cli.Update(context.TODO(), obj)
 	})
 }
+
+// FieldManager is the field manager name used by tidb-operator when applying objects with server-side apply
+const FieldManager = "tidb-operator"
+
+// ApplyOptions returns the ApplyOptions used by tidb-operator for server-side apply. Conflicts with other field
+// managers fail the apply unless force is true, so that the fields changed by others are never overwritten silently.
+func ApplyOptions(force bool) metav1.ApplyOptions {
+	return metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        force,
+	}
+}
+
+// applyObjectMeta returns the ObjectMeta of an apply configuration, it only keeps the fields
+// that should be owned by tidb-operator and drops server populated fields like resourceVersion and managedFields
+func applyObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            meta.Name,
+		Namespace:       meta.Namespace,
+		Labels:          meta.Labels,
+		Annotations:     meta.Annotations,
+		OwnerReferences: meta.OwnerReferences,
+	}
+}
+
+// convertByJSON converts between a typed object and its apply configuration. The fields of apply configurations
+// are all optional, so the zero values that a typed object always carries, e.g. the creationTimestamp, are dropped
+// when converting to an apply configuration instead of being applied and owned by tidb-operator.
+func convertByJSON(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -16,6 +16,7 @@ package controller
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -37,6 +38,14 @@ func TestRequeueError(t *testing.T) {
 	g.Expect(IsRequeueError(err)).To(BeTrue())
 	g.Expect(err.Error()).To(Equal("i am a requeue error"))
 	g.Expect(IsRequeueError(fmt.Errorf("i am not a requeue error"))).To(BeFalse())
+	g.Expect(GetRequeueAfter(err)).To(BeZero())
+
+	err = RequeueAfterErrorf(time.Minute, "i am a requeue %s with delay", "error")
+	g.Expect(IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal("i am a requeue error with delay"))
+	g.Expect(GetRequeueAfter(err)).To(Equal(time.Minute))
+	g.Expect(GetRequeueAfter(fmt.Errorf("wrapped: %w", err))).To(Equal(time.Minute))
+	g.Expect(GetRequeueAfter(fmt.Errorf("i am not a requeue error"))).To(BeZero())
 }
 
 func TestIgnoreError(t *testing.T) {
@@ -540,6 +549,97 @@ func TestSetIfNotEmpty(t *testing.T) {
 	}
 }
 
+func TestContainerResource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name   string
+		req    corev1.ResourceRequirements
+		expect corev1.ResourceRequirements
+	}{
+		{
+			name: "storage is trimmed",
+			req: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:     resource.MustParse("1"),
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+			},
+			expect: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{},
+			},
+		},
+		{
+			name: "hugepages request is copied to limits",
+			req: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+					"hugepages-2Mi":       resource.MustParse("1Gi"),
+				},
+			},
+			expect: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+					"hugepages-2Mi":       resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					"hugepages-2Mi": resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			name: "hugepages limit is kept",
+			req: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"hugepages-1Gi": resource.MustParse("2Gi"),
+				},
+				Limits: corev1.ResourceList{
+					"hugepages-1Gi": resource.MustParse("4Gi"),
+				},
+			},
+			expect: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"hugepages-1Gi": resource.MustParse("2Gi"),
+				},
+				Limits: corev1.ResourceList{
+					"hugepages-1Gi": resource.MustParse("4Gi"),
+				},
+			},
+		},
+		{
+			name: "extended resources are passed through",
+			req: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"example.com/foo": resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					"example.com/foo": resource.MustParse("1"),
+				},
+			},
+			expect: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"example.com/foo": resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					"example.com/foo": resource.MustParse("1"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Expect(ContainerResource(tt.req)).To(Equal(tt.expect))
+		})
+	}
+}
+
 func TestTrimName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	name := "basic-tso-peer"
@@ -94,6 +94,12 @@ type CLIConfig struct {
 	// what resources should be watched and synced by controller
 	Selector string
 
+	// PDServerSideApply enables server-side apply for the Service, ConfigMap and StatefulSet of PD
+	PDServerSideApply bool
+	// PDDiskUsageCheck enables reading the volume usage of PD from the kubelet for `.spec.pd.diskPressureThreshold`,
+	// which requires the get permission of nodes/proxy
+	PDDiskUsageCheck bool
+
 	// KubeClientQPS indicates the maximum QPS to the kubenetes API server from client.
 	KubeClientQPS   float64
 	KubeClientBurst int
@@ -149,6 +155,8 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	// TODO: actually we just want to use the same image with tidb-controller-manager, but DownwardAPI cannot get image ID, see if there is any better solution
 	flag.StringVar(&c.TiDBDiscoveryImage, "tidb-discovery-image", c.TiDBDiscoveryImage, "The image of the tidb discovery service")
 	flag.StringVar(&c.Selector, "selector", c.Selector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	flag.BoolVar(&c.PDServerSideApply, "pd-server-side-apply", c.PDServerSideApply, "Whether tidb-operator should use server-side apply to manage the resources of PD")
+	flag.BoolVar(&c.PDDiskUsageCheck, "pd-disk-usage-check", c.PDDiskUsageCheck, "Whether tidb-operator should read the volume usage of PD from the kubelet through nodes/proxy")
 
 	// see https://pkg.go.dev/k8s.io/client-go/tools/leaderelection#LeaderElectionConfig for the config
 	flag.DurationVar(&c.LeaseDuration, "leader-lease-duration", c.LeaseDuration, "leader-lease-duration is the duration that non-leader candidates will wait to force acquire leadership")
@@ -223,11 +231,13 @@ type Dependencies struct {
 	NodeLister                  corelisterv1.NodeLister
 	SecretLister                corelisterv1.SecretLister
 	ConfigMapLister             corelisterv1.ConfigMapLister
+	UserConfigMapLister         corelisterv1.ConfigMapLister // not filtered by label, for the ConfigMaps provided by users such as .spec.pd.configFrom
 	StatefulSetLister           appslisters.StatefulSetLister
 	DeploymentLister            appslisters.DeploymentLister
 	JobLister                   batchlisters.JobLister
 	IngressLister               networklister.IngressLister
 	IngressV1Beta1Lister        extensionslister.IngressLister // TODO: in order to be compatibility with kubernetes which less than v1.19, remove it if v1.19- is not supported
+	NetworkPolicyLister         networklister.NetworkPolicyLister
 	StorageClassLister          storagelister.StorageClassLister
 	TiDBClusterLister           listers.TidbClusterLister
 	TiDBClusterAutoScalerLister listers.TidbClusterAutoScalerLister
@@ -368,12 +378,14 @@ func newDependencies(
 		NodeLister:                  nodeLister,
 		SecretLister:                kubeInformerFactory.Core().V1().Secrets().Lister(),
 		ConfigMapLister:             labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Lister(),
+		UserConfigMapLister:         kubeInformerFactory.Core().V1().ConfigMaps().Lister(),
 		StatefulSetLister:           kubeInformerFactory.Apps().V1().StatefulSets().Lister(),
 		DeploymentLister:            kubeInformerFactory.Apps().V1().Deployments().Lister(),
 		StorageClassLister:          scLister,
 		JobLister:                   kubeInformerFactory.Batch().V1().Jobs().Lister(),
 		IngressLister:               ingLister,
 		IngressV1Beta1Lister:        ingv1beta1Lister,
+		NetworkPolicyLister:         labelFilterKubeInformerFactory.Networking().V1().NetworkPolicies().Lister(),
 		TiDBClusterLister:           informerFactory.Pingcap().V1alpha1().TidbClusters().Lister(),
 		TiDBClusterAutoScalerLister: informerFactory.Pingcap().V1alpha1().TidbClusterAutoScalers().Lister(),
 		DMClusterLister:             informerFactory.Pingcap().V1alpha1().DMClusters().Lister(),
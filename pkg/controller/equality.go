@@ -118,3 +118,17 @@ func IngressEqual(newIngress, oldIngres *networkingv1.Ingress) (bool, error) {
 	}
 	return false, nil
 }
+
+// NetworkPolicyEqual compares the new NetworkPolicy's spec with old NetworkPolicy's last applied config
+func NetworkPolicyEqual(newPolicy, oldPolicy *networkingv1.NetworkPolicy) (bool, error) {
+	oldPolicySpec := networkingv1.NetworkPolicySpec{}
+	if lastAppliedConfig, ok := oldPolicy.Annotations[LastAppliedConfigAnnotation]; ok {
+		err := json.Unmarshal([]byte(lastAppliedConfig), &oldPolicySpec)
+		if err != nil {
+			klog.Errorf("unmarshal NetworkPolicySpec: [%s/%s]'s applied config failed,error: %v", oldPolicy.GetNamespace(), oldPolicy.GetName(), err)
+			return false, err
+		}
+		return apiequality.Semantic.DeepEqual(oldPolicySpec, newPolicy.Spec), nil
+	}
+	return false, nil
+}
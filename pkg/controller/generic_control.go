@@ -60,6 +60,8 @@ type TypedControlInterface interface {
 	CreateOrUpdatePVC(controller client.Object, pvc *corev1.PersistentVolumeClaim, setOwnerFlag bool) (*corev1.PersistentVolumeClaim, error)
 	// CreateOrUpdateIngress create the desired ingress or update the current one to desired state if already existed
 	CreateOrUpdateIngress(controller client.Object, ingress *networkingv1.Ingress) (*networkingv1.Ingress, error)
+	// CreateOrUpdateNetworkPolicy create the desired network policy or update the current one to desired state if already existed
+	CreateOrUpdateNetworkPolicy(controller client.Object, policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error)
 	// CreateOrUpdateIngressV1beta1 create the desired v1beta1 ingress or update the current one to desired state if already existed
 	CreateOrUpdateIngressV1beta1(controller client.Object, ingress *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error)
 	// UpdateStatus update the /status subresource of the object
@@ -383,6 +385,39 @@ func (w *typedWrapper) CreateOrUpdateIngress(controller client.Object, ingress *
 	return result.(*networkingv1.Ingress), nil
 }
 
+func (w *typedWrapper) CreateOrUpdateNetworkPolicy(controller client.Object, policy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	result, err := w.GenericControlInterface.CreateOrUpdate(controller, policy, func(existing, desired client.Object) error {
+		existingPolicy := existing.(*networkingv1.NetworkPolicy)
+		desiredPolicy := desired.(*networkingv1.NetworkPolicy)
+
+		if existingPolicy.Annotations == nil {
+			existingPolicy.Annotations = map[string]string{}
+		}
+		for k, v := range desiredPolicy.Annotations {
+			existingPolicy.Annotations[k] = v
+		}
+		existingPolicy.Labels = desiredPolicy.Labels
+		equal, err := NetworkPolicyEqual(desiredPolicy, existingPolicy)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			// record desiredPolicy Spec in annotations in favor of future equality checks
+			b, err := json.Marshal(desiredPolicy.Spec)
+			if err != nil {
+				return err
+			}
+			existingPolicy.Annotations[LastAppliedConfigAnnotation] = string(b)
+			existingPolicy.Spec = desiredPolicy.Spec
+		}
+		return nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*networkingv1.NetworkPolicy), nil
+}
+
 func (w *typedWrapper) Create(controller, obj client.Object) error {
 	return w.GenericControlInterface.Create(controller, obj, true)
 }
@@ -44,6 +44,7 @@ type PodControlInterface interface {
 	DeletePod(runtime.Object, *corev1.Pod) error
 	ForceDeletePod(runtime.Object, *corev1.Pod) error
 	UpdatePod(runtime.Object, *corev1.Pod) (*corev1.Pod, error)
+	UpdatePodStatus(runtime.Object, *corev1.Pod) (*corev1.Pod, error)
 }
 
 type realPodControl struct {
@@ -106,6 +107,25 @@ func (c *realPodControl) UpdatePod(controller runtime.Object, pod *corev1.Pod) (
 	return updatePod, err
 }
 
+// UpdatePodStatus updates the status of the pod, e.g. the conditions set by the operator
+func (c *realPodControl) UpdatePodStatus(controller runtime.Object, pod *corev1.Pod) (*corev1.Pod, error) {
+	controllerMo, ok := controller.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a metav1.Object, cannot call UpdatePodStatus", controller)
+	}
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	namespace := pod.GetNamespace()
+	podName := pod.GetName()
+
+	updatePod, err := c.kubeCli.CoreV1().Pods(namespace).UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("failed to update the status of Pod: [%s/%s], error: %v", namespace, podName, err)
+		return nil, err
+	}
+	klog.Infof("the status of Pod: [%s/%s] updated successfully, %s: [%s/%s]", namespace, podName, kind, controllerMo.GetNamespace(), controllerMo.GetName())
+	return updatePod, nil
+}
+
 func (c *realPodControl) UpdateMetaInfo(tc *v1alpha1.TidbCluster, pod *corev1.Pod) (*corev1.Pod, error) {
 	ns := pod.GetNamespace()
 	podName := pod.GetName()
@@ -400,4 +420,9 @@ func (c *FakePodControl) UpdatePod(_ runtime.Object, pod *corev1.Pod) (*corev1.P
 	return pod, c.PodIndexer.Update(pod)
 }
 
+// UpdatePodStatus updates the pod in PodIndexer, it shares the error attributes with UpdatePod
+func (c *FakePodControl) UpdatePodStatus(_ runtime.Object, pod *corev1.Pod) (*corev1.Pod, error) {
+	return c.UpdatePod(nil, pod)
+}
+
 var _ PodControlInterface = &FakePodControl{}
@@ -15,6 +15,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -22,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -41,6 +43,7 @@ type PVCControlInterface interface {
 	DeletePVC(runtime.Object, *corev1.PersistentVolumeClaim) error
 	GetPVC(name, namespace string) (*corev1.PersistentVolumeClaim, error)
 	CreatePVC(controller runtime.Object, pvc *corev1.PersistentVolumeClaim) error
+	PatchPVCAnnotations(controller runtime.Object, pvc *corev1.PersistentVolumeClaim, annotations map[string]string) error
 }
 
 type realPVCControl struct {
@@ -141,6 +144,40 @@ func (c *realPVCControl) UpdatePVC(controller runtime.Object, pvc *corev1.Persis
 	return updatePVC, err
 }
 
+// PatchPVCAnnotations adds or updates the annotations of the pvc with a strategic merge patch,
+// so that the other fields of the pvc are left untouched.
+func (c *realPVCControl) PatchPVCAnnotations(controller runtime.Object, pvc *corev1.PersistentVolumeClaim, annotations map[string]string) error {
+	controllerMo, ok := controller.(metav1.Object)
+	if !ok {
+		return fmt.Errorf("%T is not a metav1.Object, cannot call setControllerReference", controller)
+	}
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	name := controllerMo.GetName()
+	namespace := controllerMo.GetNamespace()
+	pvcName := pvc.GetName()
+
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal annotations patch of PVC: [%s/%s], error: %v", namespace, pvcName, err)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		_, err := c.kubeCli.CoreV1().PersistentVolumeClaims(namespace).Patch(context.TODO(), pvcName, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		klog.Errorf("failed to patch annotations of PVC: [%s/%s], %s: %s, error: %v", namespace, pvcName, kind, name, err)
+	} else {
+		klog.V(4).Infof("patch annotations of PVC: [%s/%s] successfully, %s: %s", namespace, pvcName, kind, name)
+	}
+	c.recordPVCEvent("patch", kind, name, controller, pvcName, err)
+	return err
+}
+
 func (c *realPVCControl) UpdateMetaInfo(controller runtime.Object, pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod) (*corev1.PersistentVolumeClaim, error) {
 	controllerMo, ok := controller.(metav1.Object)
 	if !ok {
@@ -304,6 +341,24 @@ func (c *FakePVCControl) UpdateMetaInfo(_ runtime.Object, pvc *corev1.Persistent
 	return nil, c.PVCIndexer.Update(pvc)
 }
 
+// PatchPVCAnnotations adds or updates the annotations of pvc
+func (c *FakePVCControl) PatchPVCAnnotations(_ runtime.Object, pvc *corev1.PersistentVolumeClaim, annotations map[string]string) error {
+	defer c.updatePVCTracker.Inc()
+	if c.updatePVCTracker.ErrorReady() {
+		defer c.updatePVCTracker.Reset()
+		return c.updatePVCTracker.GetError()
+	}
+
+	newPVC := pvc.DeepCopy()
+	if newPVC.Annotations == nil {
+		newPVC.Annotations = make(map[string]string)
+	}
+	for k, v := range annotations {
+		newPVC.Annotations[k] = v
+	}
+	return c.PVCIndexer.Update(newPVC)
+}
+
 func (c *FakePVCControl) GetPVC(name, namespace string) (*corev1.PersistentVolumeClaim, error) {
 	defer c.updatePVCTracker.Inc()
 	obj, existed, err := c.PVCIndexer.GetByKey(fmt.Sprintf("%s/%s", namespace, name))
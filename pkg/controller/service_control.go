@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -41,6 +42,9 @@ type ServiceControlInterface interface {
 	CreateService(runtime.Object, *corev1.Service) error
 	UpdateService(runtime.Object, *corev1.Service) (*corev1.Service, error)
 	DeleteService(runtime.Object, *corev1.Service) error
+	// ApplyService creates or updates the Service with server-side apply, conflicts with other field managers
+	// are overwritten only if force is true
+	ApplyService(controller runtime.Object, svc *corev1ac.ServiceApplyConfiguration, force bool) (*corev1.Service, error)
 	SyncComponentService(tc runtime.Object, newSvc *corev1.Service, oldService *corev1.Service, setClusterIP bool) (*corev1.Service, error)
 }
 
@@ -104,6 +108,25 @@ func (c *realServiceControl) UpdateService(controller runtime.Object, svc *corev
 	return updateSvc, err
 }
 
+func (c *realServiceControl) ApplyService(controller runtime.Object, svc *corev1ac.ServiceApplyConfiguration, force bool) (*corev1.Service, error) {
+	controllerMo, ok := controller.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a metav1.Object, cannot call setControllerReference", controller)
+	}
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	name := controllerMo.GetName()
+	namespace := controllerMo.GetNamespace()
+	svcName := *svc.Name
+
+	appliedSvc, err := c.kubeCli.CoreV1().Services(*svc.Namespace).Apply(context.TODO(), svc, ApplyOptions(force))
+	if err != nil {
+		c.recordServiceEvent("apply", name, kind, controller, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: svcName}}, err)
+		return nil, err
+	}
+	klog.Infof("apply Service: [%s/%s] successfully, kind: %s, name: %s", namespace, svcName, kind, name)
+	return appliedSvc, nil
+}
+
 func (c *realServiceControl) DeleteService(controller runtime.Object, svc *corev1.Service) error {
 	controllerMo, ok := controller.(metav1.Object)
 	if !ok {
@@ -118,6 +141,27 @@ func (c *realServiceControl) DeleteService(controller runtime.Object, svc *corev
 	return err
 }
 
+// NewServiceApplyConfiguration returns the apply configuration of the desired Service
+func NewServiceApplyConfiguration(svc *corev1.Service) (*corev1ac.ServiceApplyConfiguration, error) {
+	ac := corev1ac.Service(svc.Name, svc.Namespace)
+	if err := convertByJSON(&corev1.Service{ObjectMeta: applyObjectMeta(svc.ObjectMeta), Spec: svc.Spec}, ac); err != nil {
+		return nil, fmt.Errorf("failed to convert Service %s/%s to apply configuration, error: %v", svc.Namespace, svc.Name, err)
+	}
+	// the status is never applied
+	ac.Status = nil
+	return ac, nil
+}
+
+// ServiceApplied returns whether the fields owned by tidb-operator in the live Service are the same as the
+// apply configuration, in which case applying it again changes nothing.
+func ServiceApplied(ac *corev1ac.ServiceApplyConfiguration, live *corev1.Service) (bool, error) {
+	applied, err := corev1ac.ExtractService(live, FieldManager)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract the applied config of Service %s/%s, error: %v", live.Namespace, live.Name, err)
+	}
+	return apiequality.Semantic.DeepEqual(ac, applied), nil
+}
+
 func (c *realServiceControl) recordServiceEvent(verb, name, kind string, object runtime.Object, svc *corev1.Service, err error) {
 	svcName := svc.GetName()
 	if err == nil {
@@ -271,9 +315,30 @@ func (c *FakeServiceControl) SyncComponentService(tc runtime.Object, svc *corev1
 	return c.UpdateService(tc, svc)
 }
 
+// ApplyService adds the service to SvcIndexer if it does not exist, otherwise updates it
+func (c *FakeServiceControl) ApplyService(controller runtime.Object, ac *corev1ac.ServiceApplyConfiguration, _ bool) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	if err := convertByJSON(ac, svc); err != nil {
+		return nil, err
+	}
+	_, exist, err := c.SvcIndexer.Get(svc)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return svc, c.CreateService(controller, svc)
+	}
+	return c.UpdateService(controller, svc)
+}
+
 // DeleteService deletes the service of SvcIndexer
-func (c *FakeServiceControl) DeleteService(_ runtime.Object, _ *corev1.Service) error {
-	return nil
+func (c *FakeServiceControl) DeleteService(_ runtime.Object, svc *corev1.Service) error {
+	defer c.deleteStatefulSetTracker.Inc()
+	if c.deleteStatefulSetTracker.ErrorReady() {
+		defer c.deleteStatefulSetTracker.Reset()
+		return c.deleteStatefulSetTracker.GetError()
+	}
+	return c.SvcIndexer.Delete(svc)
 }
 
 var _ ServiceControlInterface = &FakeServiceControl{}
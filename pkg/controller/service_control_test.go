@@ -14,13 +14,17 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	core "k8s.io/client-go/testing"
@@ -110,6 +114,57 @@ func TestServiceControlUpdateServiceConflictSuccess(t *testing.T) {
 	g.Expect(updateSvc.Spec.ClusterIP).To(Equal("1.1.1.1"))
 }
 
+func TestServiceControlApplyService(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	svc := newService(tc, "pd")
+	svc.ResourceVersion = "1"
+	svc.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	svc.Spec.Ports = []corev1.ServicePort{{Name: "client", Port: 2379, TargetPort: intstr.FromInt(2379), Protocol: corev1.ProtocolTCP}}
+	ac, err := NewServiceApplyConfiguration(svc)
+	g.Expect(err).To(Succeed())
+	fakeClient := &fake.Clientset{}
+	control := NewRealServiceControl(fakeClient, nil, recorder)
+	fakeClient.AddReactor("patch", "services", func(action core.Action) (bool, runtime.Object, error) {
+		patch := action.(core.PatchAction)
+		g.Expect(patch.GetPatchType()).To(Equal(types.ApplyPatchType))
+		g.Expect(patch.GetName()).To(Equal(svc.Name))
+		g.Expect(string(patch.GetPatch())).NotTo(ContainSubstring("status"))
+
+		applied := &corev1.Service{}
+		g.Expect(json.Unmarshal(patch.GetPatch(), applied)).To(Succeed())
+		g.Expect(applied.APIVersion).To(Equal("v1"))
+		g.Expect(applied.Kind).To(Equal("Service"))
+		g.Expect(applied.ResourceVersion).To(BeEmpty())
+		g.Expect(applied.ManagedFields).To(BeEmpty())
+		g.Expect(applied.Spec).To(Equal(svc.Spec))
+		return true, applied, nil
+	})
+	_, err = control.ApplyService(tc, ac, false)
+	g.Expect(err).To(Succeed())
+}
+
+func TestServiceControlApplyServiceFailed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	svc := newService(tc, "pd")
+	fakeClient := &fake.Clientset{}
+	control := NewRealServiceControl(fakeClient, nil, recorder)
+	fakeClient.AddReactor("patch", "services", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInternalError(errors.New("API server down"))
+	})
+	ac, err := NewServiceApplyConfiguration(svc)
+	g.Expect(err).To(Succeed())
+	_, err = control.ApplyService(tc, ac, false)
+	g.Expect(err).To(HaveOccurred())
+
+	events := collectEvents(recorder.Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring(corev1.EventTypeWarning))
+}
+
 func TestServiceControlDeleteService(t *testing.T) {
 	g := NewGomegaWithT(t)
 	recorder := record.NewFakeRecorder(10)
@@ -20,10 +20,12 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
 	appsinformers "k8s.io/client-go/informers/apps/v1"
 	"k8s.io/client-go/kubernetes"
 	appslisters "k8s.io/client-go/listers/apps/v1"
@@ -37,6 +39,8 @@ import (
 type StatefulSetControlInterface interface {
 	CreateStatefulSet(runtime.Object, *apps.StatefulSet) error
 	UpdateStatefulSet(runtime.Object, *apps.StatefulSet) (*apps.StatefulSet, error)
+	// ApplyStatefulSet creates or updates the StatefulSet with server-side apply
+	ApplyStatefulSet(runtime.Object, *appsv1ac.StatefulSetApplyConfiguration) (*apps.StatefulSet, error)
 	DeleteStatefulSet(runtime.Object, *apps.StatefulSet, metav1.DeleteOptions) error
 }
 
@@ -111,6 +115,27 @@ func (c *realStatefulSetControl) UpdateStatefulSet(controller runtime.Object, se
 	return updatedSS, err
 }
 
+// ApplyStatefulSet applies a StatefulSet in a TidbCluster with server-side apply.
+func (c *realStatefulSetControl) ApplyStatefulSet(controller runtime.Object, set *appsv1ac.StatefulSetApplyConfiguration) (*apps.StatefulSet, error) {
+	controllerMo, ok := controller.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a metav1.Object, cannot call setControllerReference", controller)
+	}
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	name := controllerMo.GetName()
+	namespace := controllerMo.GetNamespace()
+	setName := *set.Name
+
+	appliedSS, err := c.kubeCli.AppsV1().StatefulSets(*set.Namespace).Apply(context.TODO(), set, ApplyOptions(false))
+	if err != nil {
+		klog.Errorf("failed to apply %s: [%s/%s]'s StatefulSet: [%s/%s], error: %v", kind, namespace, name, namespace, setName, err)
+		c.recordStatefulSetEvent("apply", kind, name, controller, &apps.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: setName}}, err)
+		return nil, err
+	}
+	klog.Infof("%s: [%s/%s]'s StatefulSet: [%s/%s] applied successfully", kind, namespace, name, namespace, setName)
+	return appliedSS, nil
+}
+
 // DeleteStatefulSet delete a StatefulSet in a TidbCluster.
 func (c *realStatefulSetControl) DeleteStatefulSet(controller runtime.Object, set *apps.StatefulSet, opts metav1.DeleteOptions) error {
 	controllerMo, ok := controller.(metav1.Object)
@@ -126,6 +151,43 @@ func (c *realStatefulSetControl) DeleteStatefulSet(controller runtime.Object, se
 	return err
 }
 
+// NewStatefulSetApplyConfiguration returns the apply configuration of the desired StatefulSet
+func NewStatefulSetApplyConfiguration(set *apps.StatefulSet) (*appsv1ac.StatefulSetApplyConfiguration, error) {
+	ac := appsv1ac.StatefulSet(set.Name, set.Namespace)
+	if err := convertByJSON(&apps.StatefulSet{ObjectMeta: applyObjectMeta(set.ObjectMeta), Spec: set.Spec}, ac); err != nil {
+		return nil, fmt.Errorf("failed to convert StatefulSet %s/%s to apply configuration, error: %v", set.Namespace, set.Name, err)
+	}
+	// the status is never applied
+	ac.Status = nil
+	return ac, nil
+}
+
+// StatefulSetApplied returns whether the fields owned by tidb-operator in the live StatefulSet are the same as the
+// apply configuration, in which case applying it again changes nothing.
+func StatefulSetApplied(ac *appsv1ac.StatefulSetApplyConfiguration, live *apps.StatefulSet) (bool, error) {
+	applied, err := appsv1ac.ExtractStatefulSet(live, FieldManager)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract the applied config of StatefulSet %s/%s, error: %v", live.Namespace, live.Name, err)
+	}
+	return apiequality.Semantic.DeepEqual(ac, applied), nil
+}
+
+// GetAppliedStatefulSetSpec returns the spec of the StatefulSet with only the fields applied by tidb-operator
+func GetAppliedStatefulSetSpec(set *apps.StatefulSet) (*apps.StatefulSetSpec, error) {
+	applied, err := appsv1ac.ExtractStatefulSet(set, FieldManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract the applied config of StatefulSet %s/%s, error: %v", set.Namespace, set.Name, err)
+	}
+	if applied.Spec == nil {
+		return nil, fmt.Errorf("statefulset:[%s/%s] not found spec's applied fields", set.Namespace, set.Name)
+	}
+	spec := &apps.StatefulSetSpec{}
+	if err := convertByJSON(applied.Spec, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
 func (c *realStatefulSetControl) recordStatefulSetEvent(verb, kind, name string, object runtime.Object, set *apps.StatefulSet, err error) {
 	setName := set.Name
 	if err == nil {
@@ -228,6 +290,22 @@ func (c *FakeStatefulSetControl) UpdateStatefulSet(_ runtime.Object, set *apps.S
 	return set, c.SetIndexer.Update(set)
 }
 
+// ApplyStatefulSet adds the statefulset to SetIndexer if it does not exist, otherwise updates it
+func (c *FakeStatefulSetControl) ApplyStatefulSet(controller runtime.Object, ac *appsv1ac.StatefulSetApplyConfiguration) (*apps.StatefulSet, error) {
+	set := &apps.StatefulSet{}
+	if err := convertByJSON(ac, set); err != nil {
+		return nil, err
+	}
+	_, exist, err := c.SetIndexer.Get(set)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return set, c.CreateStatefulSet(controller, set)
+	}
+	return c.UpdateStatefulSet(controller, set)
+}
+
 // DeleteStatefulSet deletes the statefulset of SetIndexer
 func (c *FakeStatefulSetControl) DeleteStatefulSet(_ runtime.Object, _ *apps.StatefulSet, _ metav1.DeleteOptions) error {
 	return nil
@@ -14,15 +14,18 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	appslisters "k8s.io/client-go/listers/apps/v1"
 	core "k8s.io/client-go/testing"
@@ -154,6 +157,61 @@ func TestStatefulSetControlUpdateStatefulSetConflictSuccess(t *testing.T) {
 	g.Expect(updateSS.Labels).To(Equal(set.Labels))
 }
 
+func TestStatefulSetControlApplyStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	set := newStatefulSet(tc, "pd")
+	set.ResourceVersion = "1"
+	set.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	set.Spec.Replicas = func() *int32 { var i int32 = 100; return &i }()
+	ac, err := NewStatefulSetApplyConfiguration(set)
+	g.Expect(err).To(Succeed())
+	fakeClient := &fake.Clientset{}
+	control := NewRealStatefuSetControl(fakeClient, nil, recorder)
+	fakeClient.AddReactor("patch", "statefulsets", func(action core.Action) (bool, runtime.Object, error) {
+		patch := action.(core.PatchAction)
+		g.Expect(patch.GetPatchType()).To(Equal(types.ApplyPatchType))
+		g.Expect(patch.GetName()).To(Equal(set.Name))
+		// the zero values of the typed object are not applied
+		g.Expect(string(patch.GetPatch())).NotTo(ContainSubstring("creationTimestamp"))
+		g.Expect(string(patch.GetPatch())).NotTo(ContainSubstring("status"))
+
+		applied := &apps.StatefulSet{}
+		g.Expect(json.Unmarshal(patch.GetPatch(), applied)).To(Succeed())
+		g.Expect(applied.APIVersion).To(Equal("apps/v1"))
+		g.Expect(applied.Kind).To(Equal("StatefulSet"))
+		g.Expect(applied.ResourceVersion).To(BeEmpty())
+		g.Expect(applied.ManagedFields).To(BeEmpty())
+		return true, applied, nil
+	})
+	appliedSS, err := control.ApplyStatefulSet(tc, ac)
+	g.Expect(err).To(Succeed())
+	g.Expect(int(*appliedSS.Spec.Replicas)).To(Equal(100))
+	g.Expect(appliedSS.Labels).To(Equal(set.Labels))
+	g.Expect(appliedSS.OwnerReferences).To(Equal(set.OwnerReferences))
+}
+
+func TestStatefulSetControlApplyStatefulSetFailed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	set := newStatefulSet(tc, "pd")
+	fakeClient := &fake.Clientset{}
+	control := NewRealStatefuSetControl(fakeClient, nil, recorder)
+	fakeClient.AddReactor("patch", "statefulsets", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewConflict(action.GetResource().GroupResource(), set.Name, errors.New("conflict"))
+	})
+	ac, err := NewStatefulSetApplyConfiguration(set)
+	g.Expect(err).To(Succeed())
+	_, err = control.ApplyStatefulSet(tc, ac)
+	g.Expect(apierrors.IsConflict(err)).To(BeTrue())
+
+	events := collectEvents(recorder.Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring(corev1.EventTypeWarning))
+}
+
 func TestStatefulSetControlDeleteStatefulSet(t *testing.T) {
 	g := NewGomegaWithT(t)
 	recorder := record.NewFakeRecorder(10)
@@ -18,8 +18,10 @@ import (
 	"time"
 
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -96,6 +98,19 @@ func NewController(deps *controller.Dependencies) *Controller {
 		},
 		DeleteFunc: c.deleteStatefulSet,
 	})
+	// the ConfigMaps referenced by .spec.pd.configFrom are provided by users, watch them so that
+	// their changes are picked up without waiting for the resync
+	configMapInformer := deps.KubeInformerFactory.Core().V1().ConfigMaps()
+	configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueTidbClustersForConfigMap,
+		UpdateFunc: func(old, cur interface{}) {
+			if old.(*corev1.ConfigMap).ResourceVersion == cur.(*corev1.ConfigMap).ResourceVersion {
+				return
+			}
+			c.enqueueTidbClustersForConfigMap(cur)
+		},
+		DeleteFunc: c.enqueueTidbClustersForConfigMap,
+	})
 
 	return c
 }
@@ -138,7 +153,14 @@ func (c *Controller) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 	if err := c.sync(key.(string)); err != nil {
-		if perrors.Find(err, controller.IsRequeueError) != nil {
+		if rerr := perrors.Find(err, controller.IsRequeueError); rerr != nil {
+			// the item known to need syncing at a given time is requeued then, rather than backing off
+			if after := controller.GetRequeueAfter(rerr); after > 0 {
+				klog.Infof("TidbCluster: %v, still need sync: %v, requeuing after %v", key.(string), err, after)
+				c.queue.Forget(key)
+				c.queue.AddAfter(key, after)
+				return true
+			}
 			klog.Infof("TidbCluster: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
 			utilruntime.HandleError(fmt.Errorf("TidbCluster: %v, sync failed %v, requeuing", key.(string), err))
@@ -264,6 +286,38 @@ func (c *Controller) deleteStatefulSet(obj interface{}) {
 	c.enqueueTidbCluster(tc)
 }
 
+// enqueueTidbClustersForConfigMap enqueues the tidbclusters whose .spec.pd.configFrom references the configmap,
+// accounting for deletion tombstones.
+func (c *Controller) enqueueTidbClustersForConfigMap(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %+v", obj))
+			return
+		}
+		cm, ok = tombstone.Obj.(*corev1.ConfigMap)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not a configmap %+v", obj))
+			return
+		}
+	}
+
+	ns := cm.GetNamespace()
+	tcs, err := c.deps.TiDBClusterLister.TidbClusters(ns).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list tidbclusters in namespace %s: %v", ns, err))
+		return
+	}
+	for _, tc := range tcs {
+		if tc.Spec.PD == nil || tc.Spec.PD.ConfigFrom == nil || tc.Spec.PD.ConfigFrom.Name != cm.GetName() {
+			continue
+		}
+		klog.V(4).Infof("ConfigMap %s/%s referenced by the pd configFrom changed, TidbCluster: %s/%s", ns, cm.GetName(), ns, tc.Name)
+		c.enqueueTidbCluster(tc)
+	}
+}
+
 // resolveTidbClusterFromSet returns the TidbCluster by a StatefulSet,
 // or nil if the StatefulSet could not be resolved to a matching TidbCluster
 // of the correct Kind.
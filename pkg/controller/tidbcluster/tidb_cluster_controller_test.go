@@ -194,6 +194,66 @@ func TestTidbClusterControllerUpdateStatefulSet(t *testing.T) {
 	}
 }
 
+func TestTidbClusterControllerEnqueueTidbClustersForConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: corev1.NamespaceDefault,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		configFrom  *corev1.ConfigMapKeySelector
+		obj         interface{}
+		expectedLen int
+	}{
+		{
+			name:        "configmap referenced by pd configFrom",
+			configFrom:  &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"}, Key: "pd.toml"},
+			obj:         newConfigMap("pd-config"),
+			expectedLen: 1,
+		},
+		{
+			name:        "deleted configmap referenced by pd configFrom",
+			configFrom:  &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"}, Key: "pd.toml"},
+			obj:         cache.DeletedFinalStateUnknown{Key: "default/pd-config", Obj: newConfigMap("pd-config")},
+			expectedLen: 1,
+		},
+		{
+			name:        "configmap not referenced",
+			configFrom:  &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"}, Key: "pd.toml"},
+			obj:         newConfigMap("other"),
+			expectedLen: 0,
+		},
+		{
+			name:        "pd configFrom is not set",
+			obj:         newConfigMap("pd-config"),
+			expectedLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbCluster()
+			tc.Spec.PD.ConfigFrom = tt.configFrom
+
+			fakeDeps := controller.NewFakeDependencies()
+			tcc := NewController(fakeDeps)
+			tcc.control = NewFakeTidbClusterControlInterface()
+			tcIndexer := fakeDeps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+			g.Expect(tcIndexer.Add(tc)).To(Succeed())
+
+			tcc.enqueueTidbClustersForConfigMap(tt.obj)
+			g.Expect(tcc.queue.Len()).To(Equal(tt.expectedLen))
+		})
+	}
+}
+
 func TestTidbClusterControllerSync(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
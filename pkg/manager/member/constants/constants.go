@@ -23,6 +23,10 @@ const (
 	// PDDataVolumeMountPath is the mount path for pd data volume
 	PDDataVolumeMountPath = "/var/lib/pd"
 
+	// PDProjectedVolumeMountPath is the mount path for the projected volume combining the config, startup script
+	// and TLS volumes of pd, the files of each volume are in the directory named after the volume
+	PDProjectedVolumeMountPath = "/var/lib/pd-projected"
+
 	// TiCDCCertPath is the path for ticdc cert in container
 	TiCDCCertPath = "/var/lib/ticdc-tls"
 )
@@ -0,0 +1,105 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+const (
+	// pdConfigSchemaMinMajor and pdConfigSchemaMaxMajor are the major versions of PD covered by the schema
+	pdConfigSchemaMinMajor = 6
+	pdConfigSchemaMaxMajor = 8
+)
+
+// pdConfigSchema is the JSON schema of the config of PD, which lists the keys known to any version of PD
+// from v6 to v8. It's not generated for each version, so a key added in a later version is accepted by
+// the earlier ones too.
+//
+//go:embed pd_config_schema.json
+var pdConfigSchema []byte
+
+// pdConfigSchemaError is the error of the config of PD which doesn't conform to the schema of the PD version
+type pdConfigSchemaError struct {
+	version string
+	errs    []string
+}
+
+func (e *pdConfigSchemaError) Error() string {
+	return fmt.Sprintf("config of pd %s is invalid: %s", e.version, strings.Join(e.errs, "; "))
+}
+
+// isPDConfigSchemaError returns whether the error is caused by the config of PD which doesn't conform to the schema
+func isPDConfigSchemaError(err error) bool {
+	var schemaErr *pdConfigSchemaError
+	return errors.As(err, &schemaErr)
+}
+
+// getPDConfigSchema returns the schema of the config of the PD version, nil is returned if the version
+// is not semantic versioning compatible or it's not covered by the schema
+func getPDConfigSchema(version string) (*spec.Schema, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, nil
+	}
+	if v.Major() < pdConfigSchemaMinMajor || v.Major() > pdConfigSchemaMaxMajor {
+		return nil, nil
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(pdConfigSchema, schema); err != nil {
+		return nil, fmt.Errorf("failed to parse the config schema of pd: %v", err)
+	}
+	return schema, nil
+}
+
+// validatePDConfigSchema validates the config of PD against the schema, which catches the unknown keys, e.g.
+// the typo `replication.max-replica`, and the values of the wrong types. Nothing is validated if the PD version
+// is not covered by the schema.
+func validatePDConfigSchema(config *v1alpha1.PDConfigWraper, version string) error {
+	schema, err := getPDConfigSchema(version)
+	if err != nil || schema == nil {
+		return err
+	}
+	// round trip via JSON so that the config is made of the types known to the validator
+	data, err := json.Marshal(config.Inner())
+	if err != nil {
+		return err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	result := validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(obj)
+	if result.IsValid() {
+		return nil
+	}
+	errs := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		errs = append(errs, err.Error())
+	}
+	sort.Strings(errs)
+	return &pdConfigSchemaError{version: version, errs: errs}
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	statsapi "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// pdDiskUsageCheckTimeout is the timeout of fetching the volume usage of all the PD members in a sync
+const pdDiskUsageCheckTimeout = 10 * time.Second
+
+// volumeUsage is the usage of a volume reported by the kubelet
+type volumeUsage struct {
+	UsedBytes     uint64
+	CapacityBytes uint64
+}
+
+// volumeUsageGetter gets the usage of the PVCs mounted by a pod
+type volumeUsageGetter interface {
+	// GetPVCUsage returns the usage of the volumes mounted by the pod, keyed by the PVC name
+	GetPVCUsage(ctx context.Context, pod *corev1.Pod) (map[string]volumeUsage, error)
+}
+
+type kubeletVolumeUsageGetter struct {
+	kubeCli kubernetes.Interface
+}
+
+// newKubeletVolumeUsageGetter returns a volumeUsageGetter which reads the stats summary of
+// the kubelet through the node proxy of the API server
+func newKubeletVolumeUsageGetter(kubeCli kubernetes.Interface) volumeUsageGetter {
+	return &kubeletVolumeUsageGetter{kubeCli: kubeCli}
+}
+
+func (g *kubeletVolumeUsageGetter) GetPVCUsage(ctx context.Context, pod *corev1.Pod) (map[string]volumeUsage, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, fmt.Errorf("pod %s/%s is not scheduled yet", pod.Namespace, pod.Name)
+	}
+	data, err := g.kubeCli.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(pod.Spec.NodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats summary of node %s: %v", pod.Spec.NodeName, err)
+	}
+	summary := &statsapi.Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stats summary of node %s: %v", pod.Spec.NodeName, err)
+	}
+
+	usages := map[string]volumeUsage{}
+	for _, podStats := range summary.Pods {
+		if podStats.PodRef.Namespace != pod.Namespace || podStats.PodRef.Name != pod.Name {
+			continue
+		}
+		for _, vol := range podStats.VolumeStats {
+			if vol.PVCRef == nil || vol.UsedBytes == nil || vol.CapacityBytes == nil {
+				continue
+			}
+			usages[vol.PVCRef.Name] = volumeUsage{
+				UsedBytes:     *vol.UsedBytes,
+				CapacityBytes: *vol.CapacityBytes,
+			}
+		}
+	}
+	return usages, nil
+}
+
+// syncPDDiskPressureCondition sets the PDDiskPressure condition if the usage of any volume of
+// the PD members exceeds `.spec.pd.diskPressureThreshold`. It is best-effort, the condition is
+// set to unknown if the usage of no member can be fetched. The volume usage getter is only set
+// if the operator is started with `--pd-disk-usage-check`.
+func (m *pdMemberManager) syncPDDiskPressureCondition(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) {
+	threshold := tc.Spec.PD.DiskPressureThreshold
+	if threshold == nil || m.volumeUsageGetter == nil {
+		tc.Status.PD.RemoveCondition(v1alpha1.PDDiskPressure)
+		return
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	ctx, cancel := context.WithTimeout(context.Background(), pdDiskUsageCheckTimeout)
+	defer cancel()
+	checked := 0
+	var offending []string
+	for _, ordinal := range getStsPodOrdinals(set).List() {
+		podName := PdPodName(tcName, ordinal)
+		pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				klog.Warningf("syncPDDiskPressureCondition: failed to get pod %s/%s, error: %v", ns, podName, err)
+			}
+			continue
+		}
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			klog.Warningf("syncPDDiskPressureCondition: timed out to get volume usage of pod %s/%s", ns, podName)
+			continue
+		}
+		usages, err := m.volumeUsageGetter.GetPVCUsage(ctx, pod)
+		if err != nil {
+			klog.Warningf("syncPDDiskPressureCondition: failed to get volume usage of pod %s/%s, error: %v", ns, podName, err)
+			continue
+		}
+		checked++
+
+		var maxPercent uint64
+		for _, usage := range usages {
+			if usage.CapacityBytes == 0 {
+				continue
+			}
+			if percent := usage.UsedBytes * 100 / usage.CapacityBytes; percent > maxPercent {
+				maxPercent = percent
+			}
+		}
+		if maxPercent >= uint64(*threshold) {
+			offending = append(offending, fmt.Sprintf("%s(%d%%)", podName, maxPercent))
+		}
+	}
+
+	if checked == 0 {
+		klog.Warningf("syncPDDiskPressureCondition: volume usage of no pd member of cluster %s/%s is available", ns, tcName)
+		tc.Status.PD.SetCondition(metav1.Condition{
+			Type:    v1alpha1.PDDiskPressure,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "DiskUsageUnknown",
+			Message: "volume usage of no pd member is available",
+		})
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    v1alpha1.PDDiskPressure,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DiskUsageNormal",
+		Message: fmt.Sprintf("disk usage of all pd members is below %d%%", *threshold),
+	}
+	if len(offending) > 0 {
+		sort.Strings(offending)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DiskUsageHigh"
+		condition.Message = fmt.Sprintf("disk usage of pd members exceeds %d%%: %s", *threshold, strings.Join(offending, ", "))
+	}
+	tc.Status.PD.SetCondition(condition)
+}
@@ -146,6 +146,7 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 			PVCUIDSet:     pvcUIDSet,
 			MemberDeleted: false,
 			CreatedAt:     metav1.Now(),
+			Zone:          pdMember.Zone,
 		}
 		return controller.RequeueErrorf("marking Pod: %s/%s pd member: %s as failure", ns, podName, pdMember.Name)
 	}
@@ -313,6 +313,36 @@ func TestPDFailoverFailover(t *testing.T) {
 				g.Expect(events[1]).To(ContainSubstring("PDMemberUnhealthy default/test-pd-1(12891273174085095651) is unhealthy"))
 			},
 		},
+		{
+			name: "has one not ready member with zone",
+			update: func(tc *v1alpha1.TidbCluster) {
+				oneNotReadyMember(tc)
+				pd1Name := ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), 1)
+				pd1 := tc.Status.PD.Members[pd1Name]
+				pd1.Zone = "zone-b"
+				tc.Status.PD.Members[pd1Name] = pd1
+			},
+			maxFailoverCount:         3,
+			hasPVC:                   true,
+			hasPod:                   true,
+			podWithDeletionTimestamp: false,
+			delMemberFailed:          false,
+			delPodFailed:             false,
+			delPVCFailed:             false,
+			statusSyncFailed:         false,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.Contains(err.Error(), "marking Pod: default/test-pd-1 pd member: test-pd-1 as failure")).To(Equal(true))
+			},
+			expectFn: func(tc *v1alpha1.TidbCluster, _ *pdFailover, _ cache.Indexer) {
+				g.Expect(len(tc.Status.PD.FailureMembers)).To(Equal(1))
+				failureMembers := tc.Status.PD.FailureMembers["test-pd-1"]
+				g.Expect(failureMembers.PodName).To(Equal("test-pd-1"))
+				g.Expect(failureMembers.Zone).To(Equal("zone-b"))
+				events := collectEvents(recorder.Events)
+				g.Expect(events).To(HaveLen(2))
+			},
+		},
 		{
 			name:                     "has one not ready member but maxFailoverCount is 0",
 			update:                   oneNotReadyMember,
@@ -15,11 +15,16 @@ package member
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"path"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -30,6 +35,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
 	"github.com/pingcap/tidb-operator/pkg/util"
 
@@ -37,9 +43,13 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
@@ -49,6 +59,44 @@ const (
 	// pdClusterCertPath is where the cert for inter-cluster communication stored (if any)
 	pdClusterCertPath  = "/var/lib/pd-tls"
 	tidbClientCertPath = "/var/lib/tidb-client-tls"
+	// tidbClientCAPath is where the projected CA trust bundle for TiDB Dashboard to verify TiDB is mounted
+	tidbClientCAPath = "/var/lib/tidb-client-ca"
+	// pdLogVolumeName is the name of the volume shared by PD and the log tailer to store the PD log
+	pdLogVolumeName = "pdlog"
+	pdLogDir        = "/var/log/pdlog"
+	pdLogFile       = "pd.log"
+	// pdProjectedVolumeName is the name of the projected volume combining the config, startup script and TLS volumes of PD
+	pdProjectedVolumeName = "pd-projected"
+	// pdForceNewClusterVolumeName is the name of the volume of the force new cluster request, the start script
+	// starts the member with `--force-new-cluster` if there is a file named after the pod in pdForceNewClusterPath
+	pdForceNewClusterVolumeName = "force-new-cluster"
+	pdForceNewClusterPath       = "/etc/pd-force-new-cluster"
+	// pdHugePagesVolumeName is the name of the volume backed by the hugepages requested by PD
+	pdHugePagesVolumeName = "hugepages"
+	pdHugePagesMountPath  = "/dev/hugepages"
+	// pdSATokenVolumeName is the name of the projected volume of the service account token with the audience in .spec.pd.serviceAccountTokenAudience
+	pdSATokenVolumeName = "pd-sa-token"
+	pdSATokenMountPath  = "/var/run/secrets/tokens"
+	pdSATokenPath       = "token"
+	// pdSATokenExpirationSeconds is the requested lifetime of the service account token, the kubelet rotates it before it expires
+	pdSATokenExpirationSeconds = 3600
+	// the defaults of .spec.pd.flushMetricsOnShutdown
+	pdFlushMetricsDefaultJob            = "pd"
+	pdFlushMetricsDefaultTimeoutSeconds = 5
+	// pdLeaderHistoryLimit is the max number of the changes of the PD leader kept in .status.pd.leaderHistory
+	pdLeaderHistoryLimit = 10
+	// pdZoneLabel is the short label name mapped to the well-known zone labels of the node
+	pdZoneLabel = "zone"
+	// pdConfigRolloutFailureThreshold is the number of consecutive failures to roll out a new ConfigMap
+	// of PD before the failure is reported with an event
+	pdConfigRolloutFailureThreshold = 3
+	// pdVolumeCheckTimeoutSeconds is the time allowed to write to the data volume before PD starts
+	pdVolumeCheckTimeoutSeconds = 30
+	// pdStoreEngineLabelKey is the label key of the stores registered by TiFlash, with value pdStoreEngineTiFlash
+	pdStoreEngineLabelKey = "engine"
+	pdStoreEngineTiFlash  = "tiflash"
+	// defaultPDReadinessSidecarPath is the default path of the HTTP endpoint of the health aggregator sidecar
+	defaultPDReadinessSidecarPath = "/health"
 
 	//find a better way to manage store only managed by pd in Operator
 	pdMemberLimitPattern = `%s-pd-\d+\.%s-pd-peer\.%s\.svc%s\:\d+`
@@ -61,11 +109,12 @@ type pdMemberManager struct {
 	failover          Failover
 	suspender         suspender.Suspender
 	podVolumeModifier volumes.PodVolumeModifier
+	volumeUsageGetter volumeUsageGetter
 }
 
 // NewPDMemberManager returns a *pdMemberManager
 func NewPDMemberManager(dependencies *controller.Dependencies, pdScaler Scaler, pdUpgrader Upgrader, pdFailover Failover, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
-	return &pdMemberManager{
+	m := &pdMemberManager{
 		deps:              dependencies,
 		scaler:            pdScaler,
 		upgrader:          pdUpgrader,
@@ -73,6 +122,10 @@ func NewPDMemberManager(dependencies *controller.Dependencies, pdScaler Scaler,
 		suspender:         spder,
 		podVolumeModifier: pvm,
 	}
+	if dependencies.CLIConfig.PDDiskUsageCheck {
+		m.volumeUsageGetter = newKubeletVolumeUsageGetter(dependencies.KubeClientset)
+	}
+	return m
 }
 
 func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
@@ -86,6 +139,12 @@ func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		klog.Infof("tidbcluster: [%s/%s]'s enable micro service failed, please check `PD.Mode` and `PDMS`", tc.GetNamespace(), tc.GetName())
 	}
 
+	// Nothing syncs the PVCs of PD once the TidbCluster is gone, so the protection is released while the
+	// TidbCluster is held by the finalizer, see syncPDPVCDeletionProtection
+	if tc.DeletionTimestamp != nil {
+		return m.releasePDPVCDeletionProtection(tc)
+	}
+
 	// skip sync if pd is suspended
 	component := v1alpha1.PDMemberType
 	needSuspend, err := m.suspender.SuspendComponent(tc, component)
@@ -107,192 +166,1845 @@ func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	// Sync PD Leader Service
+	if err := m.syncPDLeaderServiceForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync PD Pprof Service
+	if err := m.syncPDPprofServiceForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync PD NetworkPolicy
+	if err := m.syncPDNetworkPolicy(tc); err != nil {
+		return err
+	}
+
+	// Sync PD store limits, failures are not fatal and are retried in the next sync
+	if err := m.syncPDStoreLimits(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd store limits, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD scheduler limits, failures are not fatal and are retried in the next sync
+	if err := m.syncPDSchedulerLimits(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd scheduler limits, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD schedulers, failures are not fatal and are retried in the next sync
+	if err := m.syncPDSchedulers(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd schedulers, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD placement rules toggle, failures are not fatal and are retried in the next sync
+	if err := m.syncPDPlacementRules(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd placement rules toggle, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD region merge config, failures are not fatal and are retried in the next sync
+	if err := m.syncPDRegionMerge(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd region merge config, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD hot region schedule limit and split merge interval, failures are not fatal and are retried in the next sync
+	if err := m.syncPDScheduleConfig(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd schedule config, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Check PD max-replicas against the failure domains of TiKV, it's advisory only
+	if err := m.checkPDMaxReplicas(tc); err != nil {
+		klog.Errorf("failed to check TidbCluster: [%s/%s]'s pd max-replicas, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD region label rules, failures are not fatal and are retried in the next sync
+	if err := m.syncPDRegionLabelRules(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd region label rules, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync PD label properties, failures are not fatal and are retried in the next sync
+	if err := m.syncPDLabelProperties(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd label properties, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	// Sync the deletion protection of PD PVCs
+	if err := m.syncPDPVCDeletionProtection(tc); err != nil {
+		return err
+	}
+
+	// Sync the auditing annotations of PD PVCs
+	if err := m.syncPDPVCAuditAnnotations(tc); err != nil {
+		return err
+	}
+
+	// Sync the member designated to be started with --force-new-cluster, it must be done before
+	// syncing the StatefulSet which renders the start script
+	if err := m.syncPDForceNewCluster(tc); err != nil {
+		return err
+	}
+
 	// Sync PD StatefulSet
 	return m.syncPDStatefulSetForTidbCluster(tc)
 }
 
-func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
+// syncPDPVCDeletionProtection adds the protection finalizer to the PVCs of PD if the deletion protection is enabled,
+// and removes it if the deletion is confirmed by the annotation of the tidb cluster or the protection is disabled.
+// A protected PVC stays in Terminating after it's deleted until the finalizer is removed. The PVCs deleted by the
+// failover or the scale-in of PD are never protected, and the TidbCluster holds the same finalizer while the
+// protection is enabled, so that the protection is released when the TidbCluster is deleted.
+func (m *pdMemberManager) syncPDPVCDeletionProtection(tc *v1alpha1.TidbCluster) error {
 	if tc.Spec.Paused {
-		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd service", tc.GetNamespace(), tc.GetName())
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing the deletion protection of pd pvcs", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	protect := tc.PDDeletionProtectionEnabled() && tc.Annotations[label.AnnPDPVCDeletionConfirmed] != "true"
+	if protect {
+		if err := m.setPDPVCProtectionFinalizerOfTidbCluster(tc, true); err != nil {
+			return err
+		}
+	}
+	if err := m.setPDPVCProtectionFinalizers(tc, protect); err != nil {
+		return err
+	}
+	if !protect {
+		return m.setPDPVCProtectionFinalizerOfTidbCluster(tc, false)
+	}
+	return nil
+}
+
+// releasePDPVCDeletionProtection removes the protection finalizer from the PVCs of PD and then from the TidbCluster
+// being deleted, so that neither of them is left in Terminating.
+func (m *pdMemberManager) releasePDPVCDeletionProtection(tc *v1alpha1.TidbCluster) error {
+	if !k8s.ContainsString(tc.Finalizers, label.PDPVCProtectionFinalizer, nil) {
 		return nil
 	}
+	if err := m.setPDPVCProtectionFinalizers(tc, false); err != nil {
+		return err
+	}
+	return m.setPDPVCProtectionFinalizerOfTidbCluster(tc, false)
+}
 
+// setPDPVCProtectionFinalizers adds or removes the protection finalizer of the PVCs of PD. The PVCs being deleted by
+// the failover or the scale-in of PD always have it removed.
+func (m *pdMemberManager) setPDPVCProtectionFinalizers(tc *v1alpha1.TidbCluster, protect bool) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	newSvc := m.getNewPDServiceForTidbCluster(tc)
-	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
-	if errors.IsNotFound(err) {
-		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
-		if err != nil {
-			return err
-		}
-		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+	if err != nil {
+		return err
 	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
 	if err != nil {
-		return fmt.Errorf("syncPDServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncPDPVCDeletionProtection: failed to list pvcs for cluster %s/%s, error: %s", ns, tcName, err)
 	}
 
-	oldSvc := oldSvcTmp.DeepCopy()
+	for _, pvc := range pvcs {
+		protectPVC := protect && !isPDPVCDeletedByOperator(tc, pvc)
+		protected := k8s.ContainsString(pvc.Finalizers, label.PDPVCProtectionFinalizer, nil)
+		if protectPVC == protected {
+			continue
+		}
+		// a pvc being deleted can't get new finalizers
+		if protectPVC && pvc.DeletionTimestamp != nil {
+			continue
+		}
+		newPVC := pvc.DeepCopy()
+		if protectPVC {
+			newPVC.Finalizers = append(newPVC.Finalizers, label.PDPVCProtectionFinalizer)
+		} else {
+			newPVC.Finalizers = k8s.RemoveString(newPVC.Finalizers, label.PDPVCProtectionFinalizer, nil)
+		}
+		if _, err := m.deps.PVCControl.UpdatePVC(tc, newPVC); err != nil {
+			return fmt.Errorf("syncPDPVCDeletionProtection: failed to update finalizers of pvc %s/%s for cluster %s/%s, error: %s", ns, pvc.Name, ns, tcName, err)
+		}
+		klog.Infof("syncPDPVCDeletionProtection: update pvc %s/%s for cluster %s/%s, protected: %t", ns, pvc.Name, ns, tcName, protectPVC)
+	}
+	return nil
+}
 
-	_, err = m.deps.ServiceControl.SyncComponentService(
-		tc,
-		newSvc,
-		oldSvc,
-		true)
+// isPDPVCDeletedByOperator returns whether the PVC is to be deleted by the scale-in of PD, which marks it with the
+// defer deleting annotation, or by the failover of PD, which records it in the failure member.
+func isPDPVCDeletedByOperator(tc *v1alpha1.TidbCluster, pvc *corev1.PersistentVolumeClaim) bool {
+	if _, ok := pvc.Annotations[label.AnnPVCDeferDeleting]; ok {
+		return true
+	}
+	for _, member := range tc.Status.PD.FailureMembers {
+		if _, ok := member.PVCUIDSet[pvc.UID]; ok {
+			return true
+		}
+	}
+	return false
+}
 
+// setPDPVCProtectionFinalizerOfTidbCluster adds or removes the protection finalizer of the TidbCluster. The
+// resource version is patched along to avoid overwriting the finalizers changed by others in between.
+func (m *pdMemberManager) setPDPVCProtectionFinalizerOfTidbCluster(tc *v1alpha1.TidbCluster, protect bool) error {
+	if k8s.ContainsString(tc.Finalizers, label.PDPVCProtectionFinalizer, nil) == protect {
+		return nil
+	}
+	// a tidb cluster being deleted can't get new finalizers
+	if protect && tc.DeletionTimestamp != nil {
+		return nil
+	}
+	finalizers := k8s.RemoveString(tc.Finalizers, label.PDPVCProtectionFinalizer, nil)
+	if protect {
+		finalizers = append(finalizers, label.PDPVCProtectionFinalizer)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": tc.ResourceVersion,
+		},
+	})
 	if err != nil {
 		return err
 	}
-
+	if _, err := m.deps.TiDBClusterControl.Patch(tc, patch); err != nil {
+		return fmt.Errorf("syncPDPVCDeletionProtection: failed to update finalizers of cluster %s/%s, error: %s", tc.GetNamespace(), tc.GetName(), err)
+	}
+	tc.Finalizers = finalizers
 	return nil
 }
 
-func (m *pdMemberManager) syncPDHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
-	if tc.Spec.Paused {
-		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd headless service", tc.GetNamespace(), tc.GetName())
+// syncPDPVCAuditAnnotations patches the annotations identifying the cluster, the component and the pod ordinal
+// to the bound PVCs of PD if it's enabled. The existing annotations are kept if it's disabled.
+func (m *pdMemberManager) syncPDPVCAuditAnnotations(tc *v1alpha1.TidbCluster) error {
+	if !tc.PDPVCAuditAnnotationsEnabled() {
 		return nil
 	}
 
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
-
-	newSvc := getNewPDHeadlessServiceForTidbCluster(tc)
-	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDPeerMemberName(tcName))
-	if errors.IsNotFound(err) {
-		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
-		if err != nil {
-			return err
-		}
-		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+	if err != nil {
+		return err
 	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
 	if err != nil {
-		return fmt.Errorf("syncPDHeadlessServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDPeerMemberName(tcName), ns, tcName, err)
+		return fmt.Errorf("syncPDPVCAuditAnnotations: failed to list pvcs for cluster %s/%s, error: %s", ns, tcName, err)
 	}
 
-	oldSvc := oldSvcTmp.DeepCopy()
-
-	_, err = m.deps.ServiceControl.SyncComponentService(
-		tc,
-		newSvc,
-		oldSvc,
-		false)
+	for _, pvc := range pvcs {
+		if pvc.Status.Phase != corev1.ClaimBound || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		// the name of pvc is in the format of {volume}-{statefulset}-{ordinal}
+		ordinal, err := util.GetOrdinalFromPodName(pvc.Name)
+		if err != nil {
+			klog.Warningf("syncPDPVCAuditAnnotations: failed to get ordinal of pvc %s/%s for cluster %s/%s, error: %s", ns, pvc.Name, ns, tcName, err)
+			continue
+		}
+		annotations := getPDPVCAuditAnnotations(tc)
+		annotations[label.AnnPVCPodOrdinal] = strconv.Itoa(int(ordinal))
 
-	if err != nil {
-		return err
+		synced := true
+		for k, v := range annotations {
+			if pvc.Annotations[k] != v {
+				synced = false
+				break
+			}
+		}
+		if synced {
+			continue
+		}
+		if err := m.deps.PVCControl.PatchPVCAnnotations(tc, pvc, annotations); err != nil {
+			return fmt.Errorf("syncPDPVCAuditAnnotations: failed to patch annotations of pvc %s/%s for cluster %s/%s, error: %s", ns, pvc.Name, ns, tcName, err)
+		}
 	}
-
 	return nil
 }
 
-func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbCluster) error {
+// syncPDForceNewCluster consumes the annotation which designates a surviving PD member to be started with
+// `--force-new-cluster` to recover PD from the loss of quorum. The annotation is removed whether the request
+// is accepted or not, so it's applied at most once, and the request is refused unless the designated pod
+// belongs to PD and PD has lost the quorum. The designated member is cleared once PD has the quorum again.
+//
+// The request is delivered to the designated member only, by a file named after the pod in a ConfigMap
+// mounted by all the PD pods, and the pod is restarted to pick it up. The start script shared by the PD
+// pods is untouched, so the request doesn't depend on a rollout of the StatefulSet, which is blocked
+// while PD isn't synced.
+func (m *pdMemberManager) syncPDForceNewCluster(tc *v1alpha1.TidbCluster) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	oldPDSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
-	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("syncPDStatefulSetForTidbCluster: fail to get sts %s for cluster %s/%s, error: %s", controller.PDMemberName(tcName), ns, tcName, err)
+	if fnc := tc.Status.PD.ForceNewCluster; fnc != nil && tc.Status.PD.Synced && pdHasQuorum(tc) {
+		if err := m.deletePDForceNewClusterConfigMap(tc); err != nil {
+			return err
+		}
+		klog.Infof("syncPDForceNewCluster: pd of cluster %s/%s recovered, clear the force new cluster member %s", ns, tcName, fnc.Member)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, "ForceNewClusterCompleted", fmt.Sprintf("pd recovered from the force new cluster of member %s", fnc.Member))
+		tc.Status.PD.ForceNewCluster = nil
 	}
-	setNotExist := errors.IsNotFound(err)
-
-	oldPDSet := oldPDSetTmp.DeepCopy()
 
-	if err := m.syncTidbClusterStatus(tc, oldPDSet); err != nil {
-		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s status, error: %v", ns, tcName, err)
+	podName, ok := tc.Annotations[label.AnnPDForceNewCluster]
+	if !ok {
+		return nil
 	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, label.AnnPDForceNewCluster)
+	if _, err := m.deps.TiDBClusterControl.Patch(tc, []byte(patch)); err != nil {
+		return fmt.Errorf("syncPDForceNewCluster: failed to remove annotation %s of cluster %s/%s, error: %s", label.AnnPDForceNewCluster, ns, tcName, err)
+	}
+	delete(tc.Annotations, label.AnnPDForceNewCluster)
 
-	if tc.Spec.Paused {
-		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd statefulset", tc.GetNamespace(), tc.GetName())
+	if err := checkPDForceNewCluster(tc, podName); err != nil {
+		klog.Warningf("syncPDForceNewCluster: refuse to force new cluster of pd member %s for cluster %s/%s, error: %v", podName, ns, tcName, err)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedForceNewCluster", fmt.Sprintf("refuse to force new cluster of pd member %s: %v", podName, err))
 		return nil
 	}
 
-	cm, err := m.syncPDConfigMap(tc, oldPDSet)
-	if err != nil {
-		return err
+	fnc := &v1alpha1.PDForceNewCluster{
+		Member:      podName,
+		RequestTime: metav1.Now(),
 	}
-	newPDSet, err := getNewPDSetForTidbCluster(tc, cm)
-	if err != nil {
-		return err
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDForceNewClusterConfigMapName(tcName),
+			Namespace:       ns,
+			Labels:          label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		// the request time makes the marker file in the data dir, which makes sure that
+		// `--force-new-cluster` is applied at most once for each request
+		Data: map[string]string{
+			podName: strconv.FormatInt(fnc.RequestTime.Unix(), 10),
+		},
 	}
-	if setNotExist {
-		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDSet)
-		if err != nil {
-			return err
-		}
-		if err := m.deps.StatefulSetControl.CreateStatefulSet(tc, newPDSet); err != nil {
-			return err
-		}
-		tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{}
-		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
+	if _, err := m.deps.TypedControl.CreateOrUpdateConfigMap(tc, cm); err != nil {
+		return fmt.Errorf("syncPDForceNewCluster: failed to write configmap %s for cluster %s/%s, error: %s", cm.Name, ns, tcName, err)
 	}
 
-	// Force update takes precedence over scaling because force upgrade won't take effect when cluster gets stuck at scaling
-	if !tc.Status.PD.Synced && !templateEqual(newPDSet, oldPDSet) {
-		// upgrade forced only when `Synced` is false, because unable to upgrade gracefully
-		forceUpgradeAnnoSet := NeedForceUpgrade(tc.Annotations)
-		onlyOnePD := *oldPDSet.Spec.Replicas < 2 && len(tc.Status.PD.PeerMembers) == 0 // it's acceptable to use old record about peer members
+	klog.Infof("syncPDForceNewCluster: pd member %s of cluster %s/%s will be started with --force-new-cluster", podName, ns, tcName)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "ForceNewCluster", fmt.Sprintf("pd member %s will be started with --force-new-cluster", podName))
+	tc.Status.PD.ForceNewCluster = fnc
 
-		if forceUpgradeAnnoSet || onlyOnePD {
-			tc.Status.PD.Phase = v1alpha1.UpgradePhase
-			mngerutils.SetUpgradePartition(newPDSet, 0)
-			errSTS := mngerutils.UpdateStatefulSet(m.deps.StatefulSetControl, tc, newPDSet, oldPDSet)
-			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd needs force upgrade, %v", ns, tcName, errSTS)
-		}
+	// restart the designated member to start it with the request, the pod not created yet picks it up when it starts
+	pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("syncPDForceNewCluster: failed to get pod %s/%s for cluster %s/%s, error: %s", ns, podName, ns, tcName, err)
+	}
+	if err := m.deps.PodControl.DeletePod(tc, pod); err != nil {
+		return fmt.Errorf("syncPDForceNewCluster: failed to restart pod %s/%s for cluster %s/%s, error: %s", ns, podName, ns, tcName, err)
 	}
+	return nil
+}
 
-	// Scaling takes precedence over upgrading because:
-	// - if a pd fails in the upgrading, users may want to delete it or add
-	//   new replicas
-	// - it's ok to scale in the middle of upgrading (in statefulset controller
-	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldPDSet, newPDSet); err != nil {
-		return err
+// deletePDForceNewClusterConfigMap deletes the ConfigMap which delivers the force new cluster request
+func (m *pdMemberManager) deletePDForceNewClusterConfigMap(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	name := controller.PDForceNewClusterConfigMapName(tc.GetName())
+	existing, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deletePDForceNewClusterConfigMap: failed to get configmap %s for cluster %s/%s, error: %s", name, ns, tc.GetName(), err)
+	}
+	// never delete the configmap not created by the operator
+	if !metav1.IsControlledBy(existing, tc) {
+		return nil
 	}
+	return m.deps.TypedControl.Delete(tc, existing.DeepCopy())
+}
 
-	if m.deps.CLIConfig.AutoFailover {
-		if m.shouldRecover(tc) {
-			m.failover.Recover(tc)
-		} else if tc.Spec.PD.MaxFailoverCount != nil && *tc.Spec.PD.MaxFailoverCount > 0 && (tc.PDAllPodsStarted() && !tc.PDAllMembersReady() || tc.PDAutoFailovering()) {
-			if err := m.failover.Failover(tc); err != nil {
-				return err
+// checkPDForceNewCluster returns an error if the pod can't be started with `--force-new-cluster`
+func checkPDForceNewCluster(tc *v1alpha1.TidbCluster, podName string) error {
+	if fnc := tc.Status.PD.ForceNewCluster; fnc != nil {
+		return fmt.Errorf("pd member %s is being started with --force-new-cluster", fnc.Member)
+	}
+	ordinal, err := util.GetOrdinalFromPodName(podName)
+	if err != nil || PdPodName(tc.GetName(), ordinal) != podName || !tc.PDStsDesiredOrdinals(false).Has(ordinal) {
+		return fmt.Errorf("%s is not a pd pod of the cluster", podName)
+	}
+	if tc.Status.PD.Synced && pdHasQuorum(tc) {
+		return fmt.Errorf("pd still has the quorum")
+	}
+	return nil
+}
+
+// pdHasQuorum returns whether the majority of the known PD members are healthy
+func pdHasQuorum(tc *v1alpha1.TidbCluster) bool {
+	total, healthy := 0, 0
+	for _, members := range []map[string]v1alpha1.PDMember{tc.Status.PD.Members, tc.Status.PD.PeerMembers} {
+		for _, member := range members {
+			total++
+			if member.Health {
+				healthy++
 			}
 		}
 	}
+	return total > 0 && healthy > total/2
+}
 
-	if tc.Status.PD.VolReplaceInProgress {
-		// Volume Replace in Progress, so do not make any changes to Sts spec, overwrite with old pod spec
-		// config as we are not ready to upgrade yet.
-		_, podSpec, err := GetLastAppliedConfig(oldPDSet)
-		if err != nil {
-			return err
+// pdHoursPerMonth is the average number of hours in a month used to compute the monthly resource totals of PD
+const pdHoursPerMonth = 730
+
+// pdResourceSummary is the total CPU and memory of all PD replicas recorded in the annotation of the StatefulSet
+type pdResourceSummary struct {
+	Replicas               int32   `json:"replicas"`
+	CPU                    string  `json:"cpu,omitempty"`
+	Memory                 string  `json:"memory,omitempty"`
+	CPUCoreHoursPerMonth   float64 `json:"cpuCoreHoursPerMonth,omitempty"`
+	MemoryGiBHoursPerMonth float64 `json:"memoryGiBHoursPerMonth,omitempty"`
+}
+
+// getPDResourceSummary returns the JSON summary of the CPU and memory of all PD replicas multiplied by the hours
+// of a month, the requests are used and fall back to the limits. It's empty if neither CPU nor memory is set.
+func getPDResourceSummary(tc *v1alpha1.TidbCluster) string {
+	quantity := func(name corev1.ResourceName) (resource.Quantity, bool) {
+		if q, ok := tc.Spec.PD.Requests[name]; ok {
+			return q, true
 		}
-		newPDSet.Spec.Template.Spec = *podSpec
+		q, ok := tc.Spec.PD.Limits[name]
+		return q, ok
+	}
+	cpu, hasCPU := quantity(corev1.ResourceCPU)
+	memory, hasMemory := quantity(corev1.ResourceMemory)
+	if !hasCPU && !hasMemory {
+		return ""
 	}
 
-	if !templateEqual(newPDSet, oldPDSet) || tc.Status.PD.Phase == v1alpha1.UpgradePhase {
-		if err := m.upgrader.Upgrade(tc, oldPDSet, newPDSet); err != nil {
-			return err
-		}
+	replicas := tc.PDStsDesiredReplicas()
+	summary := pdResourceSummary{Replicas: replicas}
+	if hasCPU {
+		total := resource.NewMilliQuantity(cpu.MilliValue()*int64(replicas), resource.DecimalSI)
+		summary.CPU = total.String()
+		summary.CPUCoreHoursPerMonth = math.Round(float64(total.MilliValue())/1000*pdHoursPerMonth*100) / 100
+	}
+	if hasMemory {
+		total := resource.NewQuantity(memory.Value()*int64(replicas), resource.BinarySI)
+		summary.Memory = total.String()
+		summary.MemoryGiBHoursPerMonth = math.Round(float64(total.Value())/(1<<30)*pdHoursPerMonth*100) / 100
 	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
 
-	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDSTS", newPDSet, oldPDSet)
+// getPDPVCAuditAnnotations returns the annotations identifying the cluster and the component of the PD PVCs.
+func getPDPVCAuditAnnotations(tc *v1alpha1.TidbCluster) map[string]string {
+	return map[string]string{
+		label.AnnPVCClusterName: tc.GetName(),
+		label.AnnPVCComponent:   v1alpha1.PDMemberType.String(),
+	}
 }
 
-// shouldRecover checks whether we should perform recovery operation.
-func (m *pdMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
-	if tc.Status.PD.FailureMembers == nil {
-		return false
+// syncPDStoreLimits applies the store limits in spec to all stores if they drift from the ones in PD.
+// It's skipped if PD is unreachable, the limits will be reconciled in the next sync.
+func (m *pdMemberManager) syncPDStoreLimits(tc *v1alpha1.TidbCluster) error {
+	storeLimits := tc.Spec.PD.StoreLimits
+	if storeLimits == nil {
+		return nil
 	}
-	// If all desired replicas (excluding failover pods) of tidb cluster are
-	// healthy, we can perform our failover recovery operation.
-	// Note that failover pods may fail (e.g. lack of resources) and we don't care
-	// about them because we're going to delete them.
-	for ordinal := range tc.PDStsDesiredOrdinals(true) {
-		name := fmt.Sprintf("%s-%d", controller.PDMemberName(tc.GetName()), ordinal)
-		pod, err := m.deps.PodLister.Pods(tc.Namespace).Get(name)
-		if err != nil {
-			klog.Errorf("pod %s/%s does not exist: %v", tc.Namespace, name, err)
-			return false
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd store limits", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	currentLimits, err := pdClient.GetStoresLimit()
+	if err != nil {
+		klog.Warningf("syncPDStoreLimits: failed to get store limits of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+
+	desiredLimits := []struct {
+		limitType string
+		rate      *int32
+		current   func(limit *pdapi.StoreLimit) float64
+	}{
+		{
+			limitType: pdapi.StoreLimitTypeAddPeer,
+			rate:      storeLimits.AddPeer,
+			current:   func(limit *pdapi.StoreLimit) float64 { return limit.AddPeer },
+		},
+		{
+			limitType: pdapi.StoreLimitTypeRemovePeer,
+			rate:      storeLimits.RemovePeer,
+			current:   func(limit *pdapi.StoreLimit) float64 { return limit.RemovePeer },
+		},
+	}
+	for _, desired := range desiredLimits {
+		if desired.rate == nil {
+			continue
+		}
+		rate := float64(*desired.rate)
+		drifted := false
+		for _, limit := range currentLimits {
+			if limit != nil && desired.current(limit) != rate {
+				drifted = true
+				break
+			}
+		}
+		if !drifted {
+			continue
+		}
+		if err := pdClient.SetAllStoresLimit(desired.limitType, rate); err != nil {
+			return fmt.Errorf("syncPDStoreLimits: failed to set %s limit of all stores to %v for cluster %s/%s, error: %v", desired.limitType, rate, ns, tcName, err)
+		}
+		klog.Infof("syncPDStoreLimits: set %s limit of all stores to %v for cluster %s/%s", desired.limitType, rate, ns, tcName)
+	}
+	return nil
+}
+
+const (
+	// the scheduler limits computed for small clusters are raised to the defaults of PD
+	pdMinLeaderScheduleLimit = 4
+	pdMinRegionScheduleLimit = 2048
+	pdMaxLeaderScheduleLimit = 64
+	pdMaxRegionScheduleLimit = 16384
+	// pdRegionScheduleLimitPerStore is the region schedule limit contributed by each up store
+	pdRegionScheduleLimitPerStore = 256
+)
+
+// computePDSchedulerLimits returns the leader and region schedule limits for a cluster with the given number of up stores.
+// The leader schedule limit grows by 1 and the region schedule limit grows by 256 per store, bounded by
+// the defaults of PD at the lower end so that small clusters behave as if the limits were not tuned.
+func computePDSchedulerLimits(upStores int) (leaderLimit, regionLimit uint64) {
+	clamp := func(v, min, max uint64) uint64 {
+		if v < min {
+			return min
+		}
+		if v > max {
+			return max
+		}
+		return v
+	}
+	stores := uint64(0)
+	if upStores > 0 {
+		stores = uint64(upStores)
+	}
+	leaderLimit = clamp(stores, pdMinLeaderScheduleLimit, pdMaxLeaderScheduleLimit)
+	regionLimit = clamp(stores*pdRegionScheduleLimitPerStore, pdMinRegionScheduleLimit, pdMaxRegionScheduleLimit)
+	return leaderLimit, regionLimit
+}
+
+// syncPDSchedulerLimits computes the scheduler limits from the number of up stores and applies them via PD on drift,
+// so that the limits are re-evaluated as the cluster scales.
+func (m *pdMemberManager) syncPDSchedulerLimits(tc *v1alpha1.TidbCluster) error {
+	if !tc.PDAutoTuneSchedulerLimitsEnabled() {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd scheduler limits", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	storesInfo, err := pdClient.GetStores()
+	if err != nil {
+		klog.Warningf("syncPDSchedulerLimits: failed to get stores of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	upStores := 0
+	for _, store := range storesInfo.Stores {
+		if store != nil && store.Store != nil && store.Store.StateName == v1alpha1.TiKVStateUp {
+			upStores++
+		}
+	}
+	if upStores == 0 {
+		klog.V(4).Infof("syncPDSchedulerLimits: no up stores in cluster %s/%s, skip syncing", ns, tcName)
+		return nil
+	}
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		klog.Warningf("syncPDSchedulerLimits: failed to get config of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+
+	leaderLimit, regionLimit := computePDSchedulerLimits(upStores)
+	schedule := config.Schedule
+	if schedule != nil && schedule.LeaderScheduleLimit != nil && *schedule.LeaderScheduleLimit == leaderLimit &&
+		schedule.RegionScheduleLimit != nil && *schedule.RegionScheduleLimit == regionLimit {
+		return nil
+	}
+	desired := pdapi.PDScheduleConfig{
+		LeaderScheduleLimit: pointer.Uint64Ptr(leaderLimit),
+		RegionScheduleLimit: pointer.Uint64Ptr(regionLimit),
+	}
+	if err := pdClient.UpdateScheduleConfig(desired); err != nil {
+		return fmt.Errorf("syncPDSchedulerLimits: failed to set leader-schedule-limit to %d and region-schedule-limit to %d for cluster %s/%s with %d up stores, error: %v",
+			leaderLimit, regionLimit, ns, tcName, upStores, err)
+	}
+	klog.Infof("syncPDSchedulerLimits: set leader-schedule-limit to %d and region-schedule-limit to %d for cluster %s/%s with %d up stores",
+		leaderLimit, regionLimit, ns, tcName, upStores)
+	return nil
+}
+
+// syncPDSchedulers adds the schedulers in `.spec.pd.schedulers.add` which are absent in PD, and removes the
+// schedulers in `.spec.pd.schedulers.remove` which are present in PD. The other schedulers are left untouched.
+func (m *pdMemberManager) syncPDSchedulers(tc *v1alpha1.TidbCluster) error {
+	schedulers := tc.Spec.PD.Schedulers
+	if schedulers == nil || (len(schedulers.Add) == 0 && len(schedulers.Remove) == 0) {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd schedulers", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	current, err := pdClient.GetSchedulers()
+	if err != nil {
+		klog.Warningf("syncPDSchedulers: failed to get schedulers of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	existing := sets.NewString(current...)
+
+	for _, name := range schedulers.Add {
+		if existing.Has(name) {
+			continue
+		}
+		if err := pdClient.AddScheduler(name); err != nil {
+			return fmt.Errorf("syncPDSchedulers: failed to add scheduler %s for cluster %s/%s, error: %v", name, ns, tcName, err)
+		}
+		existing.Insert(name)
+		klog.Infof("syncPDSchedulers: added scheduler %s for cluster %s/%s", name, ns, tcName)
+	}
+	for _, name := range schedulers.Remove {
+		if !existing.Has(name) {
+			continue
+		}
+		if err := pdClient.RemoveScheduler(name); err != nil {
+			return fmt.Errorf("syncPDSchedulers: failed to remove scheduler %s for cluster %s/%s, error: %v", name, ns, tcName, err)
+		}
+		existing.Delete(name)
+		klog.Infof("syncPDSchedulers: removed scheduler %s for cluster %s/%s", name, ns, tcName)
+	}
+	return nil
+}
+
+// syncPDPlacementRules reconciles `replication.enable-placement-rules` of PD to `.spec.pd.enablePlacementRules`.
+// Disabling the placement rules may drop the rules created by users or other components, so it's only applied
+// when the annotation `pingcap.com/pd-allow-disable-placement-rules` of the cluster is "true" and TiFlash,
+// which requires the placement rules, is not deployed. Otherwise an event explaining the risk is emitted.
+func (m *pdMemberManager) syncPDPlacementRules(tc *v1alpha1.TidbCluster) error {
+	desired := tc.Spec.PD.EnablePlacementRules
+	if desired == nil {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd placement rules", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		klog.Warningf("syncPDPlacementRules: failed to get config of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	if config.Replication == nil || config.Replication.EnablePlacementRules == nil ||
+		*config.Replication.EnablePlacementRules == *desired {
+		return nil
+	}
+
+	if !*desired {
+		var reason string
+		if tc.Spec.TiFlash != nil {
+			reason = "TiFlash is deployed and requires the placement rules"
+		} else if tc.Annotations[label.AnnPDAllowDisablePlacementRules] != "true" {
+			reason = fmt.Sprintf("the placement rules created by users or other components may be dropped, set annotation %s to \"true\" to disable them anyway",
+				label.AnnPDAllowDisablePlacementRules)
+		}
+		if reason != "" {
+			msg := fmt.Sprintf("disabling placement rules of PD is refused, %s", reason)
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PlacementRulesDisableRefused", msg)
+			klog.Warningf("syncPDPlacementRules: cluster %s/%s %s", ns, tcName, msg)
+			return nil
+		}
+	}
+
+	if err := pdClient.UpdateReplicationConfig(pdapi.PDReplicationConfig{EnablePlacementRules: desired}); err != nil {
+		return fmt.Errorf("syncPDPlacementRules: failed to set enable-placement-rules to %t for cluster %s/%s, error: %v", *desired, ns, tcName, err)
+	}
+	klog.Infof("syncPDPlacementRules: set enable-placement-rules to %t for cluster %s/%s", *desired, ns, tcName)
+	return nil
+}
+
+// syncPDRegionMerge applies the region merge config in `.spec.pd.regionMerge` to the running PD cluster if
+// the hot reload is enabled, so that a change takes effect without restarting PD. The items set in
+// `.spec.pd.config` take precedence and are never applied.
+func (m *pdMemberManager) syncPDRegionMerge(tc *v1alpha1.TidbCluster) error {
+	if !tc.PDRegionMergeHotReloadEnabled() {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd region merge config", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+	// the items set in the config of PD take precedence, no matter it's .pd.config or .pd.configFrom
+	configSource, err := m.getPDConfigSource(tc)
+	if err != nil {
+		return err
+	}
+	desired := getPDRegionMergeScheduleConfig(configSource)
+	if desired.EnableCrossTableMerge == nil && desired.MaxMergeRegionSize == nil && desired.MaxMergeRegionKeys == nil {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		klog.Warningf("syncPDRegionMerge: failed to get config of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	if current := config.Schedule; current != nil &&
+		pdConfigBoolApplied(desired.EnableCrossTableMerge, current.EnableCrossTableMerge) &&
+		pdConfigUint64Applied(desired.MaxMergeRegionSize, current.MaxMergeRegionSize) &&
+		pdConfigUint64Applied(desired.MaxMergeRegionKeys, current.MaxMergeRegionKeys) {
+		return nil
+	}
+	if err := pdClient.UpdateScheduleConfig(desired); err != nil {
+		return fmt.Errorf("syncPDRegionMerge: failed to update region merge config for cluster %s/%s, error: %v", ns, tcName, err)
+	}
+	klog.Infof("syncPDRegionMerge: update region merge config for cluster %s/%s", ns, tcName)
+	return nil
+}
+
+// getPDRegionMergeScheduleConfig returns the schedule config of PD with the items in `.spec.pd.regionMerge`
+// which are not set in `.spec.pd.config`
+func getPDRegionMergeScheduleConfig(tc *v1alpha1.TidbCluster) pdapi.PDScheduleConfig {
+	merge := tc.Spec.PD.RegionMerge
+	overridden := func(key string) bool {
+		return tc.Spec.PD.Config != nil && tc.Spec.PD.Config.Get(key) != nil
+	}
+
+	config := pdapi.PDScheduleConfig{}
+	if merge.EnableCrossTableMerge != nil && !overridden("schedule.enable-cross-table-merge") {
+		config.EnableCrossTableMerge = pointer.BoolPtr(*merge.EnableCrossTableMerge)
+	}
+	if merge.MaxMergeRegionSize != nil && !overridden("schedule.max-merge-region-size") {
+		config.MaxMergeRegionSize = pointer.Uint64Ptr(uint64(*merge.MaxMergeRegionSize))
+	}
+	if merge.MaxMergeRegionKeys != nil && !overridden("schedule.max-merge-region-keys") {
+		config.MaxMergeRegionKeys = pointer.Uint64Ptr(uint64(*merge.MaxMergeRegionKeys))
+	}
+	return config
+}
+
+// syncPDScheduleConfig applies `.spec.pd.hotRegionScheduleLimit` and `.spec.pd.splitMergeInterval` to the
+// running PD cluster, so that a change takes effect without restarting PD. The items set in `.spec.pd.config`
+// take precedence and are never applied.
+func (m *pdMemberManager) syncPDScheduleConfig(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd schedule config", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+	// the items set in the config of PD take precedence, no matter it's .pd.config or .pd.configFrom
+	configSource, err := m.getPDConfigSource(tc)
+	if err != nil {
+		return err
+	}
+	desired := getPDScheduleConfig(configSource)
+	if desired.HotRegionScheduleLimit == nil && desired.SplitMergeInterval == "" {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		klog.Warningf("syncPDScheduleConfig: failed to get config of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	if current := config.Schedule; current != nil &&
+		pdConfigUint64Applied(desired.HotRegionScheduleLimit, current.HotRegionScheduleLimit) &&
+		pdConfigDurationApplied(desired.SplitMergeInterval, current.SplitMergeInterval) {
+		return nil
+	}
+	if err := pdClient.UpdateScheduleConfig(desired); err != nil {
+		return fmt.Errorf("syncPDScheduleConfig: failed to update schedule config for cluster %s/%s, error: %v", ns, tcName, err)
+	}
+	klog.Infof("syncPDScheduleConfig: update schedule config for cluster %s/%s", ns, tcName)
+	return nil
+}
+
+// getPDScheduleConfig returns the schedule config of PD with `.spec.pd.hotRegionScheduleLimit` and
+// `.spec.pd.splitMergeInterval` if they are not set in `.spec.pd.config`
+func getPDScheduleConfig(tc *v1alpha1.TidbCluster) pdapi.PDScheduleConfig {
+	overridden := func(key string) bool {
+		return tc.Spec.PD.Config != nil && tc.Spec.PD.Config.Get(key) != nil
+	}
+
+	config := pdapi.PDScheduleConfig{}
+	if limit := tc.Spec.PD.HotRegionScheduleLimit; limit != nil && !overridden("schedule.hot-region-schedule-limit") {
+		config.HotRegionScheduleLimit = pointer.Uint64Ptr(uint64(*limit))
+	}
+	if interval := tc.Spec.PD.SplitMergeInterval; interval != nil && !overridden("schedule.split-merge-interval") {
+		config.SplitMergeInterval = *interval
+	}
+	return config
+}
+
+// pdConfigBoolApplied returns whether the desired item of PD config is unset or equal to the current one
+func pdConfigBoolApplied(desired, current *bool) bool {
+	return desired == nil || (current != nil && *desired == *current)
+}
+
+// pdConfigUint64Applied returns whether the desired item of PD config is unset or equal to the current one
+func pdConfigUint64Applied(desired, current *uint64) bool {
+	return desired == nil || (current != nil && *desired == *current)
+}
+
+// pdConfigDurationApplied returns whether the desired duration item of PD config is unset or equal to the current one,
+// PD may format a duration differently, e.g. 1h0m0s for 1h
+func pdConfigDurationApplied(desired, current string) bool {
+	if desired == "" || desired == current {
+		return true
+	}
+	d1, err1 := time.ParseDuration(desired)
+	d2, err2 := time.ParseDuration(current)
+	return err1 == nil && err2 == nil && d1 == d2
+}
+
+// checkPDMaxReplicas sets the PDMaxReplicasExceeded condition if the max-replicas of PD exceeds the failure domains
+// of the up TiKV stores, in which case the regions are left under-replicated. PD never places two replicas of a region
+// in the same store, or in the same location at the isolation level if it's set, so a failure domain is a distinct
+// location at the isolation level, or a store otherwise. A warning event is emitted only when the condition is set
+// or its message changes. Nothing is changed in PD.
+func (m *pdMemberManager) checkPDMaxReplicas(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiKV == nil {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip checking pd max-replicas", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		klog.Warningf("checkPDMaxReplicas: failed to get config of cluster %s/%s, skip checking, error: %v", ns, tcName, err)
+		return nil
+	}
+	if config.Replication == nil || config.Replication.MaxReplicas == nil {
+		return nil
+	}
+	storesInfo, err := pdClient.GetStores()
+	if err != nil {
+		klog.Warningf("checkPDMaxReplicas: failed to get stores of cluster %s/%s, skip checking, error: %v", ns, tcName, err)
+		return nil
+	}
+
+	isolationLevel := ""
+	if config.Replication.IsolationLevel != nil {
+		isolationLevel = *config.Replication.IsolationLevel
+	}
+	domains := countTiKVFailureDomains(storesInfo, config.Replication.LocationLabels, isolationLevel)
+	maxReplicas := *config.Replication.MaxReplicas
+	// the cluster is not bootstrapped yet
+	if domains == 0 || maxReplicas <= uint64(domains) {
+		tc.Status.PD.RemoveCondition(v1alpha1.PDMaxReplicasExceeded)
+		return nil
+	}
+	msg := fmt.Sprintf("max-replicas %d of PD exceeds the %d failure domains of the up TiKV stores, regions are under-replicated",
+		maxReplicas, domains)
+	if isolationLevel != "" {
+		msg = fmt.Sprintf("%s, the failure domains are counted at the isolation level %q", msg, isolationLevel)
+	}
+	if old := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.PDMaxReplicasExceeded); old != nil &&
+		old.Status == metav1.ConditionTrue && old.Message == msg {
+		return nil
+	}
+	tc.Status.PD.SetCondition(metav1.Condition{
+		Type:    v1alpha1.PDMaxReplicasExceeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ExceedFailureDomains",
+		Message: msg,
+	})
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDMaxReplicasExceedFailureDomains", msg)
+	klog.Warningf("checkPDMaxReplicas: cluster %s/%s %s", ns, tcName, msg)
+	return nil
+}
+
+// countTiKVFailureDomains counts the distinct locations of the up TiKV stores at the isolation level, i.e. the
+// values of the location labels up to the isolation level. Each store is a failure domain if the isolation level
+// is not one of the location labels. The TiFlash stores are excluded.
+func countTiKVFailureDomains(storesInfo *pdapi.StoresInfo, locationLabels []string, isolationLevel string) int {
+	depth := 0
+	for i, l := range locationLabels {
+		if l == isolationLevel {
+			depth = i + 1
+			break
+		}
+	}
+
+	domains := sets.NewString()
+	for _, store := range storesInfo.Stores {
+		if store == nil || store.Store == nil || store.Store.Store == nil || store.Store.StateName != v1alpha1.TiKVStateUp {
+			continue
+		}
+		labels := map[string]string{}
+		for _, l := range store.Store.Labels {
+			labels[l.GetKey()] = l.GetValue()
+		}
+		if labels[pdStoreEngineLabelKey] == pdStoreEngineTiFlash {
+			continue
+		}
+		if depth == 0 {
+			domains.Insert(strconv.FormatUint(store.Store.Id, 10))
+			continue
+		}
+		location := make([]string, 0, depth)
+		for _, key := range locationLabels[:depth] {
+			location = append(location, labels[key])
+		}
+		domains.Insert(strings.Join(location, "/"))
+	}
+	return domains.Len()
+}
+
+// syncPDRegionLabelRules applies the region label rules in spec via PD on drift, and deletes the rules applied before
+// but removed from spec. The IDs of the applied rules are recorded in status, so the rules created by others are kept.
+func (m *pdMemberManager) syncPDRegionLabelRules(tc *v1alpha1.TidbCluster) error {
+	desiredRules := tc.Spec.PD.RegionLabelRules
+	if len(desiredRules) == 0 && len(tc.Status.PD.AppliedRegionLabelRules) == 0 {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd region label rules", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	currentRules, err := pdClient.GetRegionLabelRules()
+	if err != nil {
+		klog.Warningf("syncPDRegionLabelRules: failed to get region label rules of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	current := make(map[string]*pdapi.RegionLabelRule, len(currentRules))
+	for _, rule := range currentRules {
+		if rule != nil {
+			current[rule.ID] = rule
+		}
+	}
+
+	applied := sets.NewString(tc.Status.PD.AppliedRegionLabelRules...)
+	// record the applied rules even if some of them fail, so they can be cleaned up after removed from spec
+	defer func() {
+		tc.Status.PD.AppliedRegionLabelRules = applied.List()
+	}()
+
+	desiredIDs := sets.NewString()
+	for i := range desiredRules {
+		desired := toPDRegionLabelRule(&desiredRules[i])
+		desiredIDs.Insert(desired.ID)
+		if rule, ok := current[desired.ID]; ok && reflect.DeepEqual(rule, desired) {
+			applied.Insert(desired.ID)
+			continue
+		}
+		if err := pdClient.SetRegionLabelRule(desired); err != nil {
+			return fmt.Errorf("syncPDRegionLabelRules: failed to set region label rule %s for cluster %s/%s, error: %v", desired.ID, ns, tcName, err)
+		}
+		applied.Insert(desired.ID)
+		klog.Infof("syncPDRegionLabelRules: set region label rule %s for cluster %s/%s", desired.ID, ns, tcName)
+	}
+
+	for _, id := range applied.Difference(desiredIDs).List() {
+		if _, ok := current[id]; ok {
+			if err := pdClient.DeleteRegionLabelRule(id); err != nil {
+				return fmt.Errorf("syncPDRegionLabelRules: failed to delete region label rule %s for cluster %s/%s, error: %v", id, ns, tcName, err)
+			}
+			klog.Infof("syncPDRegionLabelRules: deleted region label rule %s for cluster %s/%s", id, ns, tcName)
+		}
+		applied.Delete(id)
+	}
+	return nil
+}
+
+// syncPDLabelProperties sets the label properties in spec via PD if absent, and deletes the properties set before
+// but removed from spec. Only the properties set by the operator are recorded in status, so the properties set by
+// others, including the ones already present in PD when they're added to spec, are kept.
+func (m *pdMemberManager) syncPDLabelProperties(tc *v1alpha1.TidbCluster) error {
+	desiredProperties := tc.Spec.PD.LabelProperties
+	if len(desiredProperties) == 0 && len(tc.Status.PD.AppliedLabelProperties) == 0 {
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd label properties", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdClient.GetLabelProperties()
+	if err != nil {
+		klog.Warningf("syncPDLabelProperties: failed to get label properties of cluster %s/%s, skip syncing, error: %v", ns, tcName, err)
+		return nil
+	}
+	current := map[v1alpha1.PDLabelProperty]struct{}{}
+	for typ, labels := range config {
+		for _, l := range labels {
+			current[v1alpha1.PDLabelProperty{Type: typ, Key: l.Key, Value: l.Value}] = struct{}{}
+		}
+	}
+
+	applied := map[v1alpha1.PDLabelProperty]struct{}{}
+	for _, p := range tc.Status.PD.AppliedLabelProperties {
+		applied[p] = struct{}{}
+	}
+	// record the applied properties even if some of them fail, so they can be cleaned up after removed from spec
+	defer func() {
+		tc.Status.PD.AppliedLabelProperties = sortedPDLabelProperties(applied)
+	}()
+
+	desired := map[v1alpha1.PDLabelProperty]struct{}{}
+	for _, p := range desiredProperties {
+		desired[p] = struct{}{}
+		if _, ok := current[p]; !ok {
+			if err := pdClient.SetLabelProperty(pdapi.LabelProperty{Type: p.Type, Key: p.Key, Value: p.Value}); err != nil {
+				return fmt.Errorf("syncPDLabelProperties: failed to set label property %s %s=%s for cluster %s/%s, error: %v", p.Type, p.Key, p.Value, ns, tcName, err)
+			}
+			klog.Infof("syncPDLabelProperties: set label property %s %s=%s for cluster %s/%s", p.Type, p.Key, p.Value, ns, tcName)
+			applied[p] = struct{}{}
+		}
+	}
+
+	for _, p := range sortedPDLabelProperties(applied) {
+		if _, ok := desired[p]; ok {
+			continue
+		}
+		if _, ok := current[p]; ok {
+			if err := pdClient.DeleteLabelProperty(pdapi.LabelProperty{Type: p.Type, Key: p.Key, Value: p.Value}); err != nil {
+				return fmt.Errorf("syncPDLabelProperties: failed to delete label property %s %s=%s for cluster %s/%s, error: %v", p.Type, p.Key, p.Value, ns, tcName, err)
+			}
+			klog.Infof("syncPDLabelProperties: deleted label property %s %s=%s for cluster %s/%s", p.Type, p.Key, p.Value, ns, tcName)
+		}
+		delete(applied, p)
+	}
+	return nil
+}
+
+// sortedPDLabelProperties returns the label properties in the set sorted by the type, key and value
+func sortedPDLabelProperties(properties map[v1alpha1.PDLabelProperty]struct{}) []v1alpha1.PDLabelProperty {
+	if len(properties) == 0 {
+		return nil
+	}
+	sorted := make([]v1alpha1.PDLabelProperty, 0, len(properties))
+	for p := range properties {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		if sorted[i].Key != sorted[j].Key {
+			return sorted[i].Key < sorted[j].Key
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	return sorted
+}
+
+// toPDRegionLabelRule converts the region label rule in spec to the one of PD
+func toPDRegionLabelRule(rule *v1alpha1.PDRegionLabelRule) *pdapi.RegionLabelRule {
+	labels := make([]pdapi.RegionLabel, 0, len(rule.Labels))
+	for _, l := range rule.Labels {
+		labels = append(labels, pdapi.RegionLabel{Key: l.Key, Value: l.Value})
+	}
+	keyRanges := make([]pdapi.KeyRange, 0, len(rule.KeyRanges))
+	for _, r := range rule.KeyRanges {
+		keyRanges = append(keyRanges, pdapi.KeyRange{StartKey: r.StartKey, EndKey: r.EndKey})
+	}
+	return &pdapi.RegionLabelRule{
+		ID:       rule.ID,
+		Index:    int(rule.Index),
+		Labels:   labels,
+		RuleType: pdapi.RegionLabelRuleTypeKeyRange,
+		Data:     keyRanges,
+	}
+}
+
+func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd service", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	newSvc := m.getNewPDServiceForTidbCluster(tc)
+	if m.deps.CLIConfig.PDServerSideApply {
+		return m.applyPDService(tc, newSvc)
+	}
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.createPDService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncPDServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDMemberName(tcName), ns, tcName, err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	// the ports modified externally are not detected by comparing with the last applied config,
+	// drop the config to force overwriting them if the desired spec is not changed since last applied.
+	if equal, err := controller.ServiceEqual(newSvc, oldSvc); err == nil && equal {
+		if ports := getDriftedServicePorts(newSvc, oldSvc); len(ports) > 0 {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDServicePortsOverwritten",
+				fmt.Sprintf("ports %s of service %s are modified externally, overwrite them with the desired ones", strings.Join(ports, ", "), oldSvc.Name))
+			delete(oldSvc.Annotations, controller.LastAppliedConfigAnnotation)
+		}
+	}
+
+	_, err = m.deps.ServiceControl.SyncComponentService(
+		tc,
+		newSvc,
+		oldSvc,
+		true)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getDriftedServicePorts returns the names of the desired ports that are missing or different in the
+// live service. The node ports allocated by Kubernetes are ignored if they are not specified.
+func getDriftedServicePorts(newSvc, oldSvc *corev1.Service) []string {
+	livePorts := map[string]corev1.ServicePort{}
+	for _, port := range oldSvc.Spec.Ports {
+		livePorts[port.Name] = port
+	}
+	var drifted []string
+	for _, port := range newSvc.Spec.Ports {
+		live, ok := livePorts[port.Name]
+		if !ok || live.Port != port.Port || live.TargetPort != port.TargetPort || live.Protocol != port.Protocol ||
+			(port.NodePort != 0 && live.NodePort != port.NodePort) {
+			drifted = append(drifted, port.Name)
+		}
+	}
+	return drifted
+}
+
+// applyPDService applies the service of PD with server-side apply. The ports modified externally are taken
+// over from the operator silently, so they are detected as in syncPDServiceForTidbCluster and overwritten by
+// forcing the apply, while other conflicts fail the apply.
+func (m *pdMemberManager) applyPDService(tc *v1alpha1.TidbCluster, newSvc *corev1.Service) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	ac, err := controller.NewServiceApplyConfiguration(newSvc)
+	if err != nil {
+		return err
+	}
+	force := false
+	oldSvc, err := m.deps.ServiceLister.Services(ns).Get(newSvc.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("applyPDService: failed to get svc %s for cluster %s/%s, error: %s", newSvc.Name, ns, tcName, err)
+	}
+	if err == nil {
+		if ports := getDriftedServicePorts(newSvc, oldSvc); len(ports) > 0 {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDServicePortsOverwritten",
+				fmt.Sprintf("ports %s of service %s are modified externally, overwrite them with the desired ones", strings.Join(ports, ", "), oldSvc.Name))
+			force = true
+		} else {
+			applied, err := controller.ServiceApplied(ac, oldSvc)
+			if err != nil {
+				return err
+			}
+			if applied {
+				return nil
+			}
+		}
+	}
+	_, err = m.deps.ServiceControl.ApplyService(tc, ac, force)
+	return err
+}
+
+func (m *pdMemberManager) syncPDHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd headless service", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	newSvc := getNewPDHeadlessServiceForTidbCluster(tc)
+	if m.deps.CLIConfig.PDServerSideApply {
+		return m.applyPDService(tc, newSvc)
+	}
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDPeerMemberName(tcName))
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.createPDService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncPDHeadlessServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDPeerMemberName(tcName), ns, tcName, err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	_, err = m.deps.ServiceControl.SyncComponentService(
+		tc,
+		newSvc,
+		oldSvc,
+		false)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *pdMemberManager) syncPDLeaderServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd leader service", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+	if !tc.PDLeaderServiceEnabled() {
+		return m.deletePDService(tc, controller.PDLeaderMemberName(tc.GetName()))
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	newSvc := getNewPDLeaderServiceForTidbCluster(tc)
+	if m.deps.CLIConfig.PDServerSideApply {
+		return m.applyPDService(tc, newSvc)
+	}
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDLeaderMemberName(tcName))
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.createPDService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncPDLeaderServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDLeaderMemberName(tcName), ns, tcName, err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	_, err = m.deps.ServiceControl.SyncComponentService(
+		tc,
+		newSvc,
+		oldSvc,
+		true)
+
+	return err
+}
+
+func (m *pdMemberManager) syncPDPprofServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd pprof service", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+	if !tc.PDPprofServiceEnabled() {
+		return m.deletePDService(tc, controller.PDPprofMemberName(tc.GetName()))
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	newSvc := getNewPDPprofServiceForTidbCluster(tc)
+	if m.deps.CLIConfig.PDServerSideApply {
+		return m.applyPDService(tc, newSvc)
+	}
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDPprofMemberName(tcName))
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.createPDService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncPDPprofServiceForTidbCluster: failed to get svc %s for cluster %s/%s, error: %s", controller.PDPprofMemberName(tcName), ns, tcName, err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	_, err = m.deps.ServiceControl.SyncComponentService(
+		tc,
+		newSvc,
+		oldSvc,
+		true)
+
+	return err
+}
+
+// deletePDService deletes the optional service of PD when it's disabled, the service not created by the operator
+// is never deleted.
+func (m *pdMemberManager) deletePDService(tc *v1alpha1.TidbCluster, name string) error {
+	ns := tc.GetNamespace()
+	svc, err := m.deps.ServiceLister.Services(ns).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deletePDService: failed to get svc %s for cluster %s/%s, error: %s", name, ns, tc.GetName(), err)
+	}
+	if !metav1.IsControlledBy(svc, tc) {
+		return nil
+	}
+	return m.deps.ServiceControl.DeleteService(tc, svc)
+}
+
+// createPDService creates the service of PD. The service may exist already if the lister is stale
+// during fast reconciles, it's not an error and the sync is requeued to get the up-to-date service.
+func (m *pdMemberManager) createPDService(tc *v1alpha1.TidbCluster, svc *corev1.Service) error {
+	err := m.deps.ServiceControl.CreateService(tc, svc)
+	if errors.IsAlreadyExists(err) {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s service %s already exists, the lister may be stale", tc.GetNamespace(), tc.GetName(), svc.GetName())
+	}
+	return err
+}
+
+func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	oldPDSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncPDStatefulSetForTidbCluster: fail to get sts %s for cluster %s/%s, error: %s", controller.PDMemberName(tcName), ns, tcName, err)
+	}
+	setNotExist := errors.IsNotFound(err)
+
+	oldPDSet := oldPDSetTmp.DeepCopy()
+
+	if err := m.syncTidbClusterStatus(tc, oldPDSet); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s status, error: %v", ns, tcName, err)
+	}
+
+	// the failover pods are kept unready until their initial delay elapses even if the cluster is paused,
+	// so the conditions are synced before the paused check
+	failoverReadyAfter, err := m.syncPDFailoverReadyConditions(tc)
+	if err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd failover ready conditions of pods, error: %v", ns, tcName, err)
+	}
+
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd statefulset", tc.GetNamespace(), tc.GetName())
+		return requeuePDFailoverReady(tc, failoverReadyAfter)
+	}
+
+	if err := m.syncPDPreferredLeader(tc); err != nil {
+		klog.Errorf("failed to set TidbCluster: [%s/%s]'s pd leader priority of the preferred member, error: %v", ns, tcName, err)
+	}
+
+	if err := m.syncPDMemberHealthConditions(tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd member health conditions of pods, error: %v", ns, tcName, err)
+	}
+
+	cm, err := m.syncPDConfigMap(tc, oldPDSet)
+	if err != nil {
+		return err
+	}
+	newPDSet, err := getNewPDSetForTidbCluster(tc, cm)
+	if err != nil {
+		return err
+	}
+	if tc.PDParallelBootstrapEnabled() {
+		newPDSet.Spec.PodManagementPolicy = getPDBootstrapPodManagementPolicy(tc, oldPDSet)
+	}
+	if setNotExist {
+		if m.deps.CLIConfig.PDServerSideApply {
+			err = mngerutils.ApplyStatefulSet(m.deps.StatefulSetControl, tc, newPDSet, nil)
+		} else {
+			err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDSet)
+			if err != nil {
+				return err
+			}
+			err = m.deps.StatefulSetControl.CreateStatefulSet(tc, newPDSet)
+		}
+		if err != nil {
+			return err
+		}
+		tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{}
+		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
+	}
+
+	if err := m.checkPDOrdinalsStart(tc, oldPDSet); err != nil {
+		return err
+	}
+
+	// Check PD storage shrink, it's advisory only
+	if err := m.checkPDStorageShrink(tc); err != nil {
+		klog.Errorf("failed to check TidbCluster: [%s/%s]'s pd storage shrink, error: %v", ns, tcName, err)
+	}
+	if err := m.checkPDVersionTransition(tc, oldPDSet); err != nil {
+		return err
+	}
+
+	// Pin the pod template to the given revision, and never roll forward while pinned
+	pinnedRevision := tc.Annotations[label.AnnPDPinnedRevision]
+	if pinnedRevision != "" {
+		if err := m.pinPDStatefulSetToRevision(tc, oldPDSet, newPDSet, pinnedRevision); err != nil {
+			return err
+		}
+	}
+
+	// Force update takes precedence over scaling because force upgrade won't take effect when cluster gets stuck at scaling
+	if pinnedRevision == "" && !tc.Status.PD.Synced && !appliedTemplateEqual(newPDSet, oldPDSet, m.deps.CLIConfig.PDServerSideApply) {
+		// upgrade forced only when `Synced` is false, because unable to upgrade gracefully
+		forceUpgradeAnnoSet := NeedForceUpgrade(tc.Annotations)
+		onlyOnePD := *oldPDSet.Spec.Replicas < 2 && len(tc.Status.PD.PeerMembers) == 0 // it's acceptable to use old record about peer members
+
+		if forceUpgradeAnnoSet || onlyOnePD {
+			tc.Status.PD.Phase = v1alpha1.UpgradePhase
+			mngerutils.SetUpgradePartition(newPDSet, 0)
+			if err := m.verifyPDConfigMapVolumes(tc, newPDSet, cm, false); err != nil {
+				return err
+			}
+			var errSTS error
+			if m.deps.CLIConfig.PDServerSideApply {
+				errSTS = mngerutils.ApplyStatefulSet(m.deps.StatefulSetControl, tc, newPDSet, oldPDSet)
+			} else {
+				errSTS = mngerutils.UpdateStatefulSet(m.deps.StatefulSetControl, tc, newPDSet, oldPDSet)
+			}
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd needs force upgrade, %v", ns, tcName, errSTS)
+		}
+	}
+
+	// Scale-out is deferred until the upgrade completes, as a new member joining the PD cluster in the
+	// middle of a rolling upgrade may destabilize the quorum. The phase is kept as upgrade in this case,
+	// see syncTidbClusterStatus.
+	scaleOutDeferred := false
+	if scaling, _, _, _ := scaleOne(oldPDSet, newPDSet); scaling > 0 && tc.Status.PD.Phase == v1alpha1.UpgradePhase {
+		msg := fmt.Sprintf("scale-out of pd from %d to %d replicas is deferred until the upgrade completes",
+			*oldPDSet.Spec.Replicas, *newPDSet.Spec.Replicas)
+		klog.Infof("tidbcluster: [%s/%s] %s", ns, tcName, msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, "PDScaleOutDeferred", msg)
+		resetReplicas(newPDSet, oldPDSet)
+		scaleOutDeferred = true
+	}
+
+	// Scaling in takes precedence over upgrading because:
+	// - if a pd fails in the upgrading, users may want to delete it
+	// - it's ok to scale in the middle of upgrading (in statefulset controller
+	//   scaling takes precedence over upgrading too)
+	if err := m.scaler.Scale(tc, oldPDSet, newPDSet); err != nil {
+		return err
+	}
+
+	if m.deps.CLIConfig.AutoFailover {
+		if m.shouldRecover(tc) {
+			m.failover.Recover(tc)
+		} else if tc.Spec.PD.MaxFailoverCount != nil && *tc.Spec.PD.MaxFailoverCount > 0 && (tc.PDAllPodsStarted() && !tc.PDAllMembersReady() || tc.PDAutoFailovering()) {
+			if _, disabled := tc.Annotations[label.AnnPDDisableFailover]; disabled {
+				klog.Infof("tidbcluster: [%s/%s]'s pd failover is skipped because of annotation %s", ns, tcName, label.AnnPDDisableFailover)
+			} else if err := m.failover.Failover(tc); err != nil {
+				return err
+			}
+		}
+	}
+
+	if tc.Status.PD.VolReplaceInProgress {
+		// Volume Replace in Progress, so do not make any changes to Sts spec, overwrite with old pod spec
+		// config as we are not ready to upgrade yet.
+		podSpec, err := getLastAppliedPodSpec(oldPDSet, m.deps.CLIConfig.PDServerSideApply)
+		if err != nil {
+			return err
+		}
+		newPDSet.Spec.Template.Spec = *podSpec
+	}
+
+	if err := m.verifyPDConfigMapVolumes(tc, newPDSet, cm, pinnedRevision != "" || tc.Status.PD.VolReplaceInProgress); err != nil {
+		return err
+	}
+
+	if pinnedRevision == "" && (!appliedTemplateEqual(newPDSet, oldPDSet, m.deps.CLIConfig.PDServerSideApply) || tc.Status.PD.Phase == v1alpha1.UpgradePhase) {
+		if err := m.upgrader.Upgrade(tc, oldPDSet, newPDSet); err != nil {
+			return err
+		}
+	}
+
+	if m.deps.CLIConfig.PDServerSideApply {
+		err = mngerutils.ApplyStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDSTS", newPDSet, oldPDSet)
+	} else {
+		err = mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDSTS", newPDSet, oldPDSet)
+	}
+	m.recordPDConfigRolloutResult(tc, newPDSet.Name, err)
+	if err == nil && scaleOutDeferred {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd scale-out is deferred until the upgrade completes", ns, tcName)
+	}
+	if err == nil {
+		return requeuePDFailoverReady(tc, failoverReadyAfter)
+	}
+	return err
+}
+
+// checkPDOrdinalsStart rejects the change of `.spec.pd.ordinalsStart` after the StatefulSet of PD is created.
+// The ordinals of the StatefulSet are never updated, changing them would recreate the PD pods with other names
+// and lose the PD members, so it's enforced here besides the webhook which may be not deployed.
+func (m *pdMemberManager) checkPDOrdinalsStart(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
+	current, desired := getStsOrdinalsStart(set), tc.PDOrdinalsStart()
+	if current == desired {
+		return nil
+	}
+	msg := fmt.Sprintf("the ordinals start of pd can't be changed from %d to %d after the statefulset is created", current, desired)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedUpdatePDSTS", msg)
+	return fmt.Errorf("tidbcluster: [%s/%s] %s", tc.GetNamespace(), tc.GetName(), msg)
+}
+
+// requeuePDFailoverReady requeues the sync when the initial delay of a failover pod elapses, nil is returned
+// if no failover pod is waiting
+func requeuePDFailoverReady(tc *v1alpha1.TidbCluster, after time.Duration) error {
+	if after <= 0 {
+		return nil
+	}
+	return controller.RequeueAfterErrorf(after, "tidbcluster: [%s/%s]'s pd failover pods are waiting for the initial delay of readiness", tc.GetNamespace(), tc.GetName())
+}
+
+// recordPDConfigRolloutResult counts the consecutive failures to update the StatefulSet of PD while a config
+// change is pending. The new ConfigMap is created before the StatefulSet is updated, so it's referenced by
+// nothing but the status until the update succeeds, and it's not validated by any running PD. When the update
+// keeps failing, an event pairing the ConfigMap with the error is emitted to let users roll back the config.
+func (m *pdMemberManager) recordPDConfigRolloutResult(tc *v1alpha1.TidbCluster, setName string, err error) {
+	if err == nil || !tc.Status.PD.ConfigUpdatePending {
+		tc.Status.PD.ConfigRolloutFailures = 0
+		return
+	}
+	tc.Status.PD.ConfigRolloutFailures++
+	if tc.Status.PD.ConfigRolloutFailures < pdConfigRolloutFailureThreshold {
+		return
+	}
+	msg := fmt.Sprintf("failed to update statefulset %s to use the new configmap %s for %d times, "+
+		"the config is not validated by any running PD, roll back the config of PD if it's unexpected, error: %v",
+		setName, tc.Status.PD.PendingConfigMapName, tc.Status.PD.ConfigRolloutFailures, err)
+	klog.Warningf("tidbcluster: [%s/%s] %s", tc.GetNamespace(), tc.GetName(), msg)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedRolloutPDConfig", msg)
+}
+
+// getPDBootstrapPodManagementPolicy returns the pod management policy of the PD StatefulSet when
+// `.spec.pd.parallelBootstrap` is enabled. Parallel is only used to create the StatefulSet of a cluster
+// which has no PD members yet, so that the members of a new cluster start at the same time, and
+// OrderedReady is used to recreate the StatefulSet of a bootstrapped cluster. The policy of an existing
+// StatefulSet is immutable, so it's kept as is instead of failing the update.
+func getPDBootstrapPodManagementPolicy(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet) apps.PodManagementPolicyType {
+	if oldSet != nil {
+		if oldSet.Spec.PodManagementPolicy == apps.ParallelPodManagement && len(tc.Status.PD.Members) > 0 {
+			klog.V(4).Infof("tidbcluster: [%s/%s]'s pd statefulset keeps the Parallel pod management policy, "+
+				"delete it with --cascade=orphan to switch to OrderedReady", tc.GetNamespace(), tc.GetName())
+		}
+		return oldSet.Spec.PodManagementPolicy
+	}
+	if len(tc.Status.PD.Members) == 0 {
+		return apps.ParallelPodManagement
+	}
+	return apps.OrderedReadyPodManagement
+}
+
+// syncPDMemberHealthConditions sets the PDMemberHealthy condition of the PD pods according to the health
+// of the PD members in status, so that tools reading pod conditions can act on the health of PD members.
+// The pods of the members which are not in status, e.g. not joined yet, are left untouched.
+func (m *pdMemberManager) syncPDMemberHealthConditions(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	prefix := controller.PDMemberName(tc.GetName()) + "-"
+
+	var errs []error
+	for name, member := range tc.Status.PD.Members {
+		podName := strings.Split(name, ".")[0]
+		if !strings.HasPrefix(podName, prefix) {
+			continue
+		}
+		pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("syncPDMemberHealthConditions: failed to get pod %s/%s, error: %v", ns, podName, err))
+			continue
+		}
+
+		status, reason := corev1.ConditionFalse, "PDMemberUnhealthy"
+		if member.Health {
+			status, reason = corev1.ConditionTrue, "PDMemberHealthy"
+		}
+		if _, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDMemberHealthy); cond != nil && cond.Status == status {
+			continue
+		}
+
+		pod = pod.DeepCopy()
+		newCond := corev1.PodCondition{
+			Type:               v1alpha1.PDMemberHealthy,
+			Status:             status,
+			Reason:             reason,
+			LastTransitionTime: metav1.Now(),
+		}
+		if i, _ := k8s.GetPodCondition(&pod.Status, v1alpha1.PDMemberHealthy); i >= 0 {
+			pod.Status.Conditions[i] = newCond
+		} else {
+			pod.Status.Conditions = append(pod.Status.Conditions, newCond)
+		}
+		if _, err := m.deps.PodControl.UpdatePodStatus(tc, pod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// syncPDFailoverReadyConditions sets the PDFailoverReady condition of the PD pods which have it as a readiness
+// gate. It's set once the PD container of a failover pod has been running for
+// `.spec.pd.failoverReadinessInitialDelaySeconds`, and at once for the other pods, so that the failover pods
+// get a longer initial delay of readiness though the pod template is shared. A true condition is never reverted.
+// The shortest remaining delay of the failover pods is returned to requeue the sync then, 0 if none is waiting.
+func (m *pdMemberManager) syncPDFailoverReadyConditions(tc *v1alpha1.TidbCluster) (time.Duration, error) {
+	ns := tc.GetNamespace()
+	selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+	if err != nil {
+		return 0, err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return 0, fmt.Errorf("syncPDFailoverReadyConditions: failed to list pods for cluster %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+
+	var delay time.Duration
+	if s := tc.Spec.PD.FailoverReadinessInitialDelaySeconds; s != nil {
+		delay = time.Duration(*s) * time.Second
+	}
+	ordinals := tc.PDStsDesiredOrdinals(true)
+
+	var requeueAfter time.Duration
+	var errs []error
+	for _, pod := range pods {
+		if !hasPodReadinessGate(pod, v1alpha1.PDFailoverReady) {
+			continue
+		}
+		_, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDFailoverReady)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		ordinal, err := util.GetOrdinalFromPodName(pod.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("syncPDFailoverReadyConditions: unexpected pod name %q: %v", pod.Name, err))
+			continue
+		}
+
+		status, reason := corev1.ConditionTrue, "NotFailoverPod"
+		if !ordinals.Has(ordinal) {
+			status, reason = corev1.ConditionFalse, "InitialDelayNotElapsed"
+			// the delay starts once the container is running, so the pod not running yet waits the whole delay
+			remaining := delay
+			if startedAt := getPDContainerStartedAt(tc, pod); startedAt != nil {
+				remaining = delay - time.Since(startedAt.Time)
+				if remaining <= 0 {
+					status, reason = corev1.ConditionTrue, "InitialDelayElapsed"
+				}
+			}
+			if remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+				requeueAfter = remaining
+			}
+		}
+		if cond != nil && cond.Status == status {
+			continue
+		}
+
+		pod = pod.DeepCopy()
+		newCond := corev1.PodCondition{
+			Type:               v1alpha1.PDFailoverReady,
+			Status:             status,
+			Reason:             reason,
+			LastTransitionTime: metav1.Now(),
+		}
+		if i, _ := k8s.GetPodCondition(&pod.Status, v1alpha1.PDFailoverReady); i >= 0 {
+			pod.Status.Conditions[i] = newCond
+		} else {
+			pod.Status.Conditions = append(pod.Status.Conditions, newCond)
+		}
+		if _, err := m.deps.PodControl.UpdatePodStatus(tc, pod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return requeueAfter, errorutils.NewAggregate(errs)
+}
+
+// hasPodReadinessGate returns whether the pod has the readiness gate of the condition type
+func hasPodReadinessGate(pod *corev1.Pod, condType corev1.PodConditionType) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == condType {
+			return true
+		}
+	}
+	return false
+}
+
+// getPDContainerStartedAt returns the time when the running PD container of the pod started, nil is
+// returned if it's not running. The container is looked up by .spec.pd.containerName, and by the default
+// name for the pods not rolled out with the custom name yet, like findPDContainer.
+func getPDContainerStartedAt(tc *v1alpha1.TidbCluster, pod *corev1.Pod) *metav1.Time {
+	for _, name := range []string{tc.PDContainerName(), v1alpha1.PDMemberType.String()} {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != name {
+				continue
+			}
+			if status.State.Running == nil {
+				return nil
+			}
+			return &status.State.Running.StartedAt
+		}
+	}
+	return nil
+}
+
+// checkPDStorageShrink warns about a storage request of PD that is smaller than the request of the existing
+// PD data volumes, because PVCs can not be shrunk. The request rather than the capacity is compared, as the
+// capacity may be rounded up by the provisioner. The sync goes on, so that scaling and failover still work.
+func (m *pdMemberManager) checkPDStorageShrink(tc *v1alpha1.TidbCluster) error {
+	quantity, ok := tc.Spec.PD.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+	if err != nil {
+		return err
+	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("checkPDStorageShrink: failed to list pvcs for cluster %s/%s, error: %s", ns, tcName, err)
+	}
+
+	prefix := fmt.Sprintf("%s-%s-", v1alpha1.PDMemberType, controller.PDMemberName(tcName))
+	for _, pvc := range pvcs {
+		if !strings.HasPrefix(pvc.Name, prefix) {
+			continue
+		}
+		current, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !ok {
+			continue
+		}
+		if quantity.Cmp(current) < 0 {
+			msg := fmt.Sprintf("storage request %s of PD is smaller than the request %s of PVC %s, shrinking volumes is not supported",
+				quantity.String(), current.String(), pvc.Name)
+			klog.Warningf("tidbcluster: [%s/%s]'s %s", ns, tcName, msg)
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedShrinkPDStorage", msg)
+			return nil
+		}
+	}
+	return nil
+}
+
+// checkPDVersionTransition rejects the upgrade paths of PD that are not supported, i.e. downgrading to
+// an earlier minor version, or upgrading from a version earlier than v4.0 to v5.0 or later directly.
+// The check is skipped if any of the versions is not semantic versioning compatible, or the annotation
+// `pingcap.com/pd-skip-version-check` of the cluster is "true".
+func (m *pdMemberManager) checkPDVersionTransition(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet) error {
+	if tc.Annotations[label.AnnPDSkipVersionCheck] == "true" {
+		return nil
+	}
+	c := findPDContainer(tc, oldSet)
+	if c == nil {
+		return nil
+	}
+	_, fromVersion := parseImage(c.Image)
+	toVersion := tc.PDVersion()
+	if fromVersion == toVersion {
+		return nil
+	}
+	from, err := semver.NewVersion(fromVersion)
+	if err != nil {
+		return nil
+	}
+	to, err := semver.NewVersion(toVersion)
+	if err != nil {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case to.Major() < from.Major() || (to.Major() == from.Major() && to.Minor() < from.Minor()):
+		reason = "downgrading is not supported"
+	case from.Major() < 4 && to.Major() > 4:
+		reason = "upgrade to v4.0 first"
+	default:
+		return nil
+	}
+	msg := fmt.Sprintf("upgrading PD from %s to %s is not supported, %s, set annotation %s to \"true\" to skip the check",
+		fromVersion, toVersion, reason, label.AnnPDSkipVersionCheck)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedUpgradePD", msg)
+	return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s", tc.GetNamespace(), tc.GetName(), msg)
+}
+
+// pinPDStatefulSetToRevision replaces the pod template of newSet with the one recorded in the
+// ControllerRevision revisionName, and switches newSet to OnDelete, so that the rollout is frozen
+// and pods adopt the pinned revision only when they are deleted manually.
+func (m *pdMemberManager) pinPDStatefulSetToRevision(tc *v1alpha1.TidbCluster, oldSet, newSet *apps.StatefulSet, revisionName string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	revision, err := m.deps.KubeClientset.AppsV1().ControllerRevisions(ns).Get(context.TODO(), revisionName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tidbcluster: [%s/%s]'s pinned pd revision %s does not exist", ns, tcName, revisionName)
+		}
+		return fmt.Errorf("pinPDStatefulSetToRevision: failed to get revision %s for cluster %s/%s, error: %s", revisionName, ns, tcName, err)
+	}
+	if !metav1.IsControlledBy(revision, oldSet) {
+		return fmt.Errorf("tidbcluster: [%s/%s]'s pinned pd revision %s is not controlled by statefulset %s", ns, tcName, revisionName, oldSet.GetName())
+	}
+
+	// the data of a StatefulSet revision is a patch which replaces the pod template
+	var patch struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s]'s pinned pd revision %s is invalid, error: %v", ns, tcName, revisionName, err)
+	}
+
+	klog.Infof("tidbcluster: [%s/%s]'s pd is pinned to revision %s", ns, tcName, revisionName)
+	newSet.Spec.Template = patch.Spec.Template
+	newSet.Spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{
+		Type: apps.OnDeleteStatefulSetStrategyType,
+	}
+	return nil
+}
+
+// shouldRecover checks whether we should perform recovery operation.
+func (m *pdMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
+	if tc.Status.PD.FailureMembers == nil {
+		return false
+	}
+	// If all desired replicas (excluding failover pods) of tidb cluster are
+	// healthy, we can perform our failover recovery operation.
+	// Note that failover pods may fail (e.g. lack of resources) and we don't care
+	// about them because we're going to delete them.
+	for ordinal := range tc.PDStsDesiredOrdinals(true) {
+		name := fmt.Sprintf("%s-%d", controller.PDMemberName(tc.GetName()), ordinal)
+		pod, err := m.deps.PodLister.Pods(tc.Namespace).Get(name)
+		if err != nil {
+			klog.Errorf("pod %s/%s does not exist: %v", tc.Namespace, name, err)
+			return false
 		}
 		if !k8s.IsPodReady(pod) {
 			return false
@@ -307,142 +2019,561 @@ func (m *pdMemberManager) shouldRecover(tc *v1alpha1.TidbCluster) bool {
 				break
 			}
 		}
-		if !ok {
-			return false
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// getStatefulSetUpdatePartition returns the partition of the rolling update of the statefulset,
+// or nil if it's not updated by rolling update or has no partition.
+func getStatefulSetUpdatePartition(set *apps.StatefulSet) *int32 {
+	strategy := set.Spec.UpdateStrategy
+	if strategy.Type == apps.OnDeleteStatefulSetStrategyType || strategy.RollingUpdate == nil || strategy.RollingUpdate.Partition == nil {
+		return nil
+	}
+	partition := *strategy.RollingUpdate.Partition
+	return &partition
+}
+
+func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
+	if set == nil {
+		// skip if not created yet
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	tc.Status.PD.StatefulSet = &set.Status
+	tc.Status.PD.UpdatePartition = getStatefulSetUpdatePartition(set)
+
+	upgrading, err := m.pdStatefulSetIsUpgrading(set, tc)
+	if err != nil {
+		return err
+	}
+
+	prevPhase := tc.Status.PD.Phase
+	// Scaling in takes precedence over upgrading, while scaling out is deferred until the upgrade completes.
+	desiredReplicas := tc.PDStsDesiredReplicas()
+	if desiredReplicas > *set.Spec.Replicas && upgrading {
+		tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	} else if desiredReplicas != *set.Spec.Replicas {
+		tc.Status.PD.Phase = v1alpha1.ScalePhase
+	} else if upgrading {
+		tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	} else {
+		tc.Status.PD.Phase = v1alpha1.NormalPhase
+	}
+	// Hold the upgrade phase after the rollout completes until all members are healthy for enough
+	// consecutive syncs. The count is reset if the status can not be synced this time.
+	stableChecks := tc.Status.PD.UpgradeStableChecks
+	tc.Status.PD.UpgradeStableChecks = 0
+	holdUpgrade := tc.Status.PD.Phase == v1alpha1.NormalPhase && prevPhase == v1alpha1.UpgradePhase &&
+		tc.PDUpgradeStabilizationChecks() > 0
+	if holdUpgrade {
+		tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	}
+
+	// the disk usage is not reported by PD, check it even if PD is unavailable
+	m.syncPDDiskPressureCondition(tc, set)
+
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+
+	healthInfo, err := pdClient.GetHealth()
+
+	if err != nil {
+		tc.Status.PD.Synced = false
+		// get endpoints info
+		eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.PDMemberName(tcName))
+		if epErr != nil {
+			return fmt.Errorf("syncTidbClusterStatus: failed to get endpoints %s for cluster %s/%s, err: %s, epErr %s", controller.PDMemberName(tcName), ns, tcName, err, epErr)
+		}
+		// pd service has no endpoints
+		if eps != nil && len(eps.Subsets) == 0 {
+			return fmt.Errorf("%s, service %s/%s has no endpoints", err, ns, controller.PDMemberName(tcName))
+		}
+		return err
+	}
+
+	cluster, err := pdClient.GetCluster()
+	if err != nil {
+		tc.Status.PD.Synced = false
+		return err
+	}
+	tc.Status.ClusterID = strconv.FormatUint(cluster.Id, 10)
+	leader, err := pdClient.GetPDLeader()
+	if err != nil {
+		tc.Status.PD.Synced = false
+		return err
+	}
+
+	rePDMembers, err := regexp.Compile(fmt.Sprintf(pdMemberLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	if err != nil {
+		return err
+	}
+	pdStatus := map[string]v1alpha1.PDMember{}
+	peerPDStatus := map[string]v1alpha1.PDMember{}
+	for _, memberHealth := range healthInfo.Healths {
+		memberID := memberHealth.MemberID
+		var clientURL string
+		if len(memberHealth.ClientUrls) > 0 {
+			clientURL = memberHealth.ClientUrls[0]
+		}
+		name := memberHealth.Name
+		if len(name) == 0 {
+			klog.Warningf("PD member: [%d] doesn't have a name, and can't get it from clientUrls: [%s], memberHealth Info: [%v] in [%s/%s]",
+				memberID, memberHealth.ClientUrls, memberHealth, ns, tcName)
+			continue
+		}
+
+		status := v1alpha1.PDMember{
+			Name:      name,
+			ID:        fmt.Sprintf("%d", memberID),
+			ClientURL: clientURL,
+			Health:    memberHealth.Health,
+		}
+		now := metav1.Now()
+		status.LastTransitionTime = now
+
+		// matching `rePDMembers` means `clientURL` is a PD in current tc
+		if rePDMembers.Match([]byte(clientURL)) {
+			oldPDMember, exist := tc.Status.PD.Members[name]
+			if exist && status.Health == oldPDMember.Health {
+				status.LastTransitionTime = clampPDMemberTransitionTime(tc, name, oldPDMember.LastTransitionTime, now)
+			}
+			status.Zone = m.getPDMemberZone(tc, name)
+			if status.Zone == "" && exist {
+				// keep the zone recorded before if the node is unavailable now
+				status.Zone = oldPDMember.Zone
+			}
+			pdStatus[name] = status
+		} else {
+			oldPDMember, exist := tc.Status.PD.PeerMembers[name]
+			if exist && status.Health == oldPDMember.Health {
+				status.LastTransitionTime = clampPDMemberTransitionTime(tc, name, oldPDMember.LastTransitionTime, now)
+			}
+			peerPDStatus[name] = status
+		}
+
+		if name == leader.GetName() {
+			tc.Status.PD.Leader = status
+		}
+	}
+
+	tc.Status.PD.Synced = true
+	tc.Status.PD.Members = pdStatus
+	tc.Status.PD.PeerMembers = peerPDStatus
+	recordPDLeaderChange(tc, metav1.Now())
+	if holdUpgrade && tc.PDAllMembersReady() {
+		stableChecks++
+		if stableChecks >= tc.PDUpgradeStabilizationChecks() {
+			klog.Infof("syncTidbClusterStatus: pd members of cluster %s/%s are stable for %d syncs after upgrade", ns, tcName, stableChecks)
+			tc.Status.PD.Phase = v1alpha1.NormalPhase
+		} else {
+			tc.Status.PD.UpgradeStableChecks = stableChecks
+		}
+	}
+	m.syncPDClockSkewCondition(tc)
+	m.syncPDMismatchedClusterIDMembers(tc, cluster.Id)
+
+	if err := m.syncPDLeaderLabel(tc); err != nil {
+		return err
+	}
+	tc.Status.PD.Image = ""
+	if c := findPDContainer(tc, set); c != nil {
+		tc.Status.PD.Image = c.Image
+	}
+
+	if err := m.collectUnjoinedMembers(tc, set, pdStatus); err != nil {
+		return err
+	}
+
+	err = volumes.SyncVolumeStatus(m.podVolumeModifier, m.deps.PodLister, tc, v1alpha1.PDMemberType)
+	if err != nil {
+		return fmt.Errorf("failed to sync volume status for pd: %v", err)
+	}
+	return nil
+}
+
+// recordPDLeaderChange appends the leader in status to `.status.pd.leaderHistory` if it differs from the
+// last recorded one, the oldest changes are dropped once the history exceeds pdLeaderHistoryLimit.
+func recordPDLeaderChange(tc *v1alpha1.TidbCluster, now metav1.Time) {
+	leader := tc.Status.PD.Leader
+	if leader.Name == "" {
+		return
+	}
+	history := tc.Status.PD.LeaderHistory
+	if n := len(history); n > 0 && history[n-1].Name == leader.Name && history[n-1].ID == leader.ID {
+		return
+	}
+	history = append(history, v1alpha1.PDLeaderChange{Name: leader.Name, ID: leader.ID, Time: now})
+	if len(history) > pdLeaderHistoryLimit {
+		history = append([]v1alpha1.PDLeaderChange(nil), history[len(history)-pdLeaderHistoryLimit:]...)
+	}
+	tc.Status.PD.LeaderHistory = history
+}
+
+// syncPDPreferredLeader raises the leader priority of the member specified by .spec.pd.preferredLeader
+// above all other members, and PD transfers the leader to it by itself once the member is healthy.
+// The priority is set only when the member doesn't have the highest priority yet.
+func (m *pdMemberManager) syncPDPreferredLeader(tc *v1alpha1.TidbCluster) error {
+	preferred := tc.Spec.PD.PreferredLeader
+	if preferred == "" {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	// the members may change during scaling
+	if !tc.Status.PD.Synced || tc.PDScaling() {
+		klog.V(4).Infof("tidbcluster: [%s/%s]'s pd is not stable, skip setting the leader priority of the preferred member %s", ns, tcName, preferred)
+		return nil
+	}
+
+	member, ok := getPDPreferredLeaderMember(tc)
+	if !ok {
+		klog.Warningf("tidbcluster: [%s/%s]'s preferred pd leader %s is not a member of pd", ns, tcName, preferred)
+		return nil
+	}
+
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	membersInfo, err := pdClient.GetMembers()
+	if err != nil {
+		return err
+	}
+	var current, highest int32
+	for _, mem := range membersInfo.Members {
+		if mem.GetName() == member.Name {
+			current = mem.GetLeaderPriority()
+		} else if mem.GetLeaderPriority() > highest {
+			highest = mem.GetLeaderPriority()
+		}
+	}
+	if current > highest {
+		return nil
+	}
+
+	priority := int(highest) + 1
+	if err := pdClient.SetMemberLeaderPriority(member.Name, priority); err != nil {
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, "FailedSetPDLeaderPriority", "failed to set leader priority of the preferred pd member %s to %d: %v", member.Name, priority, err)
+		return err
+	}
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "SetPDLeaderPriority", "set leader priority of the preferred pd member %s to %d", member.Name, priority)
+	return nil
+}
+
+// getPDPreferredLeaderMember returns the PD member specified by .spec.pd.preferredLeader,
+// which is either the member name or the ordinal of the pod.
+func getPDPreferredLeaderMember(tc *v1alpha1.TidbCluster) (v1alpha1.PDMember, bool) {
+	preferred := tc.Spec.PD.PreferredLeader
+	names := []string{preferred}
+	if ordinal, err := strconv.ParseInt(preferred, 10, 32); err == nil {
+		names = []string{
+			PdName(tc.Name, int32(ordinal), tc.Namespace, tc.Spec.ClusterDomain, tc.Spec.AcrossK8s),
+			PdPodName(tc.Name, int32(ordinal)),
+		}
+	}
+	for _, name := range names {
+		if member, ok := tc.Status.PD.Members[name]; ok {
+			return member, true
+		}
+		if member, ok := tc.Status.PD.PeerMembers[name]; ok {
+			return member, true
 		}
 	}
-	return true
+	return v1alpha1.PDMember{}, false
 }
 
-func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
-	if set == nil {
-		// skip if not created yet
-		return nil
+// getPDMemberZone returns the zone of the node where the pod of the PD member is scheduled,
+// an empty string is returned if it's unknown.
+func (m *pdMemberManager) getPDMemberZone(tc *v1alpha1.TidbCluster, name string) string {
+	if m.deps.NodeLister == nil {
+		return ""
+	}
+	ns := tc.GetNamespace()
+	podName := strings.Split(name, ".")[0]
+	pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+	if err != nil {
+		klog.V(4).Infof("getPDMemberZone: failed to get pod %s/%s, error: %s", ns, podName, err)
+		return ""
+	}
+	if pod.Spec.NodeName == "" {
+		return ""
+	}
+	labels, err := getNodeLabels(m.deps.NodeLister, pod.Spec.NodeName, []string{pdZoneLabel})
+	if err != nil {
+		klog.V(4).Infof("getPDMemberZone: failed to get node %s of pod %s/%s, error: %s", pod.Spec.NodeName, ns, podName, err)
+		return ""
+	}
+	return labels[pdZoneLabel]
+}
+
+// clampPDMemberTransitionTime returns now if the stored transition time of the PD member is in the future,
+// which may be caused by clock skew, to avoid misleading the logic based on it, e.g. the failover period.
+func clampPDMemberTransitionTime(tc *v1alpha1.TidbCluster, name string, lastTransitionTime, now metav1.Time) metav1.Time {
+	if lastTransitionTime.After(now.Time) {
+		klog.Warningf("tidbcluster: [%s/%s]'s pd member %s has a future LastTransitionTime %s, clamp it to %s, the clocks may be skewed",
+			tc.GetNamespace(), tc.GetName(), name, lastTransitionTime.Format(time.RFC3339), now.Format(time.RFC3339))
+		return now
+	}
+	return lastTransitionTime
+}
+
+// syncPDClockSkewCondition sets the PDClockSkew condition if the clock offset of any healthy PD member
+// exceeds `.spec.pd.clockSkewThreshold`. It is best-effort, the condition is left untouched if the
+// offset of no member can be fetched.
+func (m *pdMemberManager) syncPDClockSkewCondition(tc *v1alpha1.TidbCluster) {
+	if tc.Spec.PD.ClockSkewThreshold == nil {
+		tc.Status.PD.RemoveCondition(v1alpha1.PDClockSkew)
+		return
+	}
+	threshold, err := time.ParseDuration(*tc.Spec.PD.ClockSkewThreshold)
+	if err != nil || threshold <= 0 {
+		tc.Status.PD.RemoveCondition(v1alpha1.PDClockSkew)
+		return
 	}
 
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
+	names := make([]string, 0, len(tc.Status.PD.Members))
+	for name := range tc.Status.PD.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	tc.Status.PD.StatefulSet = &set.Status
+	checked := 0
+	var offending []string
+	for _, name := range names {
+		member := tc.Status.PD.Members[name]
+		if !member.Health || member.ClientURL == "" {
+			continue
+		}
+		pdClient := m.deps.PDControl.GetPDClient(pdapi.Namespace(ns), tcName, tc.IsTLSClusterEnabled(), pdapi.SpecifyClient(member.ClientURL, member.Name))
+		offset, err := pdClient.GetClockOffset()
+		if err != nil {
+			klog.Warningf("syncPDClockSkewCondition: failed to get clock offset of pd member %s of cluster %s/%s, error: %v", name, ns, tcName, err)
+			continue
+		}
+		checked++
 
-	upgrading, err := m.pdStatefulSetIsUpgrading(set, tc)
-	if err != nil {
-		return err
+		if offset > threshold || offset < -threshold {
+			offending = append(offending, fmt.Sprintf("%s(%s)", name, offset.Round(time.Millisecond)))
+		}
 	}
 
-	// Scaling takes precedence over upgrading.
-	if tc.PDStsDesiredReplicas() != *set.Spec.Replicas {
-		tc.Status.PD.Phase = v1alpha1.ScalePhase
-	} else if upgrading {
-		tc.Status.PD.Phase = v1alpha1.UpgradePhase
-	} else {
-		tc.Status.PD.Phase = v1alpha1.NormalPhase
+	if checked == 0 {
+		klog.Warningf("syncPDClockSkewCondition: clock offset of no pd member of cluster %s/%s is available", ns, tcName)
+		return
 	}
 
-	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	condition := metav1.Condition{
+		Type:    v1alpha1.PDClockSkew,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ClockInSync",
+		Message: fmt.Sprintf("clock offset of all pd members is within %s", threshold),
+	}
+	if len(offending) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ClockSkewDetected"
+		condition.Message = fmt.Sprintf("clock offset of pd members exceeds %s: %s", threshold, strings.Join(offending, ", "))
+	}
+	tc.Status.PD.SetCondition(condition)
+}
 
-	healthInfo, err := pdClient.GetHealth()
+// syncPDMismatchedClusterIDMembers records the healthy PD members reporting a cluster ID different from the
+// one of the cluster, which indicates a serious misconfiguration, e.g. a member bootstrapped a new cluster.
+// A Warning event is emitted when a member is found mismatched, the members whose cluster ID can't be
+// fetched are kept as before.
+func (m *pdMemberManager) syncPDMismatchedClusterIDMembers(tc *v1alpha1.TidbCluster, clusterID uint64) {
+	if tc.Spec.PD.CheckMemberClusterID == nil || !*tc.Spec.PD.CheckMemberClusterID {
+		tc.Status.PD.MismatchedClusterIDMembers = nil
+		return
+	}
 
-	if err != nil {
-		tc.Status.PD.Synced = false
-		// get endpoints info
-		eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.PDMemberName(tcName))
-		if epErr != nil {
-			return fmt.Errorf("syncTidbClusterStatus: failed to get endpoints %s for cluster %s/%s, err: %s, epErr %s", controller.PDMemberName(tcName), ns, tcName, err, epErr)
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	names := make([]string, 0, len(tc.Status.PD.Members))
+	for name := range tc.Status.PD.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mismatched := map[string]string{}
+	for _, name := range names {
+		member := tc.Status.PD.Members[name]
+		if !member.Health || member.ClientURL == "" {
+			continue
 		}
-		// pd service has no endpoints
-		if eps != nil && len(eps.Subsets) == 0 {
-			return fmt.Errorf("%s, service %s/%s has no endpoints", err, ns, controller.PDMemberName(tcName))
+		pdClient := m.deps.PDControl.GetPDClient(pdapi.Namespace(ns), tcName, tc.IsTLSClusterEnabled(), pdapi.SpecifyClient(member.ClientURL, member.Name))
+		cluster, err := pdClient.GetCluster()
+		if err != nil {
+			klog.Warningf("syncPDMismatchedClusterIDMembers: failed to get cluster info of pd member %s of cluster %s/%s, error: %v", name, ns, tcName, err)
+			if id, ok := tc.Status.PD.MismatchedClusterIDMembers[name]; ok {
+				mismatched[name] = id
+			}
+			continue
 		}
-		return err
+		if cluster.Id == clusterID {
+			continue
+		}
+
+		id := strconv.FormatUint(cluster.Id, 10)
+		msg := fmt.Sprintf("pd member %s reports cluster id %s, which is different from the cluster id %d", name, id, clusterID)
+		klog.Warningf("syncPDMismatchedClusterIDMembers: cluster %s/%s %s", ns, tcName, msg)
+		if tc.Status.PD.MismatchedClusterIDMembers[name] != id {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDClusterIDMismatch", msg)
+		}
+		mismatched[name] = id
 	}
+	if len(mismatched) == 0 {
+		mismatched = nil
+	}
+	tc.Status.PD.MismatchedClusterIDMembers = mismatched
+}
 
-	cluster, err := pdClient.GetCluster()
-	if err != nil {
-		tc.Status.PD.Synced = false
-		return err
+// syncPDLeaderLabel labels the current PD leader pod so that the PD leader service only targets it,
+// and removes the label from the pods that are no longer the leader.
+func (m *pdMemberManager) syncPDLeaderLabel(tc *v1alpha1.TidbCluster) error {
+	if !tc.PDLeaderServiceEnabled() {
+		return nil
 	}
-	tc.Status.ClusterID = strconv.FormatUint(cluster.Id, 10)
-	leader, err := pdClient.GetPDLeader()
+
+	ns := tc.GetNamespace()
+	selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
 	if err != nil {
-		tc.Status.PD.Synced = false
 		return err
 	}
-
-	rePDMembers, err := regexp.Compile(fmt.Sprintf(pdMemberLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
 	if err != nil {
-		return err
+		return fmt.Errorf("syncPDLeaderLabel: failed to list pods for cluster %s/%s, error: %s", ns, tc.GetName(), err)
 	}
-	pdStatus := map[string]v1alpha1.PDMember{}
-	peerPDStatus := map[string]v1alpha1.PDMember{}
-	for _, memberHealth := range healthInfo.Healths {
-		memberID := memberHealth.MemberID
-		var clientURL string
-		if len(memberHealth.ClientUrls) > 0 {
-			clientURL = memberHealth.ClientUrls[0]
+
+	leaderPodName := getPDLeaderPodName(tc)
+	var leaderPod *corev1.Pod
+	// remove the label from the old leader first, so that at most one pod is targeted by the service
+	for _, pod := range pods {
+		if leaderPodName != "" && pod.Name == leaderPodName {
+			leaderPod = pod
+			continue
 		}
-		name := memberHealth.Name
-		if len(name) == 0 {
-			klog.Warningf("PD member: [%d] doesn't have a name, and can't get it from clientUrls: [%s], memberHealth Info: [%v] in [%s/%s]",
-				memberID, memberHealth.ClientUrls, memberHealth, ns, tcName)
+		if _, ok := pod.Labels[label.PDLeaderLabelKey]; !ok {
 			continue
 		}
-
-		status := v1alpha1.PDMember{
-			Name:      name,
-			ID:        fmt.Sprintf("%d", memberID),
-			ClientURL: clientURL,
-			Health:    memberHealth.Health,
+		newPod := pod.DeepCopy()
+		delete(newPod.Labels, label.PDLeaderLabelKey)
+		if _, err := m.deps.PodControl.UpdatePod(tc, newPod); err != nil {
+			return fmt.Errorf("syncPDLeaderLabel: failed to remove leader label from pod %s/%s, error: %s", ns, pod.Name, err)
 		}
-		status.LastTransitionTime = metav1.Now()
+	}
 
-		// matching `rePDMembers` means `clientURL` is a PD in current tc
-		if rePDMembers.Match([]byte(clientURL)) {
-			oldPDMember, exist := tc.Status.PD.Members[name]
-			if exist && status.Health == oldPDMember.Health {
-				status.LastTransitionTime = oldPDMember.LastTransitionTime
-			}
-			pdStatus[name] = status
-		} else {
-			oldPDMember, exist := tc.Status.PD.PeerMembers[name]
-			if exist && status.Health == oldPDMember.Health {
-				status.LastTransitionTime = oldPDMember.LastTransitionTime
-			}
-			peerPDStatus[name] = status
-		}
+	if leaderPod == nil || leaderPod.Labels[label.PDLeaderLabelKey] == "true" {
+		return nil
+	}
+	newPod := leaderPod.DeepCopy()
+	if newPod.Labels == nil {
+		newPod.Labels = map[string]string{}
+	}
+	newPod.Labels[label.PDLeaderLabelKey] = "true"
+	if _, err := m.deps.PodControl.UpdatePod(tc, newPod); err != nil {
+		return fmt.Errorf("syncPDLeaderLabel: failed to add leader label to pod %s/%s, error: %s", ns, leaderPod.Name, err)
+	}
+	return nil
+}
 
-		if name == leader.GetName() {
-			tc.Status.PD.Leader = status
-		}
+// getPDLeaderPodName returns the name of the pod of the PD leader in the current tc,
+// an empty string is returned if the leader is unknown or it belongs to the peer clusters.
+func getPDLeaderPodName(tc *v1alpha1.TidbCluster) string {
+	leaderName := tc.Status.PD.Leader.Name
+	if leaderName == "" {
+		return ""
+	}
+	if _, ok := tc.Status.PD.PeerMembers[leaderName]; ok {
+		return ""
 	}
+	// the member name is the FQDN of the pod if the cluster domain is set
+	return strings.Split(leaderName, ".")[0]
+}
 
-	tc.Status.PD.Synced = true
-	tc.Status.PD.Members = pdStatus
-	tc.Status.PD.PeerMembers = peerPDStatus
-	tc.Status.PD.Image = ""
-	if c := findContainerByName(set, "pd"); c != nil {
-		tc.Status.PD.Image = c.Image
+// getPDConfig returns the configuration of PD, which is .pd.config or the content of the ConfigMap key
+// referenced by .pd.configFrom. Every reader of the PD configuration should get it from here so that
+// both sources are honored. nil is returned if neither is set.
+func (m *pdMemberManager) getPDConfig(tc *v1alpha1.TidbCluster) (*v1alpha1.PDConfigWraper, error) {
+	ref := tc.Spec.PD.ConfigFrom
+	if ref == nil {
+		return tc.Spec.PD.Config, nil
 	}
 
-	if err := m.collectUnjoinedMembers(tc, set, pdStatus); err != nil {
-		return err
+	ns := tc.GetNamespace()
+	optional := ref.Optional != nil && *ref.Optional
+	config := v1alpha1.NewPDConfig()
+
+	cm, err := m.deps.UserConfigMapLister.ConfigMaps(ns).Get(ref.Name)
+	if err != nil {
+		if errors.IsNotFound(err) && optional {
+			return config, nil
+		}
+		return nil, fmt.Errorf("tidbcluster: [%s/%s]'s pd configFrom: failed to get configmap %s, error: %v", ns, tc.GetName(), ref.Name, err)
+	}
+	data, ok := cm.Data[ref.Key]
+	if !ok {
+		if optional {
+			return config, nil
+		}
+		return nil, fmt.Errorf("tidbcluster: [%s/%s]'s pd configFrom: key %s does not exist in configmap %s", ns, tc.GetName(), ref.Key, ref.Name)
 	}
+	if err := config.UnmarshalTOML([]byte(data)); err != nil {
+		return nil, fmt.Errorf("tidbcluster: [%s/%s]'s pd configFrom: failed to parse key %s of configmap %s, error: %v", ns, tc.GetName(), ref.Key, ref.Name, err)
+	}
+	return config, nil
+}
 
-	err = volumes.SyncVolumeStatus(m.podVolumeModifier, m.deps.PodLister, tc, v1alpha1.PDMemberType)
+// getPDConfigSource returns the TidbCluster whose .pd.config is the configuration returned by getPDConfig,
+// it's a copy if the configuration is referenced by .pd.configFrom so that the spec is not updated.
+func (m *pdMemberManager) getPDConfigSource(tc *v1alpha1.TidbCluster) (*v1alpha1.TidbCluster, error) {
+	if tc.Spec.PD.ConfigFrom == nil {
+		return tc, nil
+	}
+	config, err := m.getPDConfig(tc)
 	if err != nil {
-		return fmt.Errorf("failed to sync volume status for pd: %v", err)
+		return nil, err
 	}
-	return nil
+	configSource := tc.DeepCopy()
+	configSource.Spec.PD.Config = config
+	return configSource, nil
 }
 
 // syncPDConfigMap syncs the configmap of PD
 func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
-	// For backward compatibility, only sync tidb configmap when .pd.config is non-nil
-	if tc.Spec.PD.Config == nil {
-		return nil, nil
+	// For backward compatibility, only sync tidb configmap when .pd.config or .pd.configFrom is non-nil
+	if tc.Spec.PD.Config == nil && tc.Spec.PD.ConfigFrom == nil {
+		tc.Status.PD.ConfigUpdatePending = false
+		tc.Status.PD.PendingConfigMapName = ""
+		return nil, m.syncPDEffectiveConfigMap(tc, nil)
+	}
+	configSource, err := m.getPDConfigSource(tc)
+	if err != nil {
+		return nil, err
 	}
-	newCm, err := getPDConfigMap(tc)
+	if conflicts := getPDClusterTLSConfigConflicts(configSource); len(conflicts) > 0 {
+		msg := fmt.Sprintf("config %s of pd conflicts with the paths of the cluster TLS certificates mounted by the operator, it's overridden", strings.Join(conflicts, ", "))
+		klog.Warningf("tidbcluster: [%s/%s] %s", tc.GetNamespace(), tc.GetName(), msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDConfigConflict", msg)
+	}
+	if isPDGRPCGatewayDisabled(configSource) {
+		msg := "the grpc gateway of pd is disabled by enable-grpc-gateway, the health checks of the operator via the HTTP API of pd may fail"
+		klog.Warningf("tidbcluster: [%s/%s] %s", tc.GetNamespace(), tc.GetName(), msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDGRPCGatewayDisabled", msg)
+	}
+	newCm, err := getPDConfigMap(configSource)
 	if err != nil {
+		if isPDConfigSchemaError(err) {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDConfigSchemaInvalid", err.Error())
+		}
+		return nil, err
+	}
+	if err := m.syncPDEffectiveConfigMap(tc, newCm); err != nil {
 		return nil, err
 	}
 
@@ -462,9 +2593,87 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	if err != nil {
 		return nil, err
 	}
+	// the StatefulSet switches to the new ConfigMap when it's updated, and the config change is pending until
+	// all PD pods are recreated at the updated revision. The ConfigMap updated in place is never pending because
+	// the name doesn't change.
+	if set != nil && inUseName != "" && inUseName != newCm.Name {
+		tc.Status.PD.ConfigUpdatePending = true
+		tc.Status.PD.PendingConfigMapName = newCm.Name
+	} else if tc.Status.PD.ConfigUpdatePending && set != nil && !isPDConfigRolledOut(set) {
+		klog.V(4).Infof("tidbcluster: [%s/%s]'s pd config %s is not rolled out to all pods yet", tc.GetNamespace(), tc.GetName(), tc.Status.PD.PendingConfigMapName)
+	} else {
+		tc.Status.PD.ConfigUpdatePending = false
+		tc.Status.PD.PendingConfigMapName = ""
+	}
+	if m.deps.CLIConfig.PDServerSideApply {
+		return m.applyPDConfigMap(tc, newCm)
+	}
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// isPDConfigRolledOut returns whether all pods of the StatefulSet of PD are updated to its current revision
+func isPDConfigRolledOut(set *apps.StatefulSet) bool {
+	return !mngerutils.StatefulSetIsUpgrading(set) && set.Status.UpdatedReplicas == set.Status.Replicas
+}
+
+// applyPDConfigMap applies the ConfigMap of PD with server-side apply, nothing is applied if it's not changed
+func (m *pdMemberManager) applyPDConfigMap(tc *v1alpha1.TidbCluster, newCm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	ac, err := controller.NewConfigMapApplyConfiguration(newCm)
+	if err != nil {
+		return nil, err
+	}
+	oldCm, err := m.deps.ConfigMapLister.ConfigMaps(newCm.Namespace).Get(newCm.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("applyPDConfigMap: failed to get configmap %s for cluster %s/%s, error: %s", newCm.Name, tc.GetNamespace(), tc.GetName(), err)
+	}
+	if err == nil {
+		applied, err := controller.ConfigMapApplied(ac, oldCm)
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			return oldCm.DeepCopy(), nil
+		}
+	}
+	return m.deps.ConfigMapControl.ApplyConfigMap(tc, ac)
+}
+
+// syncPDEffectiveConfigMap exports the config file rendered for PD to a ConfigMap for debugging,
+// and deletes the ConfigMap when the export is disabled or there is no config file rendered.
+func (m *pdMemberManager) syncPDEffectiveConfigMap(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) error {
+	ns := tc.GetNamespace()
+	name := controller.PDEffectiveConfigMapName(tc.GetName())
+
+	if cm == nil || !tc.PDExportEffectiveConfigEnabled() {
+		existing, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(name)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("syncPDEffectiveConfigMap: failed to get configmap %s for cluster %s/%s, error: %s", name, ns, tc.GetName(), err)
+		}
+		// never delete the configmap not created by the operator
+		if !metav1.IsControlledBy(existing, tc) {
+			return nil
+		}
+		return m.deps.TypedControl.Delete(tc, existing.DeepCopy())
+	}
+
+	effectiveCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       ns,
+			Labels:          label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: map[string]string{
+			"config-file": cm.Data["config-file"],
+		},
+	}
+	_, err := m.deps.TypedControl.CreateOrUpdateConfigMap(tc, effectiveCm)
+	return err
+}
+
 func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
@@ -511,9 +2720,29 @@ func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster
 		if svcSpec.PortName != nil {
 			pdService.Spec.Ports[0].Name = *svcSpec.PortName
 		}
+		if svcSpec.ExternalIPs != nil {
+			pdService.Spec.ExternalIPs = svcSpec.ExternalIPs
+		}
+		if svcSpec.InternalTrafficPolicy != nil {
+			pdService.Spec.InternalTrafficPolicy = svcSpec.InternalTrafficPolicy
+		}
+	}
+
+	// the annotations set by users take precedence, both the annotations of the new and the deprecated
+	// topology aware routing are set to support Kubernetes before and since v1.27
+	if tc.PDTopologyAwareRoutingEnabled() {
+		if pdService.Annotations == nil {
+			pdService.Annotations = map[string]string{}
+		}
+		if _, ok := pdService.Annotations[label.AnnServiceTopologyMode]; !ok {
+			pdService.Annotations[label.AnnServiceTopologyMode] = "Auto"
+		}
+		if _, ok := pdService.Annotations[label.AnnServiceTopologyAwareHints]; !ok {
+			pdService.Annotations[label.AnnServiceTopologyAwareHints] = "auto"
+		}
 	}
 
-	if tc.Spec.PreferIPv6 {
+	if tc.PDPreferIPv6() {
 		SetServiceWhenPreferIPv6(pdService)
 	}
 
@@ -556,7 +2785,83 @@ func getNewPDHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Ser
 		},
 	}
 
-	if tc.Spec.PreferIPv6 {
+	if tc.PDPreferIPv6() {
+		SetServiceWhenPreferIPv6(svc)
+	}
+
+	return svc
+}
+
+func getNewPDLeaderServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
+	ns := tc.Namespace
+	tcName := tc.Name
+	svcName := controller.PDLeaderMemberName(tcName)
+	instanceName := tc.GetInstanceName()
+	pdSelector := label.New().Instance(instanceName).PD()
+	leaderSelector := pdSelector.Copy()
+	leaderSelector[label.PDLeaderLabelKey] = "true"
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            svcName,
+			Namespace:       ns,
+			Labels:          pdSelector.Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "client",
+					Port:       v1alpha1.DefaultPDClientPort,
+					TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: leaderSelector.Labels(),
+		},
+	}
+
+	if tc.PDPreferIPv6() {
+		SetServiceWhenPreferIPv6(svc)
+	}
+
+	return svc
+}
+
+// getNewPDPprofServiceForTidbCluster returns the internal-only service for pprof of PD, which forwards
+// `.spec.pd.pprofPort` to the client port where PD serves pprof. It's always a ClusterIP service regardless
+// of the service type of PD.
+func getNewPDPprofServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
+	ns := tc.Namespace
+	tcName := tc.Name
+	svcName := controller.PDPprofMemberName(tcName)
+	instanceName := tc.GetInstanceName()
+	pdSelector := label.New().Instance(instanceName).PD()
+	port := *tc.Spec.PD.PprofPort
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            svcName,
+			Namespace:       ns,
+			Labels:          pdSelector.Copy().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "pprof",
+					Port:       port,
+					TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: pdSelector.Labels(),
+		},
+	}
+
+	if tc.PDPreferIPv6() {
 		SetServiceWhenPreferIPv6(svc)
 	}
 
@@ -612,6 +2917,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 		annMount,
 		{Name: "config", ReadOnly: true, MountPath: "/etc/pd"},
 		{Name: "startup-script", ReadOnly: true, MountPath: "/usr/local/bin"},
+		{Name: pdForceNewClusterVolumeName, ReadOnly: true, MountPath: pdForceNewClusterPath},
 		{Name: dataVolumeName, MountPath: constants.PDDataVolumeMountPath},
 	}
 	if tc.IsTLSClusterEnabled() {
@@ -624,9 +2930,14 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 			})
 		}
 	}
-	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB() && clusterVersionGE4 {
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB() && clusterVersionGE4 {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: "tidb-client-tls", ReadOnly: true, MountPath: tidbClientCertPath,
+		})
+	}
+	if useProjectedDashboardTiDBCA(tc, clusterVersionGE4) {
 		volMounts = append(volMounts, corev1.VolumeMount{
-			Name: "tidb-client-tls", ReadOnly: true, MountPath: tidbClientCertPath,
+			Name: "tidb-client-ca", ReadOnly: true, MountPath: tidbClientCAPath,
 		})
 	}
 
@@ -652,6 +2963,20 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 				},
 			},
 		},
+		// the force new cluster request exists only while PD is recovered from the loss of quorum,
+		// it's always mounted because the StatefulSet can't be rolled out to add it at that time.
+		// Note that adding it changes the pod template, so the PD pods of the existing clusters are
+		// rolled once when the operator is upgraded to the version mounting it.
+		{Name: pdForceNewClusterVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: controller.PDForceNewClusterConfigMapName(tcName),
+					},
+					Optional: pointer.BoolPtr(true),
+				},
+			},
+		},
 	}
 	if tc.IsTLSClusterEnabled() {
 		vols = append(vols, corev1.Volume{
@@ -680,6 +3005,49 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 			},
 		})
 	}
+	if useProjectedDashboardTiDBCA(tc, clusterVersionGE4) {
+		vols = append(vols, corev1.Volume{
+			Name: "tidb-client-ca", VolumeSource: corev1.VolumeSource{
+				Projected: tc.Spec.PD.DashboardTiDBCAProjected.DeepCopy(),
+			},
+		})
+	}
+	if tc.Spec.PD.ProjectedVolumeMounts != nil && *tc.Spec.PD.ProjectedVolumeMounts {
+		vols, volMounts = projectPDVolumes(vols, volMounts)
+	}
+	var pdLogVolumeMount corev1.VolumeMount
+	if tc.Spec.PD.LogTailer != nil {
+		// mount a shared volume for the PD log, which is tailed to STDOUT by a sidecar.
+		pdLogVolumeMount = corev1.VolumeMount{Name: pdLogVolumeName, MountPath: pdLogDir}
+		volMounts = append(volMounts, pdLogVolumeMount)
+		vols = append(vols, corev1.Volume{
+			Name: pdLogVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	if audience := tc.Spec.PD.ServiceAccountTokenAudience; audience != nil {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: pdSATokenVolumeName, ReadOnly: true, MountPath: pdSATokenMountPath,
+		})
+		vols = append(vols, corev1.Volume{
+			Name: pdSATokenVolumeName, VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          *audience,
+							ExpirationSeconds: pointer.Int64Ptr(pdSATokenExpirationSeconds),
+							Path:              pdSATokenPath,
+						},
+					}},
+				},
+			},
+		})
+	}
+	hugePagesVols, hugePagesVolMounts := getPDHugePagesVolumes(tc)
+	vols = append(vols, hugePagesVols...)
+	volMounts = append(volMounts, hugePagesVolMounts...)
 	// handle StorageVolumes and AdditionalVolumeMounts in ComponentSpec
 	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.PD.StorageVolumes, tc.Spec.PD.StorageClassName, v1alpha1.PDMemberType)
 	volMounts = append(volMounts, storageVolMounts...)
@@ -723,6 +3091,26 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	if len(initContainers) > 0 {
 		podSecurityContext.Sysctls = []corev1.Sysctl{}
 	}
+	// fsGroupChangePolicy only makes sense when fsGroup is set, and the one
+	// set explicitly in podSecurityContext always takes precedence
+	if podSecurityContext != nil && podSecurityContext.FSGroup != nil && podSecurityContext.FSGroupChangePolicy == nil {
+		podSecurityContext.FSGroupChangePolicy = tc.PDFSGroupChangePolicy()
+	}
+	// the seccomp profile set explicitly in podSecurityContext takes precedence
+	if tc.Spec.PD.SeccompProfile != nil {
+		if podSecurityContext == nil {
+			podSecurityContext = &corev1.PodSecurityContext{}
+		}
+		if podSecurityContext.SeccompProfile == nil {
+			podSecurityContext.SeccompProfile = tc.Spec.PD.SeccompProfile.DeepCopy()
+		}
+	}
+	if tc.PDWaitForDNSEnabled() {
+		initContainers = append(initContainers, getPDWaitForDNSContainer(tc))
+	}
+	if tc.PDCheckVolumeWritableEnabled() {
+		initContainers = append(initContainers, getPDCheckVolumeWritableContainer(tc))
+	}
 
 	storageRequest, err := controller.ParseStorageRequest(tc.Spec.PD.Requests)
 	if err != nil {
@@ -732,8 +3120,11 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	setName := controller.PDMemberName(tcName)
 	stsLabels := label.New().Instance(instanceName).PD()
 	podLabels := util.CombineStringMap(stsLabels, basePDSpec.Labels())
-	podAnnotations := util.CombineStringMap(basePDSpec.Annotations(), controller.AnnProm(v1alpha1.DefaultPDClientPort, "/metrics"))
+	podAnnotations := getPDPodAnnotations(tc, basePDSpec)
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.PDLabelVal)
+	if summary := getPDResourceSummary(tc); summary != "" {
+		stsAnnotations[label.AnnPDResourceSummary] = summary
+	}
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
 	if err != nil {
@@ -741,10 +3132,10 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	}
 
 	pdContainer := corev1.Container{
-		Name:            v1alpha1.PDMemberType.String(),
+		Name:            tc.PDContainerName(),
 		Image:           tc.PDImage(),
 		ImagePullPolicy: basePDSpec.ImagePullPolicy(),
-		Command:         []string{"/bin/sh", "/usr/local/bin/pd_start_script.sh"},
+		Command:         []string{"/bin/sh", path.Join(getPDVolumePath(tc, "startup-script", "/usr/local/bin"), "pd_start_script.sh")},
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "server",
@@ -775,6 +3166,31 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 		if tc.Spec.PD.ReadinessProbe.PeriodSeconds != nil {
 			pdContainer.ReadinessProbe.PeriodSeconds = *tc.Spec.PD.ReadinessProbe.PeriodSeconds
 		}
+		if tc.Spec.PD.ReadinessProbe.TimeoutSeconds != nil {
+			pdContainer.ReadinessProbe.TimeoutSeconds = *tc.Spec.PD.ReadinessProbe.TimeoutSeconds
+		}
+	}
+
+	if tc.Spec.PD.PostStartWaitForJoin != nil && *tc.Spec.PD.PostStartWaitForJoin {
+		pdContainer.Lifecycle = &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: buildPDWaitForJoinCommand(tc),
+				},
+			},
+		}
+	}
+	// flushing the metrics is the last step of the preStop hook to include the effect of the other steps
+	if tc.Spec.PD.FlushMetricsOnShutdown != nil {
+		appendPDPreStopScript(&pdContainer, buildPDFlushMetricsScript(tc))
+	}
+
+	// container-level user and group compose with the pod security context, they only override it for the PD container
+	if tc.Spec.PD.RunAsUser != nil || tc.Spec.PD.RunAsGroup != nil {
+		pdContainer.SecurityContext = &corev1.SecurityContext{
+			RunAsUser:  tc.Spec.PD.RunAsUser,
+			RunAsGroup: tc.Spec.PD.RunAsGroup,
+		}
 	}
 
 	env := []corev1.EnvVar{
@@ -818,7 +3234,23 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	pdContainer.Env = util.AppendEnv(env, basePDSpec.Env())
 	pdContainer.EnvFrom = basePDSpec.EnvFrom()
 	podSpec.Volumes = append(vols, basePDSpec.AdditionalVolumes()...)
-	podSpec.Containers, err = MergePatchContainers([]corev1.Container{pdContainer}, basePDSpec.AdditionalContainers())
+	containers := []corev1.Container{pdContainer}
+	if tc.Spec.PD.LogTailer != nil {
+		logFile := path.Join(pdLogDir, pdLogFile)
+		containers = append(containers, corev1.Container{
+			Name:            v1alpha1.ContainerPDLogTailer.String(),
+			Image:           tc.HelperImage(),
+			ImagePullPolicy: tc.HelperImagePullPolicy(),
+			Resources:       controller.ContainerResource(tc.Spec.PD.LogTailer.ResourceRequirements),
+			VolumeMounts:    []corev1.VolumeMount{pdLogVolumeMount},
+			Command: []string{
+				"sh",
+				"-c",
+				fmt.Sprintf("touch %s; tail -n0 -F %s;", logFile, logFile),
+			},
+		})
+	}
+	podSpec.Containers, err = MergePatchContainers(containers, basePDSpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for PD of [%s/%s], error: %v", tc.Namespace, tc.Name, err)
 	}
@@ -829,6 +3261,19 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	}
 	podSpec.SecurityContext = podSecurityContext
 	podSpec.InitContainers = append(initContainers, basePDSpec.InitContainers()...)
+	podSpec.RuntimeClassName = tc.Spec.PD.RuntimeClassName
+	if len(tc.Spec.PD.Overhead) > 0 {
+		podSpec.Overhead = tc.Spec.PD.Overhead.DeepCopy()
+	}
+	if mt := tc.Spec.PD.MaintenanceToleration; mt != nil {
+		// copy the tolerations to not modify the ones in the spec
+		podSpec.Tolerations = append(append([]corev1.Toleration{}, podSpec.Tolerations...), getPDMaintenanceToleration(mt))
+	}
+	// the probes of a pod are immutable, so the longer initial delay of readiness of the failover pods
+	// is implemented by a readiness gate
+	if tc.Spec.PD.FailoverReadinessInitialDelaySeconds != nil {
+		podSpec.ReadinessGates = append(podSpec.ReadinessGates, corev1.PodReadinessGate{ConditionType: v1alpha1.PDFailoverReady})
+	}
 
 	updateStrategy := apps.StatefulSetUpdateStrategy{}
 	if tc.Status.PD.VolReplaceInProgress {
@@ -838,7 +3283,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	} else {
 		updateStrategy.Type = apps.RollingUpdateStatefulSetStrategyType
 		updateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
-			Partition: pointer.Int32Ptr(tc.PDStsDesiredReplicas() + deleteSlotsNumber),
+			Partition: pointer.Int32Ptr(tc.PDOrdinalsStart() + tc.PDStsDesiredReplicas() + deleteSlotsNumber),
 		}
 	}
 
@@ -869,10 +3314,249 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 		},
 	}
 
+	if start := tc.PDOrdinalsStart(); start > 0 {
+		pdSet.Spec.Ordinals = &apps.StatefulSetOrdinals{Start: start}
+	}
 	pdSet.Spec.VolumeClaimTemplates = append(pdSet.Spec.VolumeClaimTemplates, additionalPVCs...)
+	if tc.PDPVCAuditAnnotationsEnabled() {
+		for i := range pdSet.Spec.VolumeClaimTemplates {
+			pdSet.Spec.VolumeClaimTemplates[i].Annotations = util.CombineStringMap(pdSet.Spec.VolumeClaimTemplates[i].Annotations, getPDPVCAuditAnnotations(tc))
+		}
+	}
 	return pdSet, nil
 }
 
+// verifyPDConfigMapVolumes checks the StatefulSet of PD to be applied, after all the overrides of its pod template,
+// against the ConfigMap returned by the ConfigMap sync. While the template is pinned to a revision or kept as the
+// last applied one during a volume replacement, it references an older ConfigMap instead, which must still exist.
+func (m *pdMemberManager) verifyPDConfigMapVolumes(tc *v1alpha1.TidbCluster, set *apps.StatefulSet, cm *corev1.ConfigMap, templateReplaced bool) error {
+	if cm == nil {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	cmName := cm.Name
+	if templateReplaced {
+		cmName = getPDConfigMapVolumeName(set)
+	}
+	if err := checkPDConfigMapVolumes(set, cmName); err != nil {
+		return fmt.Errorf("inconsistent StatefulSet of PD for [%s/%s], error: %v", ns, tcName, err)
+	}
+	if cmName == cm.Name {
+		// just created or updated by the ConfigMap sync
+		return nil
+	}
+	if _, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(cmName); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("inconsistent StatefulSet of PD for [%s/%s], ConfigMap %s referenced by the pod template doesn't exist", ns, tcName, cmName)
+		}
+		return fmt.Errorf("verifyPDConfigMapVolumes: failed to get ConfigMap %s for cluster %s/%s, error: %s", cmName, ns, tcName, err)
+	}
+	return nil
+}
+
+// getPDConfigMapVolumeName returns the name of the ConfigMap referenced by the config volume of PD, either
+// a volume on its own or a source of the projected volume.
+func getPDConfigMapVolumeName(set *apps.StatefulSet) string {
+	for _, vol := range set.Spec.Template.Spec.Volumes {
+		if vol.Name == "config" && vol.ConfigMap != nil {
+			return vol.ConfigMap.Name
+		}
+		if vol.Name == pdProjectedVolumeName && vol.Projected != nil {
+			for _, source := range vol.Projected.Sources {
+				if source.ConfigMap == nil {
+					continue
+				}
+				for _, item := range source.ConfigMap.Items {
+					if strings.SplitN(item.Path, "/", 2)[0] == "config" {
+						return source.ConfigMap.Name
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// checkPDConfigMapVolumes returns an error unless both the config and the startup script volumes of PD
+// reference the given ConfigMap, so that PD never starts with a stale config or start script, e.g. the
+// one of an old ConfigMap left by a race during a rolling config update.
+// The volumes combined into the projected volume are identified by the directory of their items.
+func checkPDConfigMapVolumes(set *apps.StatefulSet, cmName string) error {
+	found := map[string]bool{}
+	for _, vol := range set.Spec.Template.Spec.Volumes {
+		if vol.Name == pdProjectedVolumeName && vol.Projected != nil {
+			for _, source := range vol.Projected.Sources {
+				if source.ConfigMap == nil {
+					continue
+				}
+				for _, item := range source.ConfigMap.Items {
+					name := strings.SplitN(item.Path, "/", 2)[0]
+					if source.ConfigMap.Name != cmName {
+						return fmt.Errorf("volume %s references ConfigMap %s, expected ConfigMap %s", name, source.ConfigMap.Name, cmName)
+					}
+					found[name] = true
+				}
+			}
+			continue
+		}
+		if vol.Name != "config" && vol.Name != "startup-script" {
+			continue
+		}
+		if vol.ConfigMap == nil {
+			return fmt.Errorf("volume %s doesn't reference a ConfigMap, expected ConfigMap %s", vol.Name, cmName)
+		}
+		if vol.ConfigMap.Name != cmName {
+			return fmt.Errorf("volume %s references ConfigMap %s, expected ConfigMap %s", vol.Name, vol.ConfigMap.Name, cmName)
+		}
+		found[vol.Name] = true
+	}
+	for _, name := range []string{"config", "startup-script"} {
+		if !found[name] {
+			return fmt.Errorf("volume %s is missing, expected to reference ConfigMap %s", name, cmName)
+		}
+	}
+	return nil
+}
+
+// getPDHugePagesVolumes returns the volumes backed by the hugepages requested in `.spec.pd.requests` or `.spec.pd.limits`
+// and their mounts. With a single page size, the volume is mounted at /dev/hugepages. With multiple page sizes, a volume
+// is created for each size as required by Kubernetes, e.g. the one of hugepages-2Mi is mounted at /dev/hugepages-2Mi.
+func getPDHugePagesVolumes(tc *v1alpha1.TidbCluster) ([]corev1.Volume, []corev1.VolumeMount) {
+	sizes := sets.NewString()
+	for _, list := range []corev1.ResourceList{tc.Spec.PD.Requests, tc.Spec.PD.Limits} {
+		for name := range list {
+			if controller.IsHugePagesResourceName(name) {
+				sizes.Insert(strings.TrimPrefix(string(name), corev1.ResourceHugePagesPrefix))
+			}
+		}
+	}
+	if sizes.Len() == 0 {
+		return nil, nil
+	}
+	if sizes.Len() == 1 {
+		vol := corev1.Volume{
+			Name: pdHugePagesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumHugePages},
+			},
+		}
+		volMount := corev1.VolumeMount{Name: pdHugePagesVolumeName, MountPath: pdHugePagesMountPath}
+		return []corev1.Volume{vol}, []corev1.VolumeMount{volMount}
+	}
+
+	var vols []corev1.Volume
+	var volMounts []corev1.VolumeMount
+	for _, size := range sizes.List() {
+		name := fmt.Sprintf("%s-%s", pdHugePagesVolumeName, strings.ToLower(size))
+		vols = append(vols, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumHugePagesPrefix + corev1.StorageMedium(size)},
+			},
+		})
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: name, MountPath: fmt.Sprintf("%s-%s", pdHugePagesMountPath, size),
+		})
+	}
+	return vols, volMounts
+}
+
+// getPDVolumePath returns the path of the files of the volume mounted at mountPath by PD. If the volume
+// is combined into the projected volume, its files are in the directory named after the volume.
+func getPDVolumePath(tc *v1alpha1.TidbCluster, volName, mountPath string) string {
+	if tc.PDProjectedVolumeMountsEnabled() {
+		return path.Join(constants.PDProjectedVolumeMountPath, volName)
+	}
+	return mountPath
+}
+
+// projectPDVolumes combines the config, startup script and TLS volumes of PD into one projected volume,
+// the items of each volume are put in a directory named after the volume, see getPDVolumePath. The projected
+// volume is mounted as a directory instead of by sub path, because the files mounted by sub path are never
+// updated by kubelet, e.g. the rotated TLS certificates and the ConfigMap updated in place.
+func projectPDVolumes(vols []corev1.Volume, volMounts []corev1.VolumeMount) ([]corev1.Volume, []corev1.VolumeMount) {
+	projectedNames := sets.NewString("config", "startup-script", "pd-tls", util.ClusterClientVolName, "tidb-client-tls")
+	prefixItems := func(dir string, items []corev1.KeyToPath) []corev1.KeyToPath {
+		prefixed := make([]corev1.KeyToPath, 0, len(items))
+		for _, item := range items {
+			item.Path = path.Join(dir, item.Path)
+			prefixed = append(prefixed, item)
+		}
+		return prefixed
+	}
+
+	projected := corev1.Volume{
+		Name: pdProjectedVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{},
+		},
+	}
+	sources := sets.NewString()
+	newVols := make([]corev1.Volume, 0, len(vols))
+	for _, vol := range vols {
+		if !projectedNames.Has(vol.Name) {
+			newVols = append(newVols, vol)
+			continue
+		}
+		switch {
+		case vol.ConfigMap != nil:
+			projected.Projected.Sources = append(projected.Projected.Sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: vol.ConfigMap.LocalObjectReference,
+					Items:                prefixItems(vol.Name, vol.ConfigMap.Items),
+				},
+			})
+		case vol.Secret != nil:
+			// the items of the TLS secrets are listed explicitly to put them in the directory of the volume
+			items := vol.Secret.Items
+			if len(items) == 0 {
+				items = []corev1.KeyToPath{
+					{Key: tlsSecretRootCAKey, Path: tlsSecretRootCAKey},
+					{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+					{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+				}
+			}
+			projected.Projected.Sources = append(projected.Projected.Sources, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: vol.Secret.SecretName},
+					Items:                prefixItems(vol.Name, items),
+				},
+			})
+		default:
+			newVols = append(newVols, vol)
+			continue
+		}
+		if sources.Len() == 0 {
+			// the projected volume takes the place of the first volume combined into it,
+			// the sources appended later are shared by the pointer of the projected volume source
+			newVols = append(newVols, projected)
+		}
+		sources.Insert(vol.Name)
+	}
+	if sources.Len() == 0 {
+		return vols, volMounts
+	}
+
+	// the projected volume takes the place of the first mount of the volumes combined into it
+	newVolMounts := make([]corev1.VolumeMount, 0, len(volMounts))
+	mounted := false
+	for _, mount := range volMounts {
+		if !sources.Has(mount.Name) {
+			newVolMounts = append(newVolMounts, mount)
+			continue
+		}
+		if !mounted {
+			newVolMounts = append(newVolMounts, corev1.VolumeMount{
+				Name: pdProjectedVolumeName, ReadOnly: true, MountPath: constants.PDProjectedVolumeMountPath,
+			})
+			mounted = true
+		}
+	}
+	return newVols, newVolMounts
+}
+
 func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	// For backward compatibility, only sync tidb configmap when .tidb.config is non-nil
 	if tc.Spec.PD.Config == nil {
@@ -887,23 +3571,155 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 
 	// override CA if tls enabled
 	if tc.IsTLSClusterEnabled() {
-		config.Set("security.cacert-path", path.Join(pdClusterCertPath, tlsSecretRootCAKey))
-		config.Set("security.cert-path", path.Join(pdClusterCertPath, corev1.TLSCertKey))
-		config.Set("security.key-path", path.Join(pdClusterCertPath, corev1.TLSPrivateKeyKey))
+		for key, value := range getPDClusterTLSConfig(tc) {
+			config.Set(key, value)
+		}
 	}
 	// Versions below v4.0 do not support Dashboard
 	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB() && clusterVersionGE4 {
-		if !tc.Spec.TiDB.TLSClient.SkipInternalClientCA {
-			config.Set("dashboard.tidb-cacert-path", path.Join(tidbClientCertPath, tlsSecretRootCAKey))
+		if useProjectedDashboardTiDBCA(tc, clusterVersionGE4) {
+			config.Set("dashboard.tidb-cacert-path", path.Join(tidbClientCAPath, tlsSecretRootCAKey))
+		} else if !tc.Spec.TiDB.TLSClient.SkipInternalClientCA {
+			config.Set("dashboard.tidb-cacert-path", path.Join(getPDVolumePath(tc, "tidb-client-tls", tidbClientCertPath), tlsSecretRootCAKey))
 		}
-		config.Set("dashboard.tidb-cert-path", path.Join(tidbClientCertPath, corev1.TLSCertKey))
-		config.Set("dashboard.tidb-key-path", path.Join(tidbClientCertPath, corev1.TLSPrivateKeyKey))
+		config.Set("dashboard.tidb-cert-path", path.Join(getPDVolumePath(tc, "tidb-client-tls", tidbClientCertPath), corev1.TLSCertKey))
+		config.Set("dashboard.tidb-key-path", path.Join(getPDVolumePath(tc, "tidb-client-tls", tidbClientCertPath), corev1.TLSPrivateKeyKey))
 	}
 
 	if tc.Spec.PD.EnableDashboardInternalProxy != nil {
 		config.Set("dashboard.internal-proxy", *tc.Spec.PD.EnableDashboardInternalProxy)
 	}
 
+	// PD rotates the log file by itself, and the log tailer sidecar tails it to stdout
+	if logTailer := tc.Spec.PD.LogTailer; logTailer != nil {
+		config.Set("log.file.filename", path.Join(pdLogDir, pdLogFile))
+		config.Set("log.file.max-size", int64(logTailer.GetMaxSize())) // `int64` to avoid marshal to string
+		config.Set("log.file.max-backups", int64(logTailer.GetMaxBackups()))
+	}
+
+	// the audit config set in .spec.pd.config explicitly takes precedence
+	if audit := tc.Spec.PD.AuditConfig; audit != nil {
+		if audit.Enabled != nil {
+			config.SetIfNil("pd-server.enable-audit", *audit.Enabled)
+		}
+		if audit.RedactInfoLog != nil {
+			config.SetIfNil("security.redact-info-log", *audit.RedactInfoLog)
+		}
+	}
+
+	// the location labels set in .spec.pd.config explicitly take precedence
+	if len(tc.Spec.PD.LocationLabels) > 0 {
+		config.SetIfNil("replication.location-labels", tc.Spec.PD.LocationLabels)
+	}
+
+	// the observability endpoints set in .spec.pd.config explicitly take precedence
+	if tc.Spec.PD.MetricStorage != nil {
+		config.SetIfNil("pd-server.metric-storage", *tc.Spec.PD.MetricStorage)
+	}
+	if tc.Spec.PD.DashboardAddress != nil {
+		config.SetIfNil("pd-server.dashboard-address", *tc.Spec.PD.DashboardAddress)
+	}
+	// the min resolved ts persistence interval set in .spec.pd.config explicitly takes precedence
+	if tc.Spec.PD.MinResolvedTSPersistenceInterval != nil {
+		config.SetIfNil("pd-server.min-resolved-ts-persistence-interval", *tc.Spec.PD.MinResolvedTSPersistenceInterval)
+	}
+	// the tso intervals set in .spec.pd.config explicitly take precedence
+	if tc.Spec.PD.TSOSaveInterval != nil {
+		config.SetIfNil("tso-save-interval", *tc.Spec.PD.TSOSaveInterval)
+	}
+	if tc.Spec.PD.TSOUpdatePhysicalInterval != nil {
+		config.SetIfNil("tso-update-physical-interval", *tc.Spec.PD.TSOUpdatePhysicalInterval)
+	}
+	// the hot region schedule limit and split merge interval set in .spec.pd.config explicitly take precedence
+	if tc.Spec.PD.HotRegionScheduleLimit != nil {
+		config.SetIfNil("schedule.hot-region-schedule-limit", *tc.Spec.PD.HotRegionScheduleLimit)
+	}
+	if tc.Spec.PD.SplitMergeInterval != nil {
+		config.SetIfNil("schedule.split-merge-interval", *tc.Spec.PD.SplitMergeInterval)
+	}
+	// the diagnostic switch set in .spec.pd.config explicitly takes precedence
+	if tc.Spec.PD.EnableDiagnostic != nil {
+		config.SetIfNil("schedule.enable-diagnostic", *tc.Spec.PD.EnableDiagnostic)
+	}
+	// the grpc gateway switch set in .spec.pd.config explicitly takes precedence
+	if tc.Spec.PD.EnableGRPCGateway != nil {
+		config.SetIfNil("enable-grpc-gateway", *tc.Spec.PD.EnableGRPCGateway)
+	}
+	// the dashboard is disabled by its address, versions below v4.0 don't serve the dashboard at all
+	if tc.Spec.PD.DisableDashboard != nil && *tc.Spec.PD.DisableDashboard && clusterVersionGE4 {
+		config.SetIfNil("pd-server.dashboard-address", "none")
+	}
+	// the dashboard public path prefix set in .spec.pd.config explicitly takes precedence
+	if tc.Spec.PD.DashboardPublicPathPrefix != nil {
+		config.SetIfNil("dashboard.public-path-prefix", *tc.Spec.PD.DashboardPublicPathPrefix)
+	}
+
+	// the gRPC message size limits set in .spec.pd.config explicitly take precedence
+	if tc.Spec.PD.MaxGRPCMessageSize != nil {
+		size, err := resource.ParseQuantity(*tc.Spec.PD.MaxGRPCMessageSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max gRPC message size %q of pd: %v", *tc.Spec.PD.MaxGRPCMessageSize, err)
+		}
+		config.SetIfNil("pd-server.max-grpc-send-msg-size", size.Value())
+		config.SetIfNil("pd-server.max-grpc-recv-msg-size", size.Value())
+	}
+
+	// the region merge config set in .spec.pd.config explicitly takes precedence
+	if merge := tc.Spec.PD.RegionMerge; merge != nil {
+		if merge.EnableCrossTableMerge != nil {
+			config.SetIfNil("schedule.enable-cross-table-merge", *merge.EnableCrossTableMerge)
+		}
+		if merge.MaxMergeRegionSize != nil {
+			config.SetIfNil("schedule.max-merge-region-size", *merge.MaxMergeRegionSize)
+		}
+		if merge.MaxMergeRegionKeys != nil {
+			config.SetIfNil("schedule.max-merge-region-keys", *merge.MaxMergeRegionKeys)
+		}
+	}
+
+	// the replication mode config set in .spec.pd.config explicitly takes precedence
+	if rm := tc.Spec.PD.ReplicationMode; rm != nil {
+		config.SetIfNil("replication-mode.replication-mode", rm.Mode)
+		if rm.LabelKey != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.label-key", *rm.LabelKey)
+		}
+		if rm.Primary != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.primary", *rm.Primary)
+		}
+		if rm.DR != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.dr", *rm.DR)
+		}
+		if rm.PrimaryReplicas != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.primary-replicas", int64(*rm.PrimaryReplicas))
+		}
+		if rm.DRReplicas != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.dr-replicas", int64(*rm.DRReplicas))
+		}
+		if rm.WaitStoreTimeout != nil {
+			config.SetIfNil("replication-mode.dr-auto-sync.wait-store-timeout", *rm.WaitStoreTimeout)
+		}
+	}
+
+	// `dashboard.disable-telemetry` is deprecated but still honored by PD, so leave both keys
+	// untouched if any of them is set explicitly to avoid conflicting with the user
+	if tc.Spec.PD.DisableTelemetry != nil && *tc.Spec.PD.DisableTelemetry &&
+		config.Get("dashboard.enable-telemetry") == nil && config.Get("dashboard.disable-telemetry") == nil {
+		config.Set("dashboard.enable-telemetry", false)
+		config.Set("dashboard.disable-telemetry", true)
+	}
+
+	// use a token unique to the cluster to prevent PD from joining the PD cluster of another cluster
+	if token := tc.PDInitialClusterToken(); token != "" {
+		config.SetIfNil("initial-cluster-token", token)
+	}
+
+	// validate the final config, including the items set by the operator, before it's rolled out
+	if tc.Spec.PD.ValidateConfigSchema != nil && *tc.Spec.PD.ValidateConfigSchema {
+		if err := validatePDConfigSchema(config, tc.PDVersion()); err != nil {
+			return nil, err
+		}
+	}
+
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -931,6 +3747,172 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	return cm, nil
 }
 
+// getPDClusterTLSConfig returns the config of the cluster TLS certificates mounted by the operator
+func getPDClusterTLSConfig(tc *v1alpha1.TidbCluster) map[string]string {
+	certPath := getPDVolumePath(tc, "pd-tls", pdClusterCertPath)
+	return map[string]string{
+		"security.cacert-path": path.Join(certPath, tlsSecretRootCAKey),
+		"security.cert-path":   path.Join(certPath, corev1.TLSCertKey),
+		"security.key-path":    path.Join(certPath, corev1.TLSPrivateKeyKey),
+	}
+}
+
+// getPDClusterTLSConfigConflicts returns the sorted keys of the TLS config set in .spec.pd.config
+// which differ from the ones injected by the operator when the cluster TLS is enabled.
+func getPDClusterTLSConfigConflicts(tc *v1alpha1.TidbCluster) []string {
+	if !tc.IsTLSClusterEnabled() || tc.Spec.PD.Config == nil {
+		return nil
+	}
+	var conflicts []string
+	for key, expected := range getPDClusterTLSConfig(tc) {
+		v := tc.Spec.PD.Config.Get(key)
+		if v == nil {
+			continue
+		}
+		if value, err := v.AsString(); err != nil || value != expected {
+			conflicts = append(conflicts, key)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// isPDGRPCGatewayDisabled returns whether the grpc gateway is disabled in the config of PD,
+// the switch set in .spec.pd.config takes precedence over .spec.pd.enableGRPCGateway.
+func isPDGRPCGatewayDisabled(tc *v1alpha1.TidbCluster) bool {
+	if tc.Spec.PD.Config != nil {
+		if v := tc.Spec.PD.Config.Get("enable-grpc-gateway"); v != nil {
+			enabled, ok := v.Interface().(bool)
+			return ok && !enabled
+		}
+	}
+	return tc.Spec.PD.EnableGRPCGateway != nil && !*tc.Spec.PD.EnableGRPCGateway
+}
+
+// getPDMaintenanceToleration returns the toleration of the taint added to the nodes during the node maintenance,
+// which matches any value of the key if the value is empty
+func getPDMaintenanceToleration(mt *v1alpha1.PDMaintenanceToleration) corev1.Toleration {
+	toleration := corev1.Toleration{
+		Key:               mt.Key,
+		Operator:          corev1.TolerationOpEqual,
+		Value:             mt.Value,
+		Effect:            corev1.TaintEffectNoExecute,
+		TolerationSeconds: mt.TolerationSeconds,
+	}
+	if mt.Value == "" {
+		toleration.Operator = corev1.TolerationOpExists
+	}
+	return toleration
+}
+
+// getPDWaitForDNSContainer returns the init container blocking until the DNS record of the PD pod
+// in the peer service can be resolved, so that PD doesn't fail to resolve its members on slow-DNS clusters.
+func getPDWaitForDNSContainer(tc *v1alpha1.TidbCluster) corev1.Container {
+	domain := fmt.Sprintf("${POD_NAME}.%s.%s.svc", controller.PDPeerMemberName(tc.Name), tc.Namespace)
+	if tc.Spec.ClusterDomain != "" {
+		domain = domain + "." + tc.Spec.ClusterDomain
+	}
+	return corev1.Container{
+		Name:            "wait-for-dns",
+		Image:           tc.HelperImage(),
+		ImagePullPolicy: tc.HelperImagePullPolicy(),
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf(`until nslookup %s; do echo "waiting for %s to be resolvable"; sleep 1; done`, domain, domain),
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
+		},
+	}
+}
+
+// findPDContainer finds the PD container in the statefulset by the configured container name,
+// and falls back to the default name in case the statefulset is not rolled out with the new name yet.
+func findPDContainer(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) *corev1.Container {
+	if c := findContainerByName(set, tc.PDContainerName()); c != nil {
+		return c
+	}
+	return findContainerByName(set, v1alpha1.PDMemberType.String())
+}
+
+// getPDPodAnnotations returns the annotations of PD pods. The annotations set by users, e.g. the ones controlling
+// the sidecar injection of service meshes, always win on conflicts over the ones added by the operator.
+func getPDPodAnnotations(tc *v1alpha1.TidbCluster, basePDSpec v1alpha1.ComponentAccessor) map[string]string {
+	// CombineStringMap keeps the first value of a key, so the maps are ordered by precedence
+	return util.CombineStringMap(
+		basePDSpec.Annotations(),
+		tc.Spec.PD.PrometheusScrapeAnnotations,
+		controller.AnnProm(v1alpha1.DefaultPDClientPort, "/metrics"),
+	)
+}
+
+// useProjectedDashboardTiDBCA returns whether TiDB Dashboard verifies TiDB with the CA trust bundle
+// in the projected volume `.spec.pd.dashboardTiDBCAProjected` instead of the one in the client secret.
+func useProjectedDashboardTiDBCA(tc *v1alpha1.TidbCluster, clusterVersionGE4 bool) bool {
+	return tc.Spec.PD.DashboardTiDBCAProjected != nil && tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() &&
+		!tc.SkipTLSWhenConnectTiDB() && clusterVersionGE4 && !tc.Spec.TiDB.TLSClient.SkipInternalClientCA
+}
+
+// pdVolumeCheckScript writes a file to the data volume of PD once, and fails with a clear message when
+// the write fails, e.g. the volume isn't writable by the user of PD without fsGroup, or it doesn't complete
+// in time, e.g. the storage backing the volume is unavailable. The volume is always mounted by kubelet before
+// any init container starts, so there's nothing to wait for but the write.
+const pdVolumeCheckScript = `touch %[1]s &
+pid=$!
+i=0
+while kill -0 $pid 2>/dev/null; do
+    if [ $i -ge %[3]d ]; then
+        echo "timed out after %[3]ds writing to %[2]s, the data volume of PD may be unavailable"
+        kill -9 $pid
+        exit 1
+    fi
+    i=$((i+1))
+    sleep 1
+done
+if ! wait $pid; then
+    echo "%[2]s is not writable by uid $(id -u) gid $(id -g), check the fsGroup of the pod or the ownership of the data volume of PD"
+    exit 1
+fi
+rm -f %[1]s
+`
+
+// getPDCheckVolumeWritableContainer returns the init container checking that the data volume of PD is writable,
+// so that PD fails fast with a clear message instead of crashing on a volume which is not usable. It runs as the
+// user and group set for the PD container, so the uid in the message is the one of PD when they're set.
+func getPDCheckVolumeWritableContainer(tc *v1alpha1.TidbCluster) corev1.Container {
+	mountPath := constants.PDDataVolumeMountPath
+	checkFile := path.Join(mountPath, ".volume-check")
+	var securityContext *corev1.SecurityContext
+	if tc.Spec.PD.RunAsUser != nil || tc.Spec.PD.RunAsGroup != nil {
+		securityContext = &corev1.SecurityContext{
+			RunAsUser:  tc.Spec.PD.RunAsUser,
+			RunAsGroup: tc.Spec.PD.RunAsGroup,
+		}
+	}
+	return corev1.Container{
+		Name:            "check-volume-writable",
+		Image:           tc.HelperImage(),
+		ImagePullPolicy: tc.HelperImagePullPolicy(),
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf(pdVolumeCheckScript, checkFile, mountPath, pdVolumeCheckTimeoutSeconds),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: string(v1alpha1.GetStorageVolumeName("", v1alpha1.PDMemberType)), MountPath: mountPath},
+		},
+		SecurityContext: securityContext,
+	}
+}
+
 func clusterVersionGreaterThanOrEqualTo4(version, model string) (bool, error) {
 	// TODO: remove `model` and `nightly` when support pd microservice docker
 	if model == "ms" && version == "nightly" {
@@ -995,13 +3977,109 @@ func (m *pdMemberManager) collectUnjoinedMembers(tc *v1alpha1.TidbCluster, set *
 
 // TODO: Support check status http request in future.
 func buildPDReadinessProbHandler(tc *v1alpha1.TidbCluster) corev1.ProbeHandler {
+	if tc.Spec.PD.ReadinessSidecar != nil {
+		return buildPDSidecarProbeHandler(tc.Spec.PD.ReadinessSidecar)
+	}
+
+	// fall to default case v1alpha1.TCPProbeType
+	port := v1alpha1.DefaultPDClientPort
+	if tc.Spec.PD.ProbePort != nil {
+		port = *tc.Spec.PD.ProbePort
+	}
 	return corev1.ProbeHandler{
 		TCPSocket: &corev1.TCPSocketAction{
-			Port: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+			Port: intstr.FromInt(int(port)),
+		},
+	}
+}
+
+// buildPDSidecarProbeHandler requests the HTTP endpoint of the health aggregator sidecar,
+// so that the readiness of PD follows the verdict of the sidecar.
+func buildPDSidecarProbeHandler(sidecar *v1alpha1.PDReadinessSidecar) corev1.ProbeHandler {
+	path := sidecar.Path
+	if path == "" {
+		path = defaultPDReadinessSidecarPath
+	}
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: path,
+			Port: intstr.FromInt(int(sidecar.Port)),
 		},
 	}
 }
 
+// buildPDWaitForJoinCommand polls the members API of the local PD until the pod appears in the
+// member list, the member name is either the pod name or the pod name followed by the domain.
+func buildPDWaitForJoinCommand(tc *v1alpha1.TidbCluster) []string {
+	curl := buildPDProbeCurlCommand(tc, "/pd/api/v1/members")
+	script := fmt.Sprintf(`name=${POD_NAME:-$(hostname)}; until %s | grep -q '"name": *"'"$name"'[".]'; do sleep 1; done`, curl)
+	return []string{"sh", "-c", script}
+}
+
+// buildPDFlushMetricsScript returns the script scraping the metrics of the local PD and pushing them to the
+// Pushgateway in `.spec.pd.flushMetricsOnShutdown`. A failure is ignored to never block the shutdown of PD.
+func buildPDFlushMetricsScript(tc *v1alpha1.TidbCluster) string {
+	flush := tc.Spec.PD.FlushMetricsOnShutdown
+	job := pdFlushMetricsDefaultJob
+	if flush.Job != nil && *flush.Job != "" {
+		job = *flush.Job
+	}
+	timeout := int32(pdFlushMetricsDefaultTimeoutSeconds)
+	if flush.TimeoutSeconds != nil {
+		timeout = *flush.TimeoutSeconds
+	}
+	scrape := buildPDProbeCurlCommand(tc, "/metrics")
+	url := fmt.Sprintf("%s/metrics/job/%s/instance/${POD_NAME:-$(hostname)}", strings.TrimSuffix(flush.PushgatewayURL, "/"), job)
+	return fmt.Sprintf(`%s --max-time %d | curl --silent --fail --max-time %d --data-binary @- "%s" || true`, scrape, timeout, timeout, url)
+}
+
+// appendPDPreStopScript appends the script to the preStop hook of the container, so that it runs after the
+// existing steps in sequence. A preStop hook which is not an exec action can't be composed and is kept as is.
+func appendPDPreStopScript(container *corev1.Container, script string) {
+	if container.Lifecycle == nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+	}
+	preStop := container.Lifecycle.PreStop
+	if preStop == nil {
+		container.Lifecycle.PreStop = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sh", "-c", script}},
+		}
+		return
+	}
+	if preStop.Exec == nil {
+		klog.Warningf("the preStop hook of container %s is not an exec action, skip appending %q", container.Name, script)
+		return
+	}
+
+	var existing string
+	if cmd := preStop.Exec.Command; len(cmd) == 3 && cmd[0] == "sh" && cmd[1] == "-c" {
+		existing = cmd[2]
+	} else {
+		args := make([]string, 0, len(cmd))
+		for _, arg := range cmd {
+			args = append(args, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+		}
+		existing = strings.Join(args, " ")
+	}
+	preStop.Exec.Command = []string{"sh", "-c", existing + "; " + script}
+}
+
+// buildPDProbeCurlCommand returns the curl command requesting the API of the local PD,
+// the cluster client certs are used if TLS is enabled.
+func buildPDProbeCurlCommand(tc *v1alpha1.TidbCluster, api string) string {
+	url := fmt.Sprintf("%s://127.0.0.1:%d%s", tc.Scheme(), v1alpha1.DefaultPDClientPort, api)
+	curl := []string{"curl", "--silent", "--fail", url}
+	if tc.IsTLSClusterEnabled() {
+		certPath := getPDVolumePath(tc, "pd-tls", pdClusterCertPath)
+		curl = append(curl,
+			"--cacert", path.Join(certPath, tlsSecretRootCAKey),
+			"--cert", path.Join(certPath, corev1.TLSCertKey),
+			"--key", path.Join(certPath, corev1.TLSPrivateKeyKey),
+		)
+	}
+	return strings.Join(curl, " ")
+}
+
 // TODO: seems not used
 type FakePDMemberManager struct {
 	err error
@@ -15,31 +15,44 @@ package member
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/third_party/k8s"
 )
 
 func TestPDMemberManagerSyncCreate(t *testing.T) {
@@ -734,6 +747,74 @@ func TestPDMemberManagerUpgrade(t *testing.T) {
 	}
 }
 
+func TestPDMemberManagerDeferScaleOutWhenUpgrading(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	ns := tc.Namespace
+	tcName := tc.Name
+	pmm, _, _ := newFakePDMemberManager()
+	fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+	fakeSetControl := pmm.deps.StatefulSetControl.(*controller.FakeStatefulSetControl)
+	pdClient := controller.NewFakePDClient(fakePDControl, tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+			{Name: "pd1", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "pd2", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "pd3", MemberID: uint64(3), ClientUrls: []string{"http://test-pd-3.test-pd-peer.default.svc:2379"}, Health: true},
+		}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: uint64(1)}, nil
+	})
+
+	// the statefulset is in the middle of a rolling upgrade
+	fakeSetControl.SetStatusChange(func(set *apps.StatefulSet) {
+		set.Status.Replicas = *set.Spec.Replicas
+		set.Status.CurrentRevision = "pd-1"
+		set.Status.UpdateRevision = "pd-2"
+		set.Status.ObservedGeneration = 1
+	})
+	err := pmm.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	// scale-out is deferred while upgrading
+	tc1 := tc.DeepCopy()
+	tc1.Spec.PD.Replicas = 5
+	err = pmm.Sync(tc1)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("scale-out is deferred until the upgrade completes"))
+	g.Expect(tc1.Status.PD.Phase).To(Equal(v1alpha1.UpgradePhase))
+	set, err := pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(3)))
+	events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(ContainElement(ContainSubstring("Normal PDScaleOutDeferred scale-out of pd from 3 to 5 replicas is deferred")))
+
+	// scale-in still takes precedence over upgrading
+	tc2 := tc.DeepCopy()
+	tc2.Spec.PD.Replicas = 1
+	err = pmm.Sync(tc2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc2.Status.PD.Phase).To(Equal(v1alpha1.ScalePhase))
+	set, err = pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+
+	// scale-out resumes once the upgrade completes
+	set = set.DeepCopy()
+	set.Spec.Replicas = pointer.Int32Ptr(3)
+	set.Status.Replicas = 3
+	set.Status.CurrentRevision = "pd-2"
+	g.Expect(fakeSetControl.SetIndexer.Update(set)).To(Succeed())
+	err = pmm.Sync(tc1)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc1.Status.PD.Phase).To(Equal(v1alpha1.ScalePhase))
+	set, err = pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(4)))
+}
+
 func TestPDMemberManagerSyncPDSts(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
@@ -1975,1244 +2056,8323 @@ func TestGetNewPDSetForTidbCluster(t *testing.T) {
 			testSts: func(sts *apps.StatefulSet) {
 				g := NewGomegaWithT(t)
 				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
-					ProbeHandler:        buildPDReadinessProbHandler(nil),
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+						},
+					},
 					InitialDelaySeconds: int32(10),
 				}))
 			},
 		},
-	}
-
-	for i := range tests {
-		tt := tests[i]
-		t.Run(tt.name, func(t *testing.T) {
-			sts, err := getNewPDSetForTidbCluster(&tt.tc, nil)
-			if (err != nil) != tt.wantErr {
-				t.Fatalf("error %v, wantErr %v", err, tt.wantErr)
-			}
-			tt.testSts(sts)
-		})
-	}
-}
-
-func TestGetPDConfigMap(t *testing.T) {
-	g := NewGomegaWithT(t)
-	updateStrategy := v1alpha1.ConfigUpdateStrategyInPlace
-	testCases := []struct {
-		name     string
-		tc       v1alpha1.TidbCluster
-		expected *corev1.ConfigMap
-	}{
 		{
-			name: "PD config is nil",
+			name: "PD spec readiness with timeout",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					PD:   &v1alpha1.PDSpec{},
-					TiDB: &v1alpha1.TiDBSpec{},
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							ReadinessProbe: &v1alpha1.Probe{
+								Type:           pointer.StringPtr("tcp"),
+								TimeoutSeconds: pointer.Int32Ptr(5),
+							},
+						},
+					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: nil,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+						},
+					},
+					InitialDelaySeconds: int32(10),
+					TimeoutSeconds:      int32(5),
+				}))
+			},
 		},
 		{
-			name: "basic",
+			name: "PD spec readiness with command probe",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
 						ComponentSpec: v1alpha1.ComponentSpec{
-							ConfigUpdateStrategy: &updateStrategy,
-						},
-						Config: mustPDConfig(&v1alpha1.PDConfig{
-							Schedule: &v1alpha1.PDScheduleConfig{
-								MaxStoreDownTime:         pointer.StringPtr("5m"),
-								DisableRemoveDownReplica: pointer.BoolPtr(true),
-							},
-							Replication: &v1alpha1.PDReplicationConfig{
-								MaxReplicas:    func() *uint64 { i := uint64(5); return &i }(),
-								LocationLabels: []string{"node", "rack"},
+							ReadinessProbe: &v1alpha1.Probe{
+								Type: pointer.StringPtr(v1alpha1.CommandProbeType),
 							},
-						}),
+						},
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
 					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
-					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				// the readiness of PD doesn't depend on TiKV, which needs PD to be ready to register
+				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
 						},
 					},
+					InitialDelaySeconds: int32(10),
+				}))
+			},
+		},
+		{
+			name: "pd spec pvcAuditAnnotations",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
 				},
-				Data: map[string]string{
-					"startup-script": "",
-					"config-file": `[replication]
-  location-labels = ["node", "rack"]
-  max-replicas = 5
-
-[schedule]
-  disable-remove-down-replica = true
-  max-store-down-time = "5m"
-`,
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						StorageVolumes: []v1alpha1.StorageVolume{
+							{
+								Name:        "log",
+								StorageSize: "2Gi",
+								MountPath:   "/var/log",
+							}},
+						PVCAuditAnnotations: pointer.BoolPtr(true),
+					},
+					TiDB: &v1alpha1.TiDBSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.VolumeClaimTemplates).To(HaveLen(2))
+				for _, vct := range sts.Spec.VolumeClaimTemplates {
+					g.Expect(vct.Annotations).To(Equal(map[string]string{
+						label.AnnPVCClusterName: "tc",
+						label.AnnPVCComponent:   "pd",
+					}))
+				}
+			},
 		},
 		{
-			name: "tidb version v3.1.0, tidb client tls is enabled",
+			name: "pd spec maintenanceToleration",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-v3",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
 						ComponentSpec: v1alpha1.ComponentSpec{
-							Image: "pingcap/pd:v3.1.0",
+							Tolerations: []corev1.Toleration{
+								{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "pd", Effect: corev1.TaintEffectNoSchedule},
+							},
 						},
-						Config: v1alpha1.NewPDConfig(),
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+						MaintenanceToleration: &v1alpha1.PDMaintenanceToleration{
+							Key:               "example.com/maintenance",
+							Value:             "planned",
+							TolerationSeconds: pointer.Int64Ptr(3600),
 						},
 					},
+					TiDB: &v1alpha1.TiDBSpec{},
 					TiKV: &v1alpha1.TiKVSpec{},
 				},
 			},
-			expected: &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-v3-pd",
-					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "tls-v3",
-						"app.kubernetes.io/component":  "pd",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "tls-v3",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Tolerations).To(Equal([]corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "pd", Effect: corev1.TaintEffectNoSchedule},
+					{
+						Key:               "example.com/maintenance",
+						Operator:          corev1.TolerationOpEqual,
+						Value:             "planned",
+						Effect:            corev1.TaintEffectNoExecute,
+						TolerationSeconds: pointer.Int64Ptr(3600),
 					},
-				},
-				Data: map[string]string{
-					"startup-script": "",
-					"config-file":    "",
-				},
+				}))
 			},
 		},
 		{
-			name: "tidb version v4.0.0-rc.1, tidb client tls is enabled",
+			name: "pd spec maintenanceToleration without value",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-v4",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						ComponentSpec: v1alpha1.ComponentSpec{
-							Image: "pingcap/pd:v4.0.0-rc.1",
-						},
-						Config: v1alpha1.NewPDConfig(),
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+						MaintenanceToleration: &v1alpha1.PDMaintenanceToleration{
+							Key: "example.com/maintenance",
 						},
 					},
+					TiDB: &v1alpha1.TiDBSpec{},
 					TiKV: &v1alpha1.TiKVSpec{},
 				},
 			},
-			expected: &corev1.ConfigMap{
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Tolerations).To(Equal([]corev1.Toleration{
+					{
+						Key:      "example.com/maintenance",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoExecute,
+					},
+				}))
+			},
+		},
+		{
+			name: "PD spec without post start wait for join",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-v4-pd",
+					Name:      "tc",
 					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "tls-v4",
-						"app.kubernetes.io/component":  "pd",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "tls-v4",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
-					},
 				},
-				Data: map[string]string{
-					"startup-script": "",
-					"config-file": `[dashboard]
-  tidb-cacert-path = "/var/lib/tidb-client-tls/ca.crt"
-  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
-  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
-`,
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						PostStartWaitForJoin: pointer.BoolPtr(false),
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].Lifecycle).To(BeNil())
+			},
 		},
 		{
-			name: "tidb version nightly, tidb client tls is enabled",
+			name: "PD spec with post start wait for join",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-nightly",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						ComponentSpec: v1alpha1.ComponentSpec{
-							Image: "pingcap/pd:nightly",
-						},
-						Config: v1alpha1.NewPDConfig(),
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
-						},
+						PostStartWaitForJoin: pointer.BoolPtr(true),
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: &corev1.ConfigMap{
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].Lifecycle).To(Equal(&corev1.Lifecycle{
+					PostStart: &corev1.LifecycleHandler{
+						Exec: &corev1.ExecAction{
+							Command: []string{"sh", "-c", `name=${POD_NAME:-$(hostname)}; until curl --silent --fail http://127.0.0.1:2379/pd/api/v1/members | grep -q '"name": *"'"$name"'[".]'; do sleep 1; done`},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "PD spec with post start wait for join and tls",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-nightly-pd",
+					Name:      "tc",
 					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "tls-nightly",
-						"app.kubernetes.io/component":  "pd",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TLSCluster: &v1alpha1.TLSCluster{Enabled: true},
+					PD: &v1alpha1.PDSpec{
+						PostStartWaitForJoin: pointer.BoolPtr(true),
 					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "tls-nightly",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].Lifecycle).To(Equal(&corev1.Lifecycle{
+					PostStart: &corev1.LifecycleHandler{
+						Exec: &corev1.ExecAction{
+							Command: []string{"sh", "-c", `name=${POD_NAME:-$(hostname)}; until curl --silent --fail https://127.0.0.1:2379/pd/api/v1/members --cacert /var/lib/pd-tls/ca.crt --cert /var/lib/pd-tls/tls.crt --key /var/lib/pd-tls/tls.key | grep -q '"name": *"'"$name"'[".]'; do sleep 1; done`},
 						},
 					},
-				},
-				Data: map[string]string{
-					"startup-script": "",
-					"config-file": `[dashboard]
-  tidb-cacert-path = "/var/lib/tidb-client-tls/ca.crt"
-  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
-  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
-`,
-				},
+				}))
 			},
 		},
 		{
-			name: "tidb version nightly, tidb client tls is enabled and skip ca is configured",
+			name: "PD spec readiness with sidecar probe taking precedence over the probe type",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-nightly",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
 						ComponentSpec: v1alpha1.ComponentSpec{
-							Image: "pingcap/pd:nightly",
-						},
-						Config: v1alpha1.NewPDConfig(),
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled:              true,
-							SkipInternalClientCA: true,
+							ReadinessProbe: &v1alpha1.Probe{
+								Type: pointer.StringPtr(v1alpha1.TCPProbeType),
+							},
 						},
+						ReadinessSidecar: &v1alpha1.PDReadinessSidecar{Port: 8080, Path: "/ready"},
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "tls-nightly-pd",
-					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "tls-nightly",
-						"app.kubernetes.io/component":  "pd",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "tls-nightly",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.FromInt(8080),
 						},
 					},
-				},
-				Data: map[string]string{
-					"startup-script": "",
-					"config-file": `[dashboard]
-  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
-  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
-`,
-				},
+					InitialDelaySeconds: int32(10),
+				}))
 			},
 		},
-	}
-
-	for i := range testCases {
-		tt := testCases[i]
-		t.Run(tt.name, func(t *testing.T) {
-			cm, err := getPDConfigMap(&tt.tc)
-			g.Expect(err).To(Succeed())
-			if tt.expected == nil {
-				g.Expect(cm).To(BeNil())
-				return
-			}
-			// startup-script is better to be tested in e2e
-			cm.Data["startup-script"] = ""
-			if diff := cmp.Diff(*tt.expected, *cm); diff != "" {
-				t.Errorf("unexpected plugin configuration (-want, +got): %s", diff)
-			}
-		})
-	}
-}
-
-func TestGetNewPdServiceForTidbCluster(t *testing.T) {
-	tests := []struct {
-		name     string
-		tc       v1alpha1.TidbCluster
-		expected corev1.Service
-	}{
 		{
-			name: "basic",
+			name: "PD spec readiness with sidecar probe and default path",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					Services: []v1alpha1.Service{
-						{Name: "pd", Type: string(corev1.ServiceTypeClusterIP)},
-					},
-
-					PD: &v1alpha1.PDSpec{},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
-						},
+					PD: &v1alpha1.PDSpec{
+						ReadinessSidecar: &v1alpha1.PDReadinessSidecar{Port: 9090},
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
-					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-						"app.kubernetes.io/used-by":    "end-user",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
-					},
-				},
-				Spec: corev1.ServiceSpec{
-					Type: corev1.ServiceTypeClusterIP,
-					Ports: []corev1.ServicePort{
-						{
-							Name:       "client",
-							Port:       v1alpha1.DefaultPDClientPort,
-							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
-							Protocol:   corev1.ProtocolTCP,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/health",
+							Port: intstr.FromInt(9090),
 						},
 					},
-					Selector: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
-				},
+					InitialDelaySeconds: int32(10),
+				}))
 			},
 		},
 		{
-			name: "basic and  specify ClusterIP type,clusterIP",
+			name: "PD spec readiness with custom probe port",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					Services: []v1alpha1.Service{
-						{Name: "pd", Type: string(corev1.ServiceTypeClusterIP)},
-					},
 					PD: &v1alpha1.PDSpec{
-						Service: &v1alpha1.ServiceSpec{ClusterIP: pointer.StringPtr("172.20.10.1")},
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+						ComponentSpec: v1alpha1.ComponentSpec{
+							ReadinessProbe: &v1alpha1.Probe{
+								Type: pointer.StringPtr(v1alpha1.TCPProbeType),
+							},
 						},
+						ProbePort: pointer.Int32Ptr(15021),
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
-					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-						"app.kubernetes.io/used-by":    "end-user",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
-					},
-				},
-				Spec: corev1.ServiceSpec{
-					ClusterIP: "172.20.10.1",
-					Type:      corev1.ServiceTypeClusterIP,
-					Ports: []corev1.ServicePort{
-						{
-							Name:       "client",
-							Port:       v1alpha1.DefaultPDClientPort,
-							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
-							Protocol:   corev1.ProtocolTCP,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(&corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(15021),
 						},
 					},
-					Selector: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
-				},
+					InitialDelaySeconds: int32(10),
+				}))
 			},
 		},
 		{
-			name: "basic and specify LoadBalancerIP type , LoadBalancerType",
+			name: "PD spec with runtime class and overhead",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					Services: []v1alpha1.Service{
-						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
-					},
 					PD: &v1alpha1.PDSpec{
-						Service: &v1alpha1.ServiceSpec{LoadBalancerIP: pointer.StringPtr("172.20.10.1")},
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+						RuntimeClassName: pointer.StringPtr("kata"),
+						Overhead: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("250m"),
+							corev1.ResourceMemory: resource.MustParse("160Mi"),
 						},
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: corev1.Service{
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.RuntimeClassName).To(Equal(pointer.StringPtr("kata")))
+				g.Expect(sts.Spec.Template.Spec.Overhead).To(Equal(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("160Mi"),
+				}))
+			},
+		},
+		{
+			name: "PD spec without runtime class and overhead",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
+					Name:      "tc",
 					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-						"app.kubernetes.io/used-by":    "end-user",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
-					},
 				},
-				Spec: corev1.ServiceSpec{
-					LoadBalancerIP: "172.20.10.1",
-					Type:           corev1.ServiceTypeLoadBalancer,
-					Ports: []corev1.ServicePort{
-						{
-							Name:       "client",
-							Port:       v1alpha1.DefaultPDClientPort,
-							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
-							Protocol:   corev1.ProtocolTCP,
+				Spec: v1alpha1.TidbClusterSpec{
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+				g.Expect(sts.Spec.Template.Spec.Overhead).To(BeNil())
+			},
+		},
+		{
+			name: "PD fsGroupChangePolicy is not set by default when fsGroup is set",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								FSGroup: pointer.Int64Ptr(1000),
+							},
 						},
 					},
-					Selector: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.FSGroupChangePolicy).To(BeNil())
+			},
 		},
 		{
-			name: "basic and specify pd service overwrite",
+			name: "PD fsGroupChangePolicy is set from spec",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					Services: []v1alpha1.Service{
-						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
-					},
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								FSGroup: pointer.Int64Ptr(1000),
+							},
 						},
+						FSGroupChangePolicy: func() *corev1.PodFSGroupChangePolicy {
+							p := corev1.FSGroupChangeAlways
+							return &p
+						}(),
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				policy := corev1.FSGroupChangeAlways
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.FSGroupChangePolicy).To(Equal(&policy))
+			},
+		},
+		{
+			name: "PD fsGroupChangePolicy in podSecurityContext takes precedence",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
-							ClusterIP: pointer.StringPtr("172.20.10.1")},
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								FSGroup: pointer.Int64Ptr(1000),
+								FSGroupChangePolicy: func() *corev1.PodFSGroupChangePolicy {
+									p := corev1.FSGroupChangeAlways
+									return &p
+								}(),
+							},
+						},
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: corev1.Service{
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				policy := corev1.FSGroupChangeAlways
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.FSGroupChangePolicy).To(Equal(&policy))
+			},
+		},
+		{
+			name: "PD fsGroupChangePolicy is not set without fsGroup",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
+					Name:      "tc",
 					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-						"app.kubernetes.io/used-by":    "end-user",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								RunAsNonRoot: &asNonRoot,
+							},
 						},
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
-				Spec: corev1.ServiceSpec{
-					ClusterIP: "172.20.10.1",
-					Type:      corev1.ServiceTypeClusterIP,
-					Ports: []corev1.ServicePort{
-						{
-							Name:       "client",
-							Port:       v1alpha1.DefaultPDClientPort,
-							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
-							Protocol:   corev1.ProtocolTCP,
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.FSGroupChangePolicy).To(BeNil())
+			},
+		},
+		{
+			name: "PD seccompProfile is set in pod security context",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
 						},
 					},
-					Selector: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.SeccompProfile).To(Equal(&corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				}))
+			},
 		},
 		{
-			name: "basic and specify pd service portname",
+			name: "PD seccompProfile in podSecurityContext takes precedence",
 			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo",
+					Name:      "tc",
 					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					Services: []v1alpha1.Service{
-						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
-					},
 					PD: &v1alpha1.PDSpec{
-						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
-							ClusterIP: pointer.StringPtr("172.20.10.1"),
-							PortName:  pointer.StringPtr("http-pd"),
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								RunAsNonRoot: &asNonRoot,
+								SeccompProfile: &corev1.SeccompProfile{
+									Type:             corev1.SeccompProfileTypeLocalhost,
+									LocalhostProfile: pointer.StringPtr("profiles/pd.json"),
+								},
+							},
 						},
-					},
-
-					TiDB: &v1alpha1.TiDBSpec{
-						TLSClient: &v1alpha1.TiDBTLSClient{
-							Enabled: true,
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
 						},
 					},
 					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			expected: corev1.Service{
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(Equal(&asNonRoot))
+				g.Expect(sts.Spec.Template.Spec.SecurityContext.SeccompProfile).To(Equal(&corev1.SeccompProfile{
+					Type:             corev1.SeccompProfileTypeLocalhost,
+					LocalhostProfile: pointer.StringPtr("profiles/pd.json"),
+				}))
+			},
+		},
+		{
+			name: "PD prometheus scrape annotations are merged into pod annotations",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-pd",
+					Name:      "tc",
 					Namespace: "ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-						"app.kubernetes.io/used-by":    "end-user",
-					},
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: "pingcap.com/v1alpha1",
-							Kind:       "TidbCluster",
-							Name:       "foo",
-							UID:        "",
-							Controller: func(b bool) *bool {
-								return &b
-							}(true),
-							BlockOwnerDeletion: func(b bool) *bool {
-								return &b
-							}(true),
-						},
-					},
 				},
-				Spec: corev1.ServiceSpec{
-					ClusterIP: "172.20.10.1",
-					Type:      corev1.ServiceTypeClusterIP,
-					Ports: []corev1.ServicePort{
-						{
-							Name:       "http-pd",
-							Port:       v1alpha1.DefaultPDClientPort,
-							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
-							Protocol:   corev1.ProtocolTCP,
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						PrometheusScrapeAnnotations: map[string]string{
+							"prometheus.io/interval": "30s",
 						},
 					},
-					Selector: map[string]string{
-						"app.kubernetes.io/name":       "tidb-cluster",
-						"app.kubernetes.io/managed-by": "tidb-operator",
-						"app.kubernetes.io/instance":   "foo",
-						"app.kubernetes.io/component":  "pd",
-					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				annos := sts.Spec.Template.Annotations
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/interval", "30s"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/scrape", "true"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/path", "/metrics"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/port", "2379"))
+			},
 		},
-	}
-
-	for i := range tests {
-		tt := tests[i]
-		t.Run(tt.name, func(t *testing.T) {
-			pmm, _, _ := newFakePDMemberManager()
-			svc := pmm.getNewPDServiceForTidbCluster(&tt.tc)
-			if diff := cmp.Diff(tt.expected, *svc); diff != "" {
-				t.Errorf("unexpected Service (-want, +got): %s", diff)
-			}
-		})
-	}
-}
-
-func TestPDMemberManagerSyncPDStsWhenPdNotJoinCluster(t *testing.T) {
-	g := NewGomegaWithT(t)
-	type testcase struct {
-		name                string
-		modify              func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer)
-		pdHealth            *pdapi.HealthInfo
-		tcStatusChange      func(cluster *v1alpha1.TidbCluster)
-		err                 bool
-		expectTidbClusterFn func(*GomegaWithT, *v1alpha1.TidbCluster)
-	}
-
-	testFn := func(test *testcase, t *testing.T) {
-		tc := newTidbClusterForPD()
-		ns := tc.Namespace
-		tcName := tc.Name
-
-		pmm, podIndexer, pvcIndexer := newFakePDMemberManager()
-		fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
-		pdClient := controller.NewFakePDClient(fakePDControl, tc)
-
-		pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
-			return test.pdHealth, nil
-		})
-		pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
-			return &metapb.Cluster{Id: uint64(1)}, nil
-		})
-
-		err := pmm.Sync(tc)
-		g.Expect(controller.IsRequeueError(err)).To(BeTrue())
-		_, err = pmm.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
-		g.Expect(err).NotTo(HaveOccurred())
-		_, err = pmm.deps.ServiceLister.Services(ns).Get(controller.PDPeerMemberName(tcName))
-		g.Expect(err).NotTo(HaveOccurred())
-		_, err = pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
-		g.Expect(err).NotTo(HaveOccurred())
-		if test.tcStatusChange != nil {
-			test.tcStatusChange(tc)
-		}
-		test.modify(tc, podIndexer, pvcIndexer)
-		err = pmm.syncPDStatefulSetForTidbCluster(tc)
-		if test.err {
-			g.Expect(err).To(HaveOccurred())
-		} else {
-			g.Expect(err).NotTo(HaveOccurred())
-		}
-		if test.expectTidbClusterFn != nil {
-			test.expectTidbClusterFn(g, tc)
-		}
-	}
-	tests := []testcase{
 		{
-			name: "add pd unjoin cluster member info ",
-			modify: func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer) {
-				var pods []*corev1.Pod
-				COUNT := 3
-				for ordinal := 0; ordinal < COUNT; ordinal++ {
-					pod := &corev1.Pod{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        ordinalPodName(v1alpha1.PDMemberType, cluster.GetName(), int32(ordinal)),
-							Namespace:   metav1.NamespaceDefault,
-							Annotations: map[string]string{},
-							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
-						},
-					}
-					podIndexer.Add(pod)
-					pods = append(pods, pod)
-				}
-				for ordinal := 0; ordinal < COUNT; ordinal++ {
-					pvc1 := &corev1.PersistentVolumeClaim{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        ordinalPVCName(v1alpha1.PDMemberType, controller.PDMemberName(cluster.GetName()), int32(ordinal)),
-							Namespace:   metav1.NamespaceDefault,
-							Annotations: map[string]string{},
-							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
-						},
-					}
-					pvc2 := pvc1.DeepCopy()
-					pvc1.Name = pvc1.Name + "-1"
-					pvc1.UID = pvc1.UID + "-1"
-					pvc2.Name = pvc2.Name + "-2"
-					pvc2.UID = pvc2.UID + "-2"
-					pod := pods[ordinal]
-					pvc1.ObjectMeta.Labels[label.AnnPodNameKey] = pod.GetName()
-					pvc2.ObjectMeta.Labels[label.AnnPodNameKey] = pod.GetName()
-					pod.Spec.Volumes = append(pod.Spec.Volumes,
-						corev1.Volume{
-							VolumeSource: corev1.VolumeSource{
-								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: pvc1.Name,
-								},
+			name: "PD prometheus scrape path is overridden",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Annotations: map[string]string{
+								"foo": "bar",
 							},
 						},
-						corev1.Volume{
-							VolumeSource: corev1.VolumeSource{
-								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: pvc2.Name,
-								},
-							},
-						})
-					pvcIndexer.Add(pvc1)
-					pvcIndexer.Add(pvc2)
-				}
+						PrometheusScrapeAnnotations: map[string]string{
+							"prometheus.io/path": "/custom/metrics",
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
 			},
-			pdHealth: &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
-				{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0:2379"}, Health: false},
-				{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1:2379"}, Health: false},
-			}},
-			err: false,
-			expectTidbClusterFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
-				g.Expect(tc.Status.PD.UnjoinedMembers["test-pd-2"]).NotTo(BeNil())
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				annos := sts.Spec.Template.Annotations
+				g.Expect(annos).To(HaveKeyWithValue("foo", "bar"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/path", "/custom/metrics"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/scrape", "true"))
 			},
 		},
 		{
-			name: "clear unjoin cluster member info when the member join the cluster ",
-			tcStatusChange: func(cluster *v1alpha1.TidbCluster) {
-				cluster.Status.PD.UnjoinedMembers = map[string]v1alpha1.UnjoinedMember{
-					"test-pd-0": {
-						PodName:   "test-pd-0",
-						CreatedAt: metav1.Now(),
+			name: "PD service mesh annotations win over the ones added by the operator",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "true",
 					},
-				}
-			},
-			modify: func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer) {
-				for ordinal := 0; ordinal < 3; ordinal++ {
-					pod := &corev1.Pod{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        ordinalPodName(v1alpha1.PDMemberType, cluster.GetName(), int32(ordinal)),
-							Namespace:   metav1.NamespaceDefault,
-							Annotations: map[string]string{},
-							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Annotations: map[string]string{
+								"sidecar.istio.io/inject":                      "false",
+								"traffic.sidecar.istio.io/excludeInboundPorts": "2380",
+								"prometheus.io/scrape":                         "false",
+							},
 						},
-					}
-					podIndexer.Add(pod)
-				}
-				for ordinal := 0; ordinal < 3; ordinal++ {
-					pvc := &corev1.PersistentVolumeClaim{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        ordinalPVCName(v1alpha1.PDMemberType, controller.PDMemberName(cluster.GetName()), int32(ordinal)),
-							Namespace:   metav1.NamespaceDefault,
-							Annotations: map[string]string{},
-							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+						PrometheusScrapeAnnotations: map[string]string{
+							"prometheus.io/scrape": "true",
+							"prometheus.io/path":   "/custom/metrics",
 						},
-					}
-					pvcIndexer.Add(pvc)
-				}
-
-			},
-			pdHealth: &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
-				{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: false},
-				{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: false},
-				{Name: "test-pd-2", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: false},
-			}},
-			err: false,
-			expectTidbClusterFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
-				g.Expect(tc.Status.PD.UnjoinedMembers).To(BeEmpty())
-				g.Expect(len(tc.Status.PD.Members)).To(Equal(3))
-			},
-		},
-	}
-	for i := range tests {
-		t.Logf("begin: %s", tests[i].name)
-		testFn(&tests[i], t)
-		t.Logf("end: %s", tests[i].name)
-	}
-}
-
-func TestPDShouldRecover(t *testing.T) {
-	pods := []*v1.Pod{
-		{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "failover-pd-0",
-				Namespace: v1.NamespaceDefault,
-			},
-			Status: v1.PodStatus{
-				Conditions: []v1.PodCondition{
-					{
-						Type:   corev1.PodReady,
-						Status: corev1.ConditionTrue,
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				annos := sts.Spec.Template.Annotations
+				g.Expect(annos).To(HaveKeyWithValue("sidecar.istio.io/inject", "false"))
+				g.Expect(annos).To(HaveKeyWithValue("traffic.sidecar.istio.io/excludeInboundPorts", "2380"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/scrape", "false"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/path", "/custom/metrics"))
+				g.Expect(annos).To(HaveKeyWithValue("prometheus.io/port", "2379"))
+			},
 		},
 		{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "failover-pd-1",
-				Namespace: v1.NamespaceDefault,
-			},
-			Status: v1.PodStatus{
-				Conditions: []v1.PodCondition{
-					{
-						Type:   corev1.PodReady,
-						Status: corev1.ConditionTrue,
+			name: "PD container runAsUser and runAsGroup",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								RunAsNonRoot: &asNonRoot,
+								RunAsUser:    pointer.Int64Ptr(1000),
+							},
+						},
+						RunAsUser:  pointer.Int64Ptr(2000),
+						RunAsGroup: pointer.Int64Ptr(3000),
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-		},
-	}
-	podsWithFailover := append(pods, &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "failover-pd-2",
-			Namespace: v1.NamespaceDefault,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   corev1.PodReady,
-					Status: corev1.ConditionFalse,
-				},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				podSecurityContext := sts.Spec.Template.Spec.SecurityContext
+				g.Expect(podSecurityContext.RunAsNonRoot).To(Equal(&asNonRoot))
+				g.Expect(podSecurityContext.RunAsUser).To(Equal(pointer.Int64Ptr(1000)))
+
+				nameToContainer := MapContainers(&sts.Spec.Template.Spec)
+				pdContainer := nameToContainer[v1alpha1.PDMemberType.String()]
+				g.Expect(pdContainer.SecurityContext).To(Equal(&corev1.SecurityContext{
+					RunAsUser:  pointer.Int64Ptr(2000),
+					RunAsGroup: pointer.Int64Ptr(3000),
+				}))
 			},
 		},
-	})
-	tests := []struct {
-		name string
-		tc   *v1alpha1.TidbCluster
-		pods []*v1.Pod
-		want bool
-	}{
 		{
-			name: "should not recover if no failure members",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD container security context is not set without runAsUser and runAsGroup",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
-				Status: v1alpha1.TidbClusterStatus{},
 			},
-			pods: pods,
-			want: false,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				nameToContainer := MapContainers(&sts.Spec.Template.Spec)
+				pdContainer := nameToContainer[v1alpha1.PDMemberType.String()]
+				g.Expect(pdContainer.SecurityContext).To(BeNil())
+			},
 		},
 		{
-			name: "should not recover if a member is not healthy",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD log tailer shares the log volume with PD",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						Replicas: 2,
-					},
-				},
-				Status: v1alpha1.TidbClusterStatus{
-					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"failover-pd-0": {
-								Name:   "failover-pd-0",
-								Health: false,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-						},
-						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
+						LogTailer: &v1alpha1.PDLogTailerSpec{
+							ResourceRequirements: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("100m"),
+								},
 							},
 						},
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			pods: pods,
-			want: false,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "pdlog",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				}))
+				logVolumeMount := corev1.VolumeMount{Name: "pdlog", MountPath: "/var/log/pdlog"}
+
+				nameToContainer := MapContainers(&sts.Spec.Template.Spec)
+				pdContainer := nameToContainer[v1alpha1.PDMemberType.String()]
+				g.Expect(pdContainer.VolumeMounts).To(ContainElement(logVolumeMount))
+
+				tailer, ok := nameToContainer[v1alpha1.ContainerPDLogTailer.String()]
+				g.Expect(ok).To(BeTrue())
+				g.Expect(tailer.Image).To(Equal("busybox:1.26.2"))
+				g.Expect(tailer.VolumeMounts).To(Equal([]corev1.VolumeMount{logVolumeMount}))
+				g.Expect(tailer.Command).To(Equal([]string{"sh", "-c", "touch /var/log/pdlog/pd.log; tail -n0 -F /var/log/pdlog/pd.log;"}))
+				g.Expect(tailer.Resources.Limits.Cpu().String()).To(Equal("100m"))
+			},
 		},
 		{
-			name: "should recover if all members are ready and healthy",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD log tailer is not added by default",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				nameToContainer := MapContainers(&sts.Spec.Template.Spec)
+				_, ok := nameToContainer[v1alpha1.ContainerPDLogTailer.String()]
+				g.Expect(ok).To(BeFalse())
+				for _, vol := range sts.Spec.Template.Spec.Volumes {
+					g.Expect(vol.Name).NotTo(Equal("pdlog"))
+				}
+			},
+		},
+		{
+			name: "PD waits for DNS resolution",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						Replicas: 2,
+						WaitForDNS: pointer.BoolPtr(true),
 					},
+					TiKV:          &v1alpha1.TiKVSpec{},
+					TiDB:          &v1alpha1.TiDBSpec{},
+					ClusterDomain: "cluster.local",
 				},
-				Status: v1alpha1.TidbClusterStatus{
-					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"failover-pd-0": {
-								Name:   "failover-pd-0",
-								Health: true,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-						},
-						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				initContainers := MapInitContainers(&sts.Spec.Template.Spec)
+				waitForDNS, ok := initContainers["wait-for-dns"]
+				g.Expect(ok).To(BeTrue())
+				g.Expect(waitForDNS.Image).To(Equal("busybox:1.26.2"))
+				g.Expect(waitForDNS.Command).To(Equal([]string{
+					"sh",
+					"-c",
+					`until nslookup ${POD_NAME}.tc-pd-peer.ns.svc.cluster.local; do echo "waiting for ${POD_NAME}.tc-pd-peer.ns.svc.cluster.local to be resolvable"; sleep 1; done`,
+				}))
+				g.Expect(waitForDNS.Env).To(Equal([]corev1.EnvVar{
+					{
+						Name: "POD_NAME",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.name",
 							},
 						},
 					},
-				},
+				}))
 			},
-			pods: pods,
-			want: true,
 		},
 		{
-			name: "should recover if all members are ready and healthy (ignore auto-created failover pods)",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD checks its volume when recreated for failover",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
-						Replicas: 2,
+						Replicas:            3,
+						CheckVolumeWritable: pointer.BoolPtr(true),
+						RunAsUser:           pointer.Int64Ptr(1000),
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 				Status: v1alpha1.TidbClusterStatus{
 					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"failover-pd-0": {
-								Name:   "failover-pd-0",
-								Health: true,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-							"failover-pd-2": {
-								Name:   "failover-pd-1",
-								Health: false,
-							},
-						},
 						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
-							},
+							"tc-pd-1": {PodName: "tc-pd-1", MemberDeleted: true},
 						},
 					},
 				},
 			},
-			pods: podsWithFailover,
-			want: true,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				// the pod created for failover shares the pod template
+				g.Expect(*sts.Spec.Replicas).To(Equal(int32(4)))
+				initContainers := MapInitContainers(&sts.Spec.Template.Spec)
+				checkVolume, ok := initContainers["check-volume-writable"]
+				g.Expect(ok).To(BeTrue())
+				g.Expect(checkVolume.Image).To(Equal("busybox:1.26.2"))
+				g.Expect(checkVolume.Command).To(Equal([]string{
+					"sh",
+					"-c",
+					`touch /var/lib/pd/.volume-check &
+pid=$!
+i=0
+while kill -0 $pid 2>/dev/null; do
+    if [ $i -ge 30 ]; then
+        echo "timed out after 30s writing to /var/lib/pd, the data volume of PD may be unavailable"
+        kill -9 $pid
+        exit 1
+    fi
+    i=$((i+1))
+    sleep 1
+done
+if ! wait $pid; then
+    echo "/var/lib/pd is not writable by uid $(id -u) gid $(id -g), check the fsGroup of the pod or the ownership of the data volume of PD"
+    exit 1
+fi
+rm -f /var/lib/pd/.volume-check
+`,
+				}))
+				g.Expect(checkVolume.VolumeMounts).To(Equal([]corev1.VolumeMount{
+					{Name: "pd", MountPath: "/var/lib/pd"},
+				}))
+				// the check runs as the user of PD
+				g.Expect(checkVolume.SecurityContext).To(Equal(&corev1.SecurityContext{
+					RunAsUser: pointer.Int64Ptr(1000),
+				}))
+			},
 		},
 		{
-			name: "Pod is not ready",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD doesn't wait for DNS resolution by default",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
-					PD: &v1alpha1.PDSpec{
-						Replicas: 3,
-					},
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
-				Status: v1alpha1.TidbClusterStatus{
-					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"failover-pd-0": {
-								Name:   "failover-pd-0",
-								Health: true,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-						},
-						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
-							},
-						},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				_, ok := MapInitContainers(&sts.Spec.Template.Spec)["wait-for-dns"]
+				g.Expect(ok).To(BeFalse())
+			},
+		},
+		{
+			name: "PD ordinals start from a non-zero index",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas:      3,
+						OrdinalsStart: pointer.Int32Ptr(3),
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
 			},
-			pods: podsWithFailover,
-			want: false,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Ordinals).To(Equal(&apps.StatefulSetOrdinals{Start: 3}))
+				g.Expect(*sts.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(6)))
+			},
 		},
 		{
-			name: "shouldn't recover when replicas is more than PD members number",
-			tc: &v1alpha1.TidbCluster{
+			name: "PD ordinals start from 0 by default",
+			tc: v1alpha1.TidbCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
+					Name:      "tc",
+					Namespace: "ns",
 				},
 				Spec: v1alpha1.TidbClusterSpec{
 					PD: &v1alpha1.PDSpec{
 						Replicas: 3,
 					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
 				},
-				Status: v1alpha1.TidbClusterStatus{
-					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"failover-pd-0": {
-								Name:   "failover-pd-0",
-								Health: true,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-						},
-						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
-							},
-						},
-					},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Ordinals).To(BeNil())
+				g.Expect(*sts.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(3)))
+			},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			sts, err := getNewPDSetForTidbCluster(&tt.tc, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.testSts(sts)
+		})
+	}
+}
+
+func TestGetPDConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+	updateStrategy := v1alpha1.ConfigUpdateStrategyInPlace
+	testCases := []struct {
+		name     string
+		tc       v1alpha1.TidbCluster
+		expected *corev1.ConfigMap
+	}{
+		{
+			name: "PD config is nil",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
 				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD:   &v1alpha1.PDSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "basic",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							ConfigUpdateStrategy: &updateStrategy,
+						},
+						Config: mustPDConfig(&v1alpha1.PDConfig{
+							Schedule: &v1alpha1.PDScheduleConfig{
+								MaxStoreDownTime:         pointer.StringPtr("5m"),
+								DisableRemoveDownReplica: pointer.BoolPtr(true),
+							},
+							Replication: &v1alpha1.PDReplicationConfig{
+								MaxReplicas:    func() *uint64 { i := uint64(5); return &i }(),
+								LocationLabels: []string{"node", "rack"},
+							},
+						}),
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `[replication]
+  location-labels = ["node", "rack"]
+  max-replicas = 5
+
+[schedule]
+  disable-remove-down-replica = true
+  max-store-down-time = "5m"
+`,
+				},
+			},
+		},
+		{
+			name: "tidb version v3.1.0, tidb client tls is enabled",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-v3",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Image: "pingcap/pd:v3.1.0",
+						},
+						Config: v1alpha1.NewPDConfig(),
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-v3-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "tls-v3",
+						"app.kubernetes.io/component":  "pd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "tls-v3",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file":    "",
+				},
+			},
+		},
+		{
+			name: "tidb version v4.0.0-rc.1, tidb client tls is enabled",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-v4",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Image: "pingcap/pd:v4.0.0-rc.1",
+						},
+						Config: v1alpha1.NewPDConfig(),
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-v4-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "tls-v4",
+						"app.kubernetes.io/component":  "pd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "tls-v4",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `[dashboard]
+  tidb-cacert-path = "/var/lib/tidb-client-tls/ca.crt"
+  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
+  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
+`,
+				},
+			},
+		},
+		{
+			name: "tidb version nightly, tidb client tls is enabled",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-nightly",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Image: "pingcap/pd:nightly",
+						},
+						Config: v1alpha1.NewPDConfig(),
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-nightly-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "tls-nightly",
+						"app.kubernetes.io/component":  "pd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "tls-nightly",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `[dashboard]
+  tidb-cacert-path = "/var/lib/tidb-client-tls/ca.crt"
+  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
+  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
+`,
+				},
+			},
+		},
+		{
+			name: "tidb version nightly, tidb client tls is enabled and skip ca is configured",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-nightly",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							Image: "pingcap/pd:nightly",
+						},
+						Config: v1alpha1.NewPDConfig(),
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled:              true,
+							SkipInternalClientCA: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-nightly-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "tls-nightly",
+						"app.kubernetes.io/component":  "pd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "tls-nightly",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `[dashboard]
+  tidb-cert-path = "/var/lib/tidb-client-tls/tls.crt"
+  tidb-key-path = "/var/lib/tidb-client-tls/tls.key"
+`,
+				},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tt := testCases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			cm, err := getPDConfigMap(&tt.tc)
+			g.Expect(err).To(Succeed())
+			if tt.expected == nil {
+				g.Expect(cm).To(BeNil())
+				return
+			}
+			// startup-script is better to be tested in e2e
+			cm.Data["startup-script"] = ""
+			if diff := cmp.Diff(*tt.expected, *cm); diff != "" {
+				t.Errorf("unexpected plugin configuration (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithLogTailer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name               string
+		logTailer          *v1alpha1.PDLogTailerSpec
+		expectMaxSize      int64
+		expectMaxBackups   int64
+		expectLogFileUnset bool
+	}{
+		{
+			name:             "default rotation settings",
+			logTailer:        &v1alpha1.PDLogTailerSpec{},
+			expectMaxSize:    300,
+			expectMaxBackups: 3,
+		},
+		{
+			name: "custom rotation settings",
+			logTailer: &v1alpha1.PDLogTailerSpec{
+				MaxSize:    pointer.Int32Ptr(100),
+				MaxBackups: pointer.Int32Ptr(5),
+			},
+			expectMaxSize:    100,
+			expectMaxBackups: 5,
+		},
+		{
+			name:               "log tailer is disabled",
+			expectLogFileUnset: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			tc.Spec.PD.LogTailer = tt.logTailer
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectLogFileUnset {
+				g.Expect(config.Get("log.file.filename")).To(BeNil())
+				return
+			}
+			g.Expect(config.Get("log.file.filename").MustString()).To(Equal("/var/log/pdlog/pd.log"))
+			g.Expect(config.Get("log.file.max-size").MustInt()).To(Equal(tt.expectMaxSize))
+			g.Expect(config.Get("log.file.max-backups").MustInt()).To(Equal(tt.expectMaxBackups))
+		})
+	}
+}
+
+func TestGetPDConfigMapWithAuditConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                string
+		config              map[string]interface{}
+		auditConfig         *v1alpha1.PDAuditConfig
+		expectEnableAudit   interface{}
+		expectRedactInfoLog interface{}
+	}{
+		{
+			name: "audit config is not set",
+		},
+		{
+			name: "audit config is mapped into pd config",
+			auditConfig: &v1alpha1.PDAuditConfig{
+				Enabled:       pointer.BoolPtr(true),
+				RedactInfoLog: pointer.BoolPtr(true),
+			},
+			expectEnableAudit:   true,
+			expectRedactInfoLog: true,
+		},
+		{
+			name: "only the items set are mapped",
+			auditConfig: &v1alpha1.PDAuditConfig{
+				Enabled: pointer.BoolPtr(false),
+			},
+			expectEnableAudit: false,
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"pd-server.enable-audit":   false,
+				"security.redact-info-log": false,
+			},
+			auditConfig: &v1alpha1.PDAuditConfig{
+				Enabled:       pointer.BoolPtr(true),
+				RedactInfoLog: pointer.BoolPtr(true),
+			},
+			expectEnableAudit:   false,
+			expectRedactInfoLog: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.AuditConfig = tt.auditConfig
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expect := range map[string]interface{}{
+				"pd-server.enable-audit":   tt.expectEnableAudit,
+				"security.redact-info-log": tt.expectRedactInfoLog,
+			} {
+				if expect == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+					continue
+				}
+				g.Expect(config.Get(key).Interface()).To(Equal(expect), key)
+			}
+			if tt.config == nil {
+				// the spec of the tidb cluster is not changed
+				g.Expect(tc.Spec.PD.Config.Get("pd-server.enable-audit")).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDServicesWithStaleLister(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name    string
+		svcName string
+		syncFn  func(*pdMemberManager, *v1alpha1.TidbCluster) error
+	}{
+		{
+			name:    "pd service",
+			svcName: "test-pd",
+			syncFn:  (*pdMemberManager).syncPDServiceForTidbCluster,
+		},
+		{
+			name:    "pd headless service",
+			svcName: "test-pd-peer",
+			syncFn:  (*pdMemberManager).syncPDHeadlessServiceForTidbCluster,
+		},
+		{
+			name:    "pd leader service",
+			svcName: "test-pd-leader",
+			syncFn:  (*pdMemberManager).syncPDLeaderServiceForTidbCluster,
+		},
+		{
+			name:    "pd pprof service",
+			svcName: "test-pd-pprof",
+			syncFn:  (*pdMemberManager).syncPDPprofServiceForTidbCluster,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+			tc.Spec.PD.PprofPort = pointer.Int32Ptr(6060)
+			tc.Spec.PD.EnablePprofService = pointer.BoolPtr(true)
+			pmm, _, _ := newFakePDMemberManager()
+			fakeSvcControl := pmm.deps.ServiceControl.(*controller.FakeServiceControl)
+
+			// the service has been created but the lister doesn't see it yet
+			fakeSvcControl.SetCreateServiceError(errors.NewAlreadyExists(corev1.Resource("services"), tt.svcName), 0)
+			err := tt.syncFn(pmm, tc)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			g.Expect(err.Error()).To(ContainSubstring(tt.svcName + " already exists"))
+
+			// the service is created in the next round when it doesn't exist actually
+			g.Expect(tt.syncFn(pmm, tc)).To(Succeed())
+			_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(tt.svcName)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// other errors are still returned as is
+			tc2 := newTidbClusterForPD()
+			tc2.Name = "test2"
+			tc2.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+			tc2.Spec.PD.PprofPort = pointer.Int32Ptr(6060)
+			tc2.Spec.PD.EnablePprofService = pointer.BoolPtr(true)
+			fakeSvcControl.SetCreateServiceError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+			err = tt.syncFn(pmm, tc2)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(controller.IsRequeueError(err)).To(BeFalse())
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDServiceWithDriftedPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		mutate      func(svc *corev1.Service)
+		expectEvent bool
+	}{
+		{
+			name:   "ports are not modified",
+			mutate: func(svc *corev1.Service) {},
+		},
+		{
+			name: "port number is modified",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].Port = 12379
+			},
+			expectEvent: true,
+		},
+		{
+			name: "target port is modified",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].TargetPort = intstr.FromInt(12379)
+			},
+			expectEvent: true,
+		},
+		{
+			name: "port is renamed",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].Name = "other"
+			},
+			expectEvent: true,
+		},
+		{
+			name: "node port is allocated",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].NodePort = 30379
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, serverSideApply := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s, server-side apply: %v", tt.name, serverSideApply), func(t *testing.T) {
+				tc := newTidbClusterForPD()
+				pmm, _, _ := newFakePDMemberManager()
+				pmm.deps.CLIConfig.PDServerSideApply = serverSideApply
+				fakeSvcControl := pmm.deps.ServiceControl.(*controller.FakeServiceControl)
+				g.Expect(pmm.syncPDServiceForTidbCluster(tc)).To(Succeed())
+
+				svc, err := pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDMemberName(tc.Name))
+				g.Expect(err).NotTo(HaveOccurred())
+				svc = svc.DeepCopy()
+				tt.mutate(svc)
+				g.Expect(fakeSvcControl.SvcIndexer.Update(svc)).To(Succeed())
+
+				g.Expect(pmm.syncPDServiceForTidbCluster(tc)).To(Succeed())
+				svc, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDMemberName(tc.Name))
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(svc.Spec.Ports).To(HaveLen(1))
+				g.Expect(svc.Spec.Ports[0].Name).To(Equal("client"))
+				g.Expect(svc.Spec.Ports[0].Port).To(Equal(v1alpha1.DefaultPDClientPort))
+				g.Expect(svc.Spec.Ports[0].TargetPort).To(Equal(intstr.FromInt(int(v1alpha1.DefaultPDClientPort))))
+
+				events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+				if tt.expectEvent {
+					g.Expect(events).To(HaveLen(1))
+					g.Expect(events[0]).To(ContainSubstring("PDServicePortsOverwritten"))
+				} else {
+					g.Expect(events).To(BeEmpty())
+				}
+			})
+		}
+	}
+}
+
+func TestGetNewPdServiceForTidbCluster(t *testing.T) {
+	localPolicy := corev1.ServiceInternalTrafficPolicyLocal
+	tests := []struct {
+		name     string
+		tc       v1alpha1.TidbCluster
+		expected corev1.Service
+	}{
+		{
+			name: "basic",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeClusterIP)},
+					},
+
+					PD: &v1alpha1.PDSpec{},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and  specify ClusterIP type,clusterIP",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeClusterIP)},
+					},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{ClusterIP: pointer.StringPtr("172.20.10.1")},
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "172.20.10.1",
+					Type:      corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and specify LoadBalancerIP type , LoadBalancerType",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
+					},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{LoadBalancerIP: pointer.StringPtr("172.20.10.1")},
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					LoadBalancerIP: "172.20.10.1",
+					Type:           corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and specify pd service overwrite",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
+							ClusterIP: pointer.StringPtr("172.20.10.1")},
+					},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "172.20.10.1",
+					Type:      corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and specify pd service portname",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
+					},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
+							ClusterIP: pointer.StringPtr("172.20.10.1"),
+							PortName:  pointer.StringPtr("http-pd"),
+						},
+					},
+
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "172.20.10.1",
+					Type:      corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http-pd",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and specify pd service external ips",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
+					},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
+							ClusterIP:   pointer.StringPtr("172.20.10.1"),
+							ExternalIPs: []string{"192.168.1.10", "192.168.1.11"},
+						},
+					},
+
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP:   "172.20.10.1",
+					Type:        corev1.ServiceTypeClusterIP,
+					ExternalIPs: []string{"192.168.1.10", "192.168.1.11"},
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+		{
+			name: "basic and specify pd service internal traffic policy",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					Services: []v1alpha1.Service{
+						{Name: "pd", Type: string(corev1.ServiceTypeLoadBalancer)},
+					},
+					PD: &v1alpha1.PDSpec{
+						Service: &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeClusterIP,
+							ClusterIP:             pointer.StringPtr("172.20.10.1"),
+							InternalTrafficPolicy: &localPolicy,
+						},
+					},
+
+					TiDB: &v1alpha1.TiDBSpec{
+						TLSClient: &v1alpha1.TiDBTLSClient{
+							Enabled: true,
+						},
+					},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expected: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-pd",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+						"app.kubernetes.io/used-by":    "end-user",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP:             "172.20.10.1",
+					Type:                  corev1.ServiceTypeClusterIP,
+					InternalTrafficPolicy: &localPolicy,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "client",
+							Port:       v1alpha1.DefaultPDClientPort,
+							TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+					Selector: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "pd",
+					},
+				},
+			},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			pmm, _, _ := newFakePDMemberManager()
+			svc := pmm.getNewPDServiceForTidbCluster(&tt.tc)
+			if diff := cmp.Diff(tt.expected, *svc); diff != "" {
+				t.Errorf("unexpected Service (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDStsWhenPdNotJoinCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name                string
+		modify              func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer)
+		pdHealth            *pdapi.HealthInfo
+		tcStatusChange      func(cluster *v1alpha1.TidbCluster)
+		err                 bool
+		expectTidbClusterFn func(*GomegaWithT, *v1alpha1.TidbCluster)
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		tc := newTidbClusterForPD()
+		ns := tc.Namespace
+		tcName := tc.Name
+
+		pmm, podIndexer, pvcIndexer := newFakePDMemberManager()
+		fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+		pdClient := controller.NewFakePDClient(fakePDControl, tc)
+
+		pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+			return test.pdHealth, nil
+		})
+		pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &metapb.Cluster{Id: uint64(1)}, nil
+		})
+
+		err := pmm.Sync(tc)
+		g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+		_, err = pmm.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = pmm.deps.ServiceLister.Services(ns).Get(controller.PDPeerMemberName(tcName))
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+		g.Expect(err).NotTo(HaveOccurred())
+		if test.tcStatusChange != nil {
+			test.tcStatusChange(tc)
+		}
+		test.modify(tc, podIndexer, pvcIndexer)
+		err = pmm.syncPDStatefulSetForTidbCluster(tc)
+		if test.err {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		if test.expectTidbClusterFn != nil {
+			test.expectTidbClusterFn(g, tc)
+		}
+	}
+	tests := []testcase{
+		{
+			name: "add pd unjoin cluster member info ",
+			modify: func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer) {
+				var pods []*corev1.Pod
+				COUNT := 3
+				for ordinal := 0; ordinal < COUNT; ordinal++ {
+					pod := &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        ordinalPodName(v1alpha1.PDMemberType, cluster.GetName(), int32(ordinal)),
+							Namespace:   metav1.NamespaceDefault,
+							Annotations: map[string]string{},
+							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+						},
+					}
+					podIndexer.Add(pod)
+					pods = append(pods, pod)
+				}
+				for ordinal := 0; ordinal < COUNT; ordinal++ {
+					pvc1 := &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        ordinalPVCName(v1alpha1.PDMemberType, controller.PDMemberName(cluster.GetName()), int32(ordinal)),
+							Namespace:   metav1.NamespaceDefault,
+							Annotations: map[string]string{},
+							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+						},
+					}
+					pvc2 := pvc1.DeepCopy()
+					pvc1.Name = pvc1.Name + "-1"
+					pvc1.UID = pvc1.UID + "-1"
+					pvc2.Name = pvc2.Name + "-2"
+					pvc2.UID = pvc2.UID + "-2"
+					pod := pods[ordinal]
+					pvc1.ObjectMeta.Labels[label.AnnPodNameKey] = pod.GetName()
+					pvc2.ObjectMeta.Labels[label.AnnPodNameKey] = pod.GetName()
+					pod.Spec.Volumes = append(pod.Spec.Volumes,
+						corev1.Volume{
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc1.Name,
+								},
+							},
+						},
+						corev1.Volume{
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc2.Name,
+								},
+							},
+						})
+					pvcIndexer.Add(pvc1)
+					pvcIndexer.Add(pvc2)
+				}
+			},
+			pdHealth: &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0:2379"}, Health: false},
+				{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1:2379"}, Health: false},
+			}},
+			err: false,
+			expectTidbClusterFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.PD.UnjoinedMembers["test-pd-2"]).NotTo(BeNil())
+			},
+		},
+		{
+			name: "clear unjoin cluster member info when the member join the cluster ",
+			tcStatusChange: func(cluster *v1alpha1.TidbCluster) {
+				cluster.Status.PD.UnjoinedMembers = map[string]v1alpha1.UnjoinedMember{
+					"test-pd-0": {
+						PodName:   "test-pd-0",
+						CreatedAt: metav1.Now(),
+					},
+				}
+			},
+			modify: func(cluster *v1alpha1.TidbCluster, podIndexer cache.Indexer, pvcIndexer cache.Indexer) {
+				for ordinal := 0; ordinal < 3; ordinal++ {
+					pod := &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        ordinalPodName(v1alpha1.PDMemberType, cluster.GetName(), int32(ordinal)),
+							Namespace:   metav1.NamespaceDefault,
+							Annotations: map[string]string{},
+							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+						},
+					}
+					podIndexer.Add(pod)
+				}
+				for ordinal := 0; ordinal < 3; ordinal++ {
+					pvc := &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        ordinalPVCName(v1alpha1.PDMemberType, controller.PDMemberName(cluster.GetName()), int32(ordinal)),
+							Namespace:   metav1.NamespaceDefault,
+							Annotations: map[string]string{},
+							Labels:      label.New().Instance(cluster.GetInstanceName()).PD().Labels(),
+						},
+					}
+					pvcIndexer.Add(pvc)
+				}
+
+			},
+			pdHealth: &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: false},
+				{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: false},
+				{Name: "test-pd-2", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: false},
+			}},
+			err: false,
+			expectTidbClusterFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.PD.UnjoinedMembers).To(BeEmpty())
+				g.Expect(len(tc.Status.PD.Members)).To(Equal(3))
+			},
+		},
+	}
+	for i := range tests {
+		t.Logf("begin: %s", tests[i].name)
+		testFn(&tests[i], t)
+		t.Logf("end: %s", tests[i].name)
+	}
+}
+
+func TestPDShouldRecover(t *testing.T) {
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "failover-pd-0",
+				Namespace: v1.NamespaceDefault,
+			},
+			Status: v1.PodStatus{
+				Conditions: []v1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "failover-pd-1",
+				Namespace: v1.NamespaceDefault,
+			},
+			Status: v1.PodStatus{
+				Conditions: []v1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		},
+	}
+	podsWithFailover := append(pods, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "failover-pd-2",
+			Namespace: v1.NamespaceDefault,
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	})
+	tests := []struct {
+		name string
+		tc   *v1alpha1.TidbCluster
+		pods []*v1.Pod
+		want bool
+	}{
+		{
+			name: "should not recover if no failure members",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Status: v1alpha1.TidbClusterStatus{},
+			},
+			pods: pods,
+			want: false,
+		},
+		{
+			name: "should not recover if a member is not healthy",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 2,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"failover-pd-0": {
+								Name:   "failover-pd-0",
+								Health: false,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: pods,
+			want: false,
+		},
+		{
+			name: "should recover if all members are ready and healthy",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 2,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"failover-pd-0": {
+								Name:   "failover-pd-0",
+								Health: true,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: pods,
+			want: true,
+		},
+		{
+			name: "should recover if all members are ready and healthy (ignore auto-created failover pods)",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 2,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"failover-pd-0": {
+								Name:   "failover-pd-0",
+								Health: true,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+							"failover-pd-2": {
+								Name:   "failover-pd-1",
+								Health: false,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: podsWithFailover,
+			want: true,
+		},
+		{
+			name: "Pod is not ready",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 3,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"failover-pd-0": {
+								Name:   "failover-pd-0",
+								Health: true,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: podsWithFailover,
+			want: false,
+		},
+		{
+			name: "shouldn't recover when replicas is more than PD members number",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 3,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"failover-pd-0": {
+								Name:   "failover-pd-0",
+								Health: true,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: pods,
+			want: false,
+		},
+		{
+			name: "PD url is misleading",
+			tc: &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failover",
+					Namespace: v1.NamespaceDefault,
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 2,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"err-pd-0": {
+								Name:   "err-pd-0",
+								Health: true,
+							},
+							"failover-pd-1": {
+								Name:   "failover-pd-1",
+								Health: true,
+							},
+						},
+						FailureMembers: map[string]v1alpha1.PDFailureMember{
+							"failover-pd-0": {
+								PodName: "failover-pd-0",
+							},
+						},
+					},
+				},
+			},
+			pods: []*v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "failover-pd-0",
+						Namespace: v1.NamespaceDefault,
+					},
+					Status: v1.PodStatus{
+						Conditions: []v1.PodCondition{
+							{
+								Type:   corev1.PodReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "err-pd-1",
+						Namespace: v1.NamespaceDefault,
+					},
+					Status: v1.PodStatus{
+						Conditions: []v1.PodCondition{
+							{
+								Type:   corev1.PodReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			fakeDeps := controller.NewFakeDependencies()
+			for _, pod := range tt.pods {
+				fakeDeps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			}
+			kubeInformerFactory := fakeDeps.KubeInformerFactory
+			kubeInformerFactory.Start(ctx.Done())
+			kubeInformerFactory.WaitForCacheSync(ctx.Done())
+			pdMemberManager := &pdMemberManager{deps: fakeDeps}
+			got := pdMemberManager.shouldRecover(tt.tc)
+			if got != tt.want {
+				t.Fatalf("wants %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func hasTLSVol(sts *apps.StatefulSet) bool {
+	for _, vol := range sts.Spec.Template.Spec.Volumes {
+		if vol.Name == "tidb-client-tls" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTLSVolMount(sts *apps.StatefulSet) bool {
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == v1alpha1.PDMemberType.String() {
+			for _, vm := range container.VolumeMounts {
+				if vm.Name == "tidb-client-tls" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func mustPDConfig(x interface{}) *v1alpha1.PDConfigWraper {
+	data, err := toml.Marshal(x)
+	if err != nil {
+		panic(err)
+	}
+
+	c := v1alpha1.NewPDConfig()
+	c.UnmarshalTOML(data)
+
+	return c
+}
+
+func TestPDMemberManagerPinRevision(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newRevision := func(name string, set *apps.StatefulSet) *apps.ControllerRevision {
+		data, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": set.Spec.Template,
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return &apps.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       set.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(set, apps.SchemeGroupVersion.WithKind("StatefulSet"))},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: 1,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		revision    func(set *apps.StatefulSet) *apps.ControllerRevision
+		errExpectFn func(*GomegaWithT, error)
+		setExpectFn func(*GomegaWithT, *apps.StatefulSet)
+	}{
+		{
+			name: "pinned revision blocks forward rollout",
+			revision: func(set *apps.StatefulSet) *apps.ControllerRevision {
+				return newRevision("test-pd-1", set)
+			},
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+			setExpectFn: func(g *GomegaWithT, set *apps.StatefulSet) {
+				g.Expect(set.Spec.Template.Spec.Containers[0].Image).To(Equal("pd-test-image"))
+				g.Expect(set.Spec.UpdateStrategy.Type).To(Equal(apps.OnDeleteStatefulSetStrategyType))
+				g.Expect(set.Spec.UpdateStrategy.RollingUpdate).To(BeNil())
+			},
+		},
+		{
+			name: "pinned revision does not exist",
+			revision: func(set *apps.StatefulSet) *apps.ControllerRevision {
+				return nil
+			},
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("does not exist"))
+			},
+		},
+		{
+			name: "pinned revision is not controlled by pd statefulset",
+			revision: func(set *apps.StatefulSet) *apps.ControllerRevision {
+				revision := newRevision("test-pd-1", set)
+				revision.OwnerReferences = nil
+				return revision
+			},
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("is not controlled by"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			pmm, _, _ := newFakePDMemberManager()
+
+			oldSet, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			oldSet.UID = types.UID("test-pd")
+			if revision := tt.revision(oldSet); revision != nil {
+				_, err = pmm.deps.KubeClientset.AppsV1().ControllerRevisions(tc.Namespace).Create(context.TODO(), revision, metav1.CreateOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			tc.Spec.PD.Image = "pd-test-image-new"
+			newSet, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pd-test-image-new"))
+
+			err = pmm.pinPDStatefulSetToRevision(tc, oldSet, newSet, "test-pd-1")
+			tt.errExpectFn(g, err)
+			if tt.setExpectFn != nil {
+				tt.setExpectFn(g, newSet)
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncWithServerSideApply(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	ns := tc.Namespace
+	tcName := tc.Name
+
+	pmm, _, _ := newFakePDMemberManager()
+	pmm.deps.CLIConfig.PDServerSideApply = true
+
+	err := pmm.Sync(tc)
+	errExpectRequeue(g, err)
+
+	// the changes are detected by the managed fields instead of the last applied config
+	for _, name := range []string{controller.PDMemberName(tcName), controller.PDPeerMemberName(tcName)} {
+		svc, err := pmm.deps.ServiceLister.Services(ns).Get(name)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(svc.Annotations).NotTo(HaveKey(controller.LastAppliedConfigAnnotation))
+	}
+
+	cms, err := pmm.deps.ConfigMapLister.ConfigMaps(ns).List(labels.Everything())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cms).To(HaveLen(1))
+
+	set, err := pmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMemberName(tcName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Annotations).NotTo(HaveKey(controller.LastAppliedConfigAnnotation))
+	g.Expect(set.Annotations).To(HaveKeyWithValue(label.AnnoOwnerGeneration, strconv.FormatInt(tc.Generation, 10)))
+
+	// applying again updates the existing service in place
+	tc.Spec.PD.Service = &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeNodePort}
+	g.Expect(pmm.syncPDServiceForTidbCluster(tc)).To(Succeed())
+	svc, err := pmm.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+}
+
+func TestPDMemberManagerCheckStorageShrink(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name       string
+		request    string
+		capacity   string
+		pvcRequest string
+		event      bool
+	}{
+		{
+			name:       "storage request is smaller than pvc request",
+			request:    "50Gi",
+			capacity:   "100Gi",
+			pvcRequest: "100Gi",
+			event:      true,
+		},
+		{
+			name:       "storage request is smaller than pvc request when capacity is unknown",
+			request:    "50Gi",
+			pvcRequest: "100Gi",
+			event:      true,
+		},
+		{
+			name:       "storage request is equal to pvc request",
+			request:    "100Gi",
+			capacity:   "100Gi",
+			pvcRequest: "100Gi",
+		},
+		{
+			name:       "storage request is equal to pvc request but smaller than the rounded up capacity",
+			request:    "10Gi",
+			capacity:   "10752Mi",
+			pvcRequest: "10Gi",
+		},
+		{
+			name:       "storage request is larger than pvc request",
+			request:    "200Gi",
+			capacity:   "100Gi",
+			pvcRequest: "100Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Requests[corev1.ResourceStorage] = resource.MustParse(tt.request)
+			pmm, _, pvcIndexer := newFakePDMemberManager()
+
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ordinalPVCName(v1alpha1.PDMemberType, controller.PDMemberName(tc.GetName()), 0),
+					Namespace: metav1.NamespaceDefault,
+					Labels:    label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse(tt.pvcRequest),
+						},
+					},
+				},
+			}
+			if tt.capacity != "" {
+				pvc.Status.Capacity = corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(tt.capacity),
+				}
+			}
+			g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+
+			// the shrink is reported without failing the sync
+			g.Expect(pmm.checkPDStorageShrink(tc)).To(Succeed())
+
+			recorder := pmm.deps.Recorder.(*record.FakeRecorder)
+			if tt.event {
+				g.Expect(recorder.Events).To(HaveLen(1))
+				g.Expect(<-recorder.Events).To(ContainSubstring("FailedShrinkPDStorage"))
+			} else {
+				g.Expect(recorder.Events).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerCheckVersionTransition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		fromImage   string
+		toImage     string
+		skipCheck   bool
+		expectError bool
+	}{
+		{
+			name:      "upgrade to a later minor version",
+			fromImage: "pingcap/pd:v6.5.0",
+			toImage:   "pingcap/pd:v7.1.0",
+		},
+		{
+			name:      "upgrade from v4.0 to a later major version",
+			fromImage: "pingcap/pd:v4.0.16",
+			toImage:   "pingcap/pd:v7.5.0",
+		},
+		{
+			name:      "downgrade to an earlier patch version",
+			fromImage: "pingcap/pd:v7.1.2",
+			toImage:   "pingcap/pd:v7.1.1",
+		},
+		{
+			name:        "downgrade to an earlier minor version",
+			fromImage:   "pingcap/pd:v7.5.0",
+			toImage:     "pingcap/pd:v7.1.0",
+			expectError: true,
+		},
+		{
+			name:        "upgrade from v3.0 to v5.0 directly",
+			fromImage:   "pingcap/pd:v3.0.20",
+			toImage:     "pingcap/pd:v5.0.0",
+			expectError: true,
+		},
+		{
+			name:      "upgrade from v3.0 to v5.0 directly with the check skipped",
+			fromImage: "pingcap/pd:v3.0.20",
+			toImage:   "pingcap/pd:v5.0.0",
+			skipCheck: true,
+		},
+		{
+			name:      "version is not semantic versioning compatible",
+			fromImage: "pingcap/pd:v7.5.0",
+			toImage:   "pingcap/pd:nightly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Image = tt.fromImage
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.Spec.PD.Image = tt.toImage
+			if tt.skipCheck {
+				tc.Annotations = map[string]string{label.AnnPDSkipVersionCheck: "true"}
+			}
+			pmm, _, _ := newFakePDMemberManager()
+			err = pmm.checkPDVersionTransition(tc, set)
+
+			recorder := pmm.deps.Recorder.(*record.FakeRecorder)
+			if tt.expectError {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+				g.Expect(recorder.Events).To(HaveLen(1))
+				g.Expect(<-recorder.Events).To(ContainSubstring("FailedUpgradePD"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(recorder.Events).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestGetNewPDLeaderServiceForTidbCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+
+	svc := getNewPDLeaderServiceForTidbCluster(tc)
+	g.Expect(svc.Name).To(Equal(controller.PDLeaderMemberName(tc.Name)))
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+	g.Expect(svc.Spec.Ports).To(HaveLen(1))
+	g.Expect(svc.Spec.Ports[0].Port).To(Equal(v1alpha1.DefaultPDClientPort))
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.PDLeaderLabelKey, "true"))
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ComponentLabelKey, label.PDLabelVal))
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.InstanceLabelKey, tc.GetInstanceName()))
+}
+
+func TestPDMemberManagerSyncPDLeaderService(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+	pmm, _, _ := newFakePDMemberManager()
+	svcName := controller.PDLeaderMemberName(tc.Name)
+
+	g.Expect(pmm.syncPDLeaderServiceForTidbCluster(tc)).To(Succeed())
+	svc, err := pmm.deps.ServiceLister.Services(tc.Namespace).Get(svcName)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the leader service is deleted when disabled
+	tc.Spec.PD.EnableLeaderService = nil
+	g.Expect(pmm.syncPDLeaderServiceForTidbCluster(tc)).To(Succeed())
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(svcName)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the service not created by the operator is never deleted
+	foreign := svc.DeepCopy()
+	foreign.OwnerReferences = nil
+	g.Expect(pmm.deps.KubeInformerFactory.Core().V1().Services().Informer().GetIndexer().Add(foreign)).To(Succeed())
+	g.Expect(pmm.syncPDLeaderServiceForTidbCluster(tc)).To(Succeed())
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(svcName)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestPDPprofPort(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.PprofPort = pointer.Int32Ptr(6060)
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	// nothing listens on the pprof port in the PD container
+	for _, port := range findPDContainer(tc, set).Ports {
+		g.Expect(port.Name).NotTo(Equal("pprof"))
+	}
+
+	// the pprof service is only maintained when enabled
+	pmm, _, _ := newFakePDMemberManager()
+	g.Expect(pmm.syncPDPprofServiceForTidbCluster(tc)).To(Succeed())
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDPprofMemberName(tc.Name))
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	tc.Spec.PD.Service = &v1alpha1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}
+	tc.Spec.PD.EnablePprofService = pointer.BoolPtr(true)
+	g.Expect(pmm.syncPDPprofServiceForTidbCluster(tc)).To(Succeed())
+	svc, err := pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDPprofMemberName(tc.Name))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Name).To(Equal("test-pd-pprof"))
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+	g.Expect(svc.Spec.Ports).To(Equal([]corev1.ServicePort{{
+		Name:       "pprof",
+		Port:       6060,
+		TargetPort: intstr.FromInt(int(v1alpha1.DefaultPDClientPort)),
+		Protocol:   corev1.ProtocolTCP,
+	}}))
+	g.Expect(svc.Spec.Selector).To(Equal(label.New().Instance(tc.GetInstanceName()).PD().Labels()))
+	g.Expect(metav1.IsControlledBy(svc, tc)).To(BeTrue())
+
+	// the pprof service is deleted when disabled
+	tc.Spec.PD.EnablePprofService = pointer.BoolPtr(false)
+	g.Expect(pmm.syncPDPprofServiceForTidbCluster(tc)).To(Succeed())
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDPprofMemberName(tc.Name))
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the service not created by the operator is never deleted
+	svcIndexer := pmm.deps.KubeInformerFactory.Core().V1().Services().Informer().GetIndexer()
+	foreign := svc.DeepCopy()
+	foreign.OwnerReferences = nil
+	g.Expect(svcIndexer.Add(foreign)).To(Succeed())
+	g.Expect(pmm.syncPDPprofServiceForTidbCluster(tc)).To(Succeed())
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDPprofMemberName(tc.Name))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestPDMemberManagerSyncPDLeaderLabel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		leader        string
+		peerLeader    bool
+		expectLeaders []string
+	}{
+		{
+			name:          "leader label moves to the new leader",
+			enabled:       true,
+			leader:        "test-pd-1",
+			expectLeaders: []string{"test-pd-1"},
+		},
+		{
+			name:          "leader label is kept on the current leader",
+			enabled:       true,
+			leader:        "test-pd-0",
+			expectLeaders: []string{"test-pd-0"},
+		},
+		{
+			name:          "leader label is removed when leader is unknown",
+			enabled:       true,
+			leader:        "",
+			expectLeaders: []string{},
+		},
+		{
+			name:          "leader label moves to the new leader named with cluster domain",
+			enabled:       true,
+			leader:        "test-pd-2.test-pd-peer.default.svc.cluster.local",
+			expectLeaders: []string{"test-pd-2"},
+		},
+		{
+			name:          "leader label is removed when leader is in the peer cluster",
+			enabled:       true,
+			leader:        "test-pd-1.test-pd-peer.other.svc.cluster.local",
+			peerLeader:    true,
+			expectLeaders: []string{},
+		},
+		{
+			name:          "leader service is disabled",
+			enabled:       false,
+			leader:        "test-pd-1",
+			expectLeaders: []string{"test-pd-0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(tt.enabled)
+			tc.Status.PD.Leader = v1alpha1.PDMember{Name: tt.leader}
+			if tt.peerLeader {
+				tc.Status.PD.PeerMembers = map[string]v1alpha1.PDMember{tt.leader: tc.Status.PD.Leader}
+			}
+			pmm, podIndexer, _ := newFakePDMemberManager()
+
+			for ordinal := 0; ordinal < 3; ordinal++ {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), int32(ordinal)),
+						Namespace: metav1.NamespaceDefault,
+						Labels:    label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+					},
+				}
+				if ordinal == 0 {
+					pod.Labels[label.PDLeaderLabelKey] = "true"
+				}
+				g.Expect(podIndexer.Add(pod)).To(Succeed())
+			}
+
+			g.Expect(pmm.syncPDLeaderLabel(tc)).To(Succeed())
+
+			selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+			g.Expect(err).NotTo(HaveOccurred())
+			pods, err := pmm.deps.PodLister.Pods(metav1.NamespaceDefault).List(selector)
+			g.Expect(err).NotTo(HaveOccurred())
+			leaders := []string{}
+			for _, pod := range pods {
+				if pod.Labels[label.PDLeaderLabelKey] == "true" {
+					leaders = append(leaders, pod.Name)
+				}
+			}
+			g.Expect(leaders).To(ConsistOf(tt.expectLeaders))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDLeaderLabelAcrossLeaderChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+	pmm, podIndexer, _ := newFakePDMemberManager()
+	for ordinal := 0; ordinal < 3; ordinal++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), int32(ordinal)),
+				Namespace: metav1.NamespaceDefault,
+				Labels:    label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+			},
+		}
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+
+	getLeaders := func() []string {
+		selector, err := label.New().Instance(tc.GetInstanceName()).PD().Selector()
+		g.Expect(err).NotTo(HaveOccurred())
+		pods, err := pmm.deps.PodLister.Pods(metav1.NamespaceDefault).List(selector)
+		g.Expect(err).NotTo(HaveOccurred())
+		leaders := []string{}
+		for _, pod := range pods {
+			if pod.Labels[label.PDLeaderLabelKey] == "true" {
+				leaders = append(leaders, pod.Name)
+			}
+		}
+		return leaders
+	}
+
+	steps := []struct {
+		leader        string
+		expectLeaders []string
+	}{
+		{leader: "test-pd-0", expectLeaders: []string{"test-pd-0"}},
+		{leader: "test-pd-1", expectLeaders: []string{"test-pd-1"}},
+		{leader: "", expectLeaders: []string{}},
+		{leader: "test-pd-2", expectLeaders: []string{"test-pd-2"}},
+		{leader: "test-pd-2", expectLeaders: []string{"test-pd-2"}},
+	}
+	for _, step := range steps {
+		tc.Status.PD.Leader = v1alpha1.PDMember{Name: step.leader}
+		g.Expect(pmm.syncPDLeaderLabel(tc)).To(Succeed())
+		g.Expect(getLeaders()).To(ConsistOf(step.expectLeaders), "leader: %q", step.leader)
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapFrom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newConfigFromTC := func(optional bool) *v1alpha1.TidbCluster {
+		tc := newTidbClusterForPD()
+		tc.Spec.PD.ConfigFrom = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "pd-config"},
+			Key:                  "pd.toml",
+			Optional:             pointer.BoolPtr(optional),
+		}
+		return tc
+	}
+	newRefCm := func(content string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pd-config",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Data: map[string]string{"pd.toml": content},
+		}
+	}
+
+	t.Run("referenced config is used and changes are detected", func(t *testing.T) {
+		tc := newConfigFromTC(false)
+		updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+		tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+		pmm, _, _ := newFakePDMemberManager()
+		cmIndexer := pmm.deps.KubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+		refCm := newRefCm("lease = 5\n")
+		g.Expect(cmIndexer.Add(refCm)).To(Succeed())
+
+		cm, err := pmm.syncPDConfigMap(tc, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cm.Data["config-file"]).To(ContainSubstring("lease = 5"))
+		g.Expect(tc.Spec.PD.Config).To(BeNil())
+
+		refCm = newRefCm("lease = 10\n")
+		g.Expect(cmIndexer.Update(refCm)).To(Succeed())
+
+		newCm, err := pmm.syncPDConfigMap(tc, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(newCm.Data["config-file"]).To(ContainSubstring("lease = 10"))
+		g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+	})
+
+	t.Run("referenced configmap does not exist", func(t *testing.T) {
+		tc := newConfigFromTC(false)
+		pmm, _, _ := newFakePDMemberManager()
+		_, err := pmm.syncPDConfigMap(tc, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("failed to get configmap pd-config"))
+	})
+
+	t.Run("referenced key does not exist", func(t *testing.T) {
+		tc := newConfigFromTC(false)
+		tc.Spec.PD.ConfigFrom.Key = "unknown"
+		pmm, _, _ := newFakePDMemberManager()
+		cmIndexer := pmm.deps.KubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+		g.Expect(cmIndexer.Add(newRefCm("lease = 5\n"))).To(Succeed())
+		_, err := pmm.syncPDConfigMap(tc, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("key unknown does not exist"))
+	})
+
+	t.Run("optional referenced configmap does not exist", func(t *testing.T) {
+		tc := newConfigFromTC(true)
+		pmm, _, _ := newFakePDMemberManager()
+		cm, err := pmm.syncPDConfigMap(tc, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cm).NotTo(BeNil())
+	})
+
+	t.Run("items in the referenced config take precedence over the schedule fields", func(t *testing.T) {
+		tc := newConfigFromTC(false)
+		tc.Spec.PD.HotRegionScheduleLimit = pointer.Int64Ptr(8)
+		pmm, _, _ := newFakePDMemberManager()
+		cmIndexer := pmm.deps.KubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+		g.Expect(cmIndexer.Add(newRefCm("[schedule]\nhot-region-schedule-limit = 4\n"))).To(Succeed())
+		configSource, err := pmm.getPDConfigSource(tc)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(getPDScheduleConfig(configSource).HotRegionScheduleLimit).To(BeNil())
+		g.Expect(tc.Spec.PD.Config).To(BeNil())
+	})
+}
+
+func TestPDMemberManagerSyncPDConfigMapPendingUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.Config.Set("lease", 5)
+	pmm, _, _ := newFakePDMemberManager()
+
+	// the statefulset doesn't exist yet
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeFalse())
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the config is not changed
+	_, err = pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeFalse())
+	g.Expect(tc.Status.PD.PendingConfigMapName).To(BeEmpty())
+
+	// the config is changed but the statefulset still uses the old configmap
+	tc.Spec.PD.Config.Set("lease", 10)
+	newCm, err := pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeTrue())
+	g.Expect(tc.Status.PD.PendingConfigMapName).To(Equal(newCm.Name))
+
+	// the statefulset uses the new configmap, but the pods are not all updated yet
+	set, err = getNewPDSetForTidbCluster(tc, newCm)
+	g.Expect(err).NotTo(HaveOccurred())
+	set.Status.Replicas = 3
+	set.Status.UpdatedReplicas = 1
+	set.Status.CurrentRevision = "pd-1"
+	set.Status.UpdateRevision = "pd-2"
+	_, err = pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeTrue())
+	g.Expect(tc.Status.PD.PendingConfigMapName).To(Equal(newCm.Name))
+
+	// all the pods are updated, but the revision is not switched yet
+	set.Status.UpdatedReplicas = 3
+	_, err = pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeTrue())
+
+	// the change is rolled out
+	set.Status.CurrentRevision = "pd-2"
+	_, err = pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeFalse())
+	g.Expect(tc.Status.PD.PendingConfigMapName).To(BeEmpty())
+}
+
+func TestGetPDResourceSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		resources     corev1.ResourceRequirements
+		replicas      int32
+		expectSummary string
+	}{
+		{
+			name:     "no cpu or memory is set",
+			replicas: 3,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("100Gi"),
+				},
+			},
+		},
+		{
+			name:     "requests are summed up",
+			replicas: 3,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			expectSummary: `{"replicas":3,"cpu":"3","memory":"6Gi","cpuCoreHoursPerMonth":2190,"memoryGiBHoursPerMonth":4380}`,
+		},
+		{
+			name:     "limits are used without requests",
+			replicas: 5,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1536Mi"),
+				},
+			},
+			expectSummary: `{"replicas":5,"cpu":"2500m","memory":"7680Mi","cpuCoreHoursPerMonth":1825,"memoryGiBHoursPerMonth":5475}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ResourceRequirements = tt.resources
+			tc.Spec.PD.Replicas = tt.replicas
+			g.Expect(getPDResourceSummary(tc)).To(Equal(tt.expectSummary))
+		})
+	}
+}
+
+func TestGetNewPDSetForTidbClusterWithResourceSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Annotations).To(HaveKeyWithValue(label.AnnPDResourceSummary,
+		`{"replicas":3,"cpu":"3","memory":"6Gi","cpuCoreHoursPerMonth":2190,"memoryGiBHoursPerMonth":4380}`))
+	// the summary is only metadata of the StatefulSet, it doesn't roll out the pods
+	g.Expect(set.Spec.Template.Annotations).NotTo(HaveKey(label.AnnPDResourceSummary))
+
+	// the summary is recomputed on the change of the resources and the replicas
+	tc.Spec.PD.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+	tc.Spec.PD.Replicas = 5
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Annotations).To(HaveKeyWithValue(label.AnnPDResourceSummary,
+		`{"replicas":5,"cpu":"10","memory":"10Gi","cpuCoreHoursPerMonth":7300,"memoryGiBHoursPerMonth":7300}`))
+
+	// the summary is removed if neither cpu nor memory is set
+	tc.Spec.PD.ResourceRequirements = corev1.ResourceRequirements{}
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Annotations).NotTo(HaveKey(label.AnnPDResourceSummary))
+}
+
+func TestGetNewPDSetForTidbClusterWithServiceAccountTokenAudience(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, vol := range set.Spec.Template.Spec.Volumes {
+		g.Expect(vol.Name).NotTo(Equal("pd-sa-token"))
+	}
+
+	tc.Spec.PD.ServiceAccountTokenAudience = pointer.StringPtr("sts.amazonaws.com")
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+		Name: "pd-sa-token",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "sts.amazonaws.com",
+						ExpirationSeconds: pointer.Int64Ptr(3600),
+						Path:              "token",
+					},
+				}},
+			},
+		},
+	}))
+	g.Expect(set.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name: "pd-sa-token", ReadOnly: true, MountPath: "/var/run/secrets/tokens",
+	}))
+}
+
+func TestGetNewPDSetForTidbClusterWithFlushMetricsOnShutdown(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		flush         *v1alpha1.PDFlushMetricsConfig
+		tls           bool
+		waitForJoin   bool
+		expectPreStop *corev1.LifecycleHandler
+	}{
+		{
+			name: "flush metrics is not set",
+		},
+		{
+			name:  "flush metrics with the defaults",
+			flush: &v1alpha1.PDFlushMetricsConfig{PushgatewayURL: "http://pushgateway:9091/"},
+			expectPreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c",
+					`curl --silent --fail http://127.0.0.1:2379/metrics --max-time 5 | ` +
+						`curl --silent --fail --max-time 5 --data-binary @- "http://pushgateway:9091/metrics/job/pd/instance/${POD_NAME:-$(hostname)}" || true`,
+				}},
+			},
+		},
+		{
+			name: "flush metrics with tls and the post start hook",
+			flush: &v1alpha1.PDFlushMetricsConfig{
+				PushgatewayURL: "https://pushgateway:9091",
+				Job:            pointer.StringPtr("pd-final"),
+				TimeoutSeconds: pointer.Int32Ptr(3),
+			},
+			tls:         true,
+			waitForJoin: true,
+			expectPreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c",
+					`curl --silent --fail https://127.0.0.1:2379/metrics --cacert /var/lib/pd-tls/ca.crt --cert /var/lib/pd-tls/tls.crt --key /var/lib/pd-tls/tls.key --max-time 3 | ` +
+						`curl --silent --fail --max-time 3 --data-binary @- "https://pushgateway:9091/metrics/job/pd-final/instance/${POD_NAME:-$(hostname)}" || true`,
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.FlushMetricsOnShutdown = tt.flush
+			if tt.tls {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+			}
+			tc.Spec.PD.PostStartWaitForJoin = pointer.BoolPtr(tt.waitForJoin)
+
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			lifecycle := set.Spec.Template.Spec.Containers[0].Lifecycle
+			if tt.expectPreStop == nil {
+				g.Expect(lifecycle).To(BeNil())
+				return
+			}
+			g.Expect(lifecycle.PreStop).To(Equal(tt.expectPreStop))
+			// the post start hook is kept
+			if tt.waitForJoin {
+				g.Expect(lifecycle.PostStart).NotTo(BeNil())
+			} else {
+				g.Expect(lifecycle.PostStart).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestAppendPDPreStopScript(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	flush := `curl --silent --fail http://127.0.0.1:2379/metrics | curl --data-binary @- "http://pushgateway:9091/metrics/job/pd" || true`
+	tests := []struct {
+		name          string
+		preStop       *corev1.LifecycleHandler
+		expectPreStop *corev1.LifecycleHandler
+	}{
+		{
+			name: "no preStop hook",
+			expectPreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c", flush}},
+			},
+		},
+		{
+			name: "after the leader transfer script",
+			preStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "curl -X POST http://127.0.0.1:2379/pd/api/v1/leader/resign"}},
+			},
+			expectPreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c",
+					"curl -X POST http://127.0.0.1:2379/pd/api/v1/leader/resign; " + flush}},
+			},
+		},
+		{
+			name: "after the leader transfer command",
+			preStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"/pd-ctl", "member", "leader", "resign", "it's"}},
+			},
+			expectPreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c",
+					`'/pd-ctl' 'member' 'leader' 'resign' 'it'\''s'; ` + flush}},
+			},
+		},
+		{
+			name: "not an exec action",
+			preStop: &corev1.LifecycleHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/shutdown"},
+			},
+			expectPreStop: &corev1.LifecycleHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/shutdown"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := &corev1.Container{Name: "pd"}
+			if tt.preStop != nil {
+				container.Lifecycle = &corev1.Lifecycle{PreStop: tt.preStop}
+			}
+			appendPDPreStopScript(container, flush)
+			g.Expect(container.Lifecycle.PreStop).To(Equal(tt.expectPreStop))
+		})
+	}
+}
+
+func TestGetNewPDSetForTidbClusterWithHugePages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, vol := range set.Spec.Template.Spec.Volumes {
+		g.Expect(vol.Name).NotTo(HavePrefix(pdHugePagesVolumeName))
+	}
+
+	// the hugepages are requested by the container, the limits default to the requests
+	tc.Spec.PD.Requests["hugepages-2Mi"] = resource.MustParse("1Gi")
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	container := set.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceName("hugepages-2Mi"), resource.MustParse("1Gi")))
+	g.Expect(container.Resources.Limits).To(HaveKeyWithValue(corev1.ResourceName("hugepages-2Mi"), resource.MustParse("1Gi")))
+	g.Expect(container.Resources.Requests).NotTo(HaveKey(corev1.ResourceStorage))
+	g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "hugepages", MountPath: "/dev/hugepages"}))
+	g.Expect(set.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+		Name: "hugepages",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumHugePages},
+		},
+	}))
+
+	// a volume is created for each page size
+	tc.Spec.PD.Limits = corev1.ResourceList{"hugepages-1Gi": resource.MustParse("2Gi")}
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	container = set.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Resources.Limits).To(HaveKeyWithValue(corev1.ResourceName("hugepages-1Gi"), resource.MustParse("2Gi")))
+	g.Expect(container.Resources.Limits).To(HaveKeyWithValue(corev1.ResourceName("hugepages-2Mi"), resource.MustParse("1Gi")))
+	g.Expect(container.VolumeMounts).To(ContainElements(
+		corev1.VolumeMount{Name: "hugepages-1gi", MountPath: "/dev/hugepages-1Gi"},
+		corev1.VolumeMount{Name: "hugepages-2mi", MountPath: "/dev/hugepages-2Mi"},
+	))
+	g.Expect(set.Spec.Template.Spec.Volumes).To(ContainElements(
+		corev1.Volume{
+			Name: "hugepages-1gi",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: "HugePages-1Gi"},
+			},
+		},
+		corev1.Volume{
+			Name: "hugepages-2mi",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: "HugePages-2Mi"},
+			},
+		},
+	))
+}
+
+func TestPDMemberManagerVerifyPDConfigMapVolumes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, _, _ := newFakePDMemberManager()
+	cmIndexer := pmm.deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+	oldCm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-3961393", Namespace: tc.Namespace}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-6d7a7163", Namespace: tc.Namespace}}
+	oldSet, err := getNewPDSetForTidbCluster(tc, oldCm)
+	g.Expect(err).NotTo(HaveOccurred())
+	newSet, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pmm.verifyPDConfigMapVolumes(tc, newSet, cm, false)).To(Succeed())
+
+	// the pod template is overridden by the one referencing the old ConfigMap, e.g. the last applied one
+	newSet.Spec.Template.Spec = *oldSet.Spec.Template.Spec.DeepCopy()
+	err = pmm.verifyPDConfigMapVolumes(tc, newSet, cm, false)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("volume config references ConfigMap test-pd-3961393, expected ConfigMap test-pd-6d7a7163"))
+
+	// the old ConfigMap is expected while the template is replaced, but it must still exist
+	err = pmm.verifyPDConfigMapVolumes(tc, newSet, cm, true)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("ConfigMap test-pd-3961393 referenced by the pod template doesn't exist"))
+	g.Expect(cmIndexer.Add(oldCm)).To(Succeed())
+	g.Expect(pmm.verifyPDConfigMapVolumes(tc, newSet, cm, true)).To(Succeed())
+
+	// the startup script volume is overridden to another ConfigMap
+	tc.Spec.PD.AdditionalVolumes = []corev1.Volume{{
+		Name: "startup-script",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: oldCm.Name}},
+		},
+	}}
+	newSet, err = getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+	err = pmm.verifyPDConfigMapVolumes(tc, newSet, cm, false)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("volume startup-script references ConfigMap test-pd-3961393, expected ConfigMap test-pd-6d7a7163"))
+	err = pmm.verifyPDConfigMapVolumes(tc, newSet, cm, true)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("volume startup-script references ConfigMap test-pd-3961393, expected ConfigMap test-pd-6d7a7163"))
+
+	// nothing is checked without a ConfigMap synced
+	g.Expect(pmm.verifyPDConfigMapVolumes(tc, newSet, nil, false)).To(Succeed())
+}
+
+func TestGetNewPDSetForTidbClusterWithProjectedVolumeMounts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	tc.Spec.PD.MountClusterClientSecret = pointer.BoolPtr(true)
+	tc.Spec.PD.ProjectedVolumeMounts = pointer.BoolPtr(true)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-6d7a7163", Namespace: tc.Namespace}}
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tlsItems := func(dir string) []corev1.KeyToPath {
+		return []corev1.KeyToPath{
+			{Key: "ca.crt", Path: dir + "/ca.crt"},
+			{Key: "tls.crt", Path: dir + "/tls.crt"},
+			{Key: "tls.key", Path: dir + "/tls.key"},
+		}
+	}
+	var projected *corev1.Volume
+	for i, vol := range set.Spec.Template.Spec.Volumes {
+		g.Expect(vol.Name).NotTo(BeElementOf("config", "startup-script", "pd-tls", "cluster-client-tls"))
+		if vol.Name == pdProjectedVolumeName {
+			projected = &set.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	g.Expect(projected).NotTo(BeNil())
+	g.Expect(projected.Projected.Sources).To(Equal([]corev1.VolumeProjection{
+		{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+				Items:                []corev1.KeyToPath{{Key: "config-file", Path: "config/pd.toml"}},
+			},
+		},
+		{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+				Items:                []corev1.KeyToPath{{Key: "startup-script", Path: "startup-script/pd_start_script.sh"}},
+			},
+		},
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: tc.Name + "-pd-cluster-secret"},
+				Items:                tlsItems("pd-tls"),
+			},
+		},
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: tc.Name + "-cluster-client-secret"},
+				Items:                tlsItems("cluster-client-tls"),
+			},
+		},
+	}))
+
+	// the projected volume is mounted as a directory, because the files mounted by sub path are never updated
+	pdContainer := set.Spec.Template.Spec.Containers[0]
+	var projectedMounts []corev1.VolumeMount
+	for _, mount := range pdContainer.VolumeMounts {
+		g.Expect(mount.Name).NotTo(BeElementOf("config", "startup-script", "pd-tls", "cluster-client-tls"))
+		g.Expect(mount.SubPath).To(BeEmpty())
+		if mount.Name == pdProjectedVolumeName {
+			projectedMounts = append(projectedMounts, mount)
+		}
+	}
+	g.Expect(projectedMounts).To(Equal([]corev1.VolumeMount{
+		{Name: pdProjectedVolumeName, ReadOnly: true, MountPath: "/var/lib/pd-projected"},
+	}))
+
+	// the paths of the script, the config and the TLS certificates point to the directories in the projected volume
+	g.Expect(pdContainer.Command).To(Equal([]string{"/bin/sh", "/var/lib/pd-projected/startup-script/pd_start_script.sh"}))
+	g.Expect(getPDClusterTLSConfig(tc)).To(Equal(map[string]string{
+		"security.cacert-path": "/var/lib/pd-projected/pd-tls/ca.crt",
+		"security.cert-path":   "/var/lib/pd-projected/pd-tls/tls.crt",
+		"security.key-path":    "/var/lib/pd-projected/pd-tls/tls.key",
+	}))
+	g.Expect(buildPDProbeCurlCommand(tc, "/pd/api/v1/health")).To(ContainSubstring("--cacert /var/lib/pd-projected/pd-tls/ca.crt"))
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	pdCm, err := getPDConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pdCm.Data["config-file"]).To(ContainSubstring(`cacert-path = "/var/lib/pd-projected/pd-tls/ca.crt"`))
+	g.Expect(pdCm.Data["startup-script"]).To(ContainSubstring("--config=/var/lib/pd-projected/config/pd.toml"))
+
+	// the ConfigMap in the projected volume is still found and checked for the config rollout
+	g.Expect(checkPDConfigMapVolumes(set, cm.Name)).To(Succeed())
+	g.Expect(checkPDConfigMapVolumes(set, "test-pd-3961393")).NotTo(Succeed())
+	g.Expect(getPDConfigMapVolumeName(set)).To(Equal(cm.Name))
+	g.Expect(mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+		return strings.HasPrefix(name, controller.PDMemberName(tc.Name))
+	})).To(Equal(cm.Name))
+}
+
+func TestCheckPDConfigMapVolumes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	configMapVolume := func(name, cmName string) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: cmName}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		volumes   []corev1.Volume
+		expectErr string
+	}{
+		{
+			name:    "consistent",
+			volumes: []corev1.Volume{configMapVolume("config", "test-pd-new"), configMapVolume("startup-script", "test-pd-new")},
+		},
+		{
+			name:      "config references the old ConfigMap",
+			volumes:   []corev1.Volume{configMapVolume("config", "test-pd-old"), configMapVolume("startup-script", "test-pd-new")},
+			expectErr: "volume config references ConfigMap test-pd-old",
+		},
+		{
+			name:      "startup script references the old ConfigMap",
+			volumes:   []corev1.Volume{configMapVolume("config", "test-pd-new"), configMapVolume("startup-script", "test-pd-old")},
+			expectErr: "volume startup-script references ConfigMap test-pd-old",
+		},
+		{
+			name: "startup script isn't from a ConfigMap",
+			volumes: []corev1.Volume{
+				configMapVolume("config", "test-pd-new"),
+				{Name: "startup-script", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+			expectErr: "volume startup-script doesn't reference a ConfigMap",
+		},
+		{
+			name:      "startup script is missing",
+			volumes:   []corev1.Volume{configMapVolume("config", "test-pd-new")},
+			expectErr: "volume startup-script is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &apps.StatefulSet{}
+			set.Spec.Template.Spec.Volumes = tt.volumes
+			err := checkPDConfigMapVolumes(set, "test-pd-new")
+			if tt.expectErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.expectErr))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerRecordPDConfigRolloutResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, _, _ := newFakePDMemberManager()
+	setName := controller.PDMemberName(tc.Name)
+	updateErr := fmt.Errorf("API server failed")
+
+	// failures are not counted if no config change is pending
+	pmm.recordPDConfigRolloutResult(tc, setName, updateErr)
+	g.Expect(tc.Status.PD.ConfigRolloutFailures).To(BeZero())
+
+	tc.Status.PD.ConfigUpdatePending = true
+	tc.Status.PD.PendingConfigMapName = "test-pd-a1b2c3d4"
+	for i := 1; i < pdConfigRolloutFailureThreshold; i++ {
+		pmm.recordPDConfigRolloutResult(tc, setName, updateErr)
+		g.Expect(tc.Status.PD.ConfigRolloutFailures).To(Equal(int32(i)))
+	}
+	g.Expect(collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)).To(BeEmpty())
+
+	// the repeated failure is reported with both the configmap and the error
+	pmm.recordPDConfigRolloutResult(tc, setName, updateErr)
+	g.Expect(tc.Status.PD.ConfigRolloutFailures).To(Equal(int32(pdConfigRolloutFailureThreshold)))
+	events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring("FailedRolloutPDConfig"))
+	g.Expect(events[0]).To(ContainSubstring(setName))
+	g.Expect(events[0]).To(ContainSubstring("test-pd-a1b2c3d4"))
+	g.Expect(events[0]).To(ContainSubstring("API server failed"))
+
+	// the counter is reset once the statefulset is updated
+	pmm.recordPDConfigRolloutResult(tc, setName, nil)
+	g.Expect(tc.Status.PD.ConfigRolloutFailures).To(BeZero())
+}
+
+func TestPDMemberManagerSyncStatefulSetReportsConfigRolloutFailure(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.Config.Set("lease", 5)
+	pmm, _, _ := newFakePDMemberManager()
+	fakeSetControl := pmm.deps.StatefulSetControl.(*controller.FakeStatefulSetControl)
+
+	// create the statefulset with the initial configmap
+	err := pmm.syncPDStatefulSetForTidbCluster(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	tc.Spec.PD.Config.Set("lease", 10)
+	for i := 0; i < pdConfigRolloutFailureThreshold; i++ {
+		fakeSetControl.SetUpdateStatefulSetError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		g.Expect(pmm.syncPDStatefulSetForTidbCluster(tc)).To(HaveOccurred())
+	}
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeTrue())
+	g.Expect(tc.Status.PD.ConfigRolloutFailures).To(Equal(int32(pdConfigRolloutFailureThreshold)))
+	events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(ContainElement(And(
+		ContainSubstring("FailedRolloutPDConfig"),
+		ContainSubstring(tc.Status.PD.PendingConfigMapName),
+	)))
+}
+
+func TestPDMemberManagerSyncPDEffectiveConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.Config.Set("lease", 5)
+	tc.Spec.PD.ExportEffectiveConfig = pointer.BoolPtr(true)
+	pmm, _, _ := newFakePDMemberManager()
+	fakeCli := pmm.deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	key := client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDEffectiveConfigMapName(tc.Name)}
+
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	effectiveCm := &corev1.ConfigMap{}
+	g.Expect(fakeCli.Get(context.TODO(), key, effectiveCm)).To(Succeed())
+	g.Expect(effectiveCm.Name).To(Equal("test-pd-effective-config"))
+	g.Expect(metav1.IsControlledBy(effectiveCm, tc)).To(BeTrue())
+	g.Expect(effectiveCm.Data).To(HaveLen(1))
+	g.Expect(effectiveCm.Data["config-file"]).To(Equal(cm.Data["config-file"]))
+	g.Expect(effectiveCm.Data["config-file"]).To(ContainSubstring("lease = 5"))
+	g.Expect(effectiveCm.Data["config-file"]).To(ContainSubstring(`cacert-path = "/var/lib/pd-tls/ca.crt"`))
+
+	// the configmap is deleted when the export is disabled
+	tc.Spec.PD.ExportEffectiveConfig = pointer.BoolPtr(false)
+	cmIndexer := pmm.deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+	g.Expect(cmIndexer.Add(effectiveCm)).To(Succeed())
+	_, err = pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	err = fakeCli.Get(context.TODO(), key, &corev1.ConfigMap{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestGetPDConfigMapWithObservabilityEndpoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                   string
+		config                 map[string]interface{}
+		metricStorage          *string
+		dashboardAddress       *string
+		expectMetricStorage    string
+		expectDashboardAddress string
+	}{
+		{
+			name: "endpoints are not set",
+		},
+		{
+			name:                   "endpoints are mapped into config",
+			metricStorage:          pointer.StringPtr("http://prometheus.monitoring:9090"),
+			dashboardAddress:       pointer.StringPtr("none"),
+			expectMetricStorage:    "http://prometheus.monitoring:9090",
+			expectDashboardAddress: "none",
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"pd-server.metric-storage": "http://thanos.monitoring:9090",
+			},
+			metricStorage:          pointer.StringPtr("http://prometheus.monitoring:9090"),
+			dashboardAddress:       pointer.StringPtr("auto"),
+			expectMetricStorage:    "http://thanos.monitoring:9090",
+			expectDashboardAddress: "auto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.MetricStorage = tt.metricStorage
+			tc.Spec.PD.DashboardAddress = tt.dashboardAddress
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expect := range map[string]string{
+				"pd-server.metric-storage":    tt.expectMetricStorage,
+				"pd-server.dashboard-address": tt.expectDashboardAddress,
+			} {
+				if expect == "" {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).MustString()).To(Equal(expect), key)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithDisableDashboard(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                   string
+		config                 map[string]interface{}
+		image                  string
+		disableDashboard       *bool
+		expectDashboardAddress string
+	}{
+		{
+			name: "disable dashboard is not set",
+		},
+		{
+			name:             "dashboard is enabled",
+			disableDashboard: pointer.BoolPtr(false),
+		},
+		{
+			name:                   "dashboard is disabled",
+			disableDashboard:       pointer.BoolPtr(true),
+			expectDashboardAddress: "none",
+		},
+		{
+			name:             "dashboard is not served below v4.0",
+			image:            "pingcap/pd:v3.1.0",
+			disableDashboard: pointer.BoolPtr(true),
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"pd-server.dashboard-address": "auto",
+			},
+			disableDashboard:       pointer.BoolPtr(true),
+			expectDashboardAddress: "auto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			if tt.image != "" {
+				tc.Spec.PD.Image = tt.image
+			}
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.DisableDashboard = tt.disableDashboard
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectDashboardAddress == "" {
+				g.Expect(config.Get("pd-server.dashboard-address")).To(BeNil())
+			} else {
+				g.Expect(config.Get("pd-server.dashboard-address").MustString()).To(Equal(tt.expectDashboardAddress))
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithMinResolvedTSPersistenceInterval(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		interval       *string
+		expectInterval string
+	}{
+		{
+			name: "interval is not set",
+		},
+		{
+			name:           "interval is mapped into config",
+			interval:       pointer.StringPtr("10s"),
+			expectInterval: "10s",
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"pd-server.min-resolved-ts-persistence-interval": "1s",
+			},
+			interval:       pointer.StringPtr("10s"),
+			expectInterval: "1s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.MinResolvedTSPersistenceInterval = tt.interval
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectInterval == "" {
+				g.Expect(config.Get("pd-server.min-resolved-ts-persistence-interval")).To(BeNil())
+			} else {
+				g.Expect(config.Get("pd-server.min-resolved-ts-persistence-interval").MustString()).To(Equal(tt.expectInterval))
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithPeerURLScheme(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name               string
+		tlsEnabled         bool
+		scheme             *string
+		startScriptVersion v1alpha1.StartScriptVersion
+		expectPeerURL      string
+		expectClientURL    string
+	}{
+		{
+			name:            "peer url scheme follows the cluster",
+			expectPeerURL:   "--advertise-peer-urls=http://",
+			expectClientURL: "--advertise-client-urls=http://",
+		},
+		{
+			name:            "peer url scheme is https before tls is enabled",
+			scheme:          pointer.StringPtr("https"),
+			expectPeerURL:   "--advertise-peer-urls=https://",
+			expectClientURL: "--advertise-client-urls=http://",
+		},
+		{
+			name:            "peer url scheme is http after tls is enabled",
+			tlsEnabled:      true,
+			scheme:          pointer.StringPtr("http"),
+			expectPeerURL:   "--advertise-peer-urls=http://",
+			expectClientURL: "--advertise-client-urls=https://",
+		},
+		{
+			name:               "peer url scheme with start script v2",
+			scheme:             pointer.StringPtr("https"),
+			startScriptVersion: v1alpha1.StartScriptV2,
+			expectPeerURL:      "--advertise-peer-urls=https://",
+			expectClientURL:    "--advertise-client-urls=http://",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			if tt.tlsEnabled {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+			}
+			tc.Spec.PD.PeerURLScheme = tt.scheme
+			tc.Spec.StartScriptVersion = tt.startScriptVersion
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cm.Data["startup-script"]).To(ContainSubstring(tt.expectPeerURL))
+			g.Expect(cm.Data["startup-script"]).To(ContainSubstring(tt.expectClientURL))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithMinResolvedTSPersistenceIntervalChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.MinResolvedTSPersistenceInterval = pointer.StringPtr("1s")
+	pmm, _, _ := newFakePDMemberManager()
+
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// a change of the interval produces a new configmap, which rolls out the statefulset
+	tc.Spec.PD.MinResolvedTSPersistenceInterval = pointer.StringPtr("10s")
+	newCm, err := pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+	newSet, err := getNewPDSetForTidbCluster(tc, newCm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(checkPDConfigMapVolumes(newSet, newCm.Name)).To(Succeed())
+	g.Expect(checkPDConfigMapVolumes(set, newCm.Name)).NotTo(Succeed())
+}
+
+func TestGetPDConfigMapWithTSOIntervals(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                         string
+		config                       map[string]interface{}
+		saveInterval                 *string
+		updatePhysicalInterval       *string
+		expectSaveInterval           string
+		expectUpdatePhysicalInterval string
+	}{
+		{
+			name: "intervals are not set",
+		},
+		{
+			name:                         "intervals are mapped into config",
+			saveInterval:                 pointer.StringPtr("5s"),
+			updatePhysicalInterval:       pointer.StringPtr("10ms"),
+			expectSaveInterval:           "5s",
+			expectUpdatePhysicalInterval: "10ms",
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"tso-save-interval":            "1s",
+				"tso-update-physical-interval": "50ms",
+			},
+			saveInterval:                 pointer.StringPtr("5s"),
+			updatePhysicalInterval:       pointer.StringPtr("10ms"),
+			expectSaveInterval:           "1s",
+			expectUpdatePhysicalInterval: "50ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.TSOSaveInterval = tt.saveInterval
+			tc.Spec.PD.TSOUpdatePhysicalInterval = tt.updatePhysicalInterval
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectSaveInterval == "" {
+				g.Expect(config.Get("tso-save-interval")).To(BeNil())
+			} else {
+				g.Expect(config.Get("tso-save-interval").MustString()).To(Equal(tt.expectSaveInterval))
+			}
+			if tt.expectUpdatePhysicalInterval == "" {
+				g.Expect(config.Get("tso-update-physical-interval")).To(BeNil())
+			} else {
+				g.Expect(config.Get("tso-update-physical-interval").MustString()).To(Equal(tt.expectUpdatePhysicalInterval))
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithDashboardPublicPathPrefix(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name             string
+		config           map[string]interface{}
+		publicPathPrefix *string
+		expectPrefix     interface{}
+	}{
+		{
+			name: "public path prefix is not set",
+		},
+		{
+			name:             "public path prefix is mapped into config",
+			publicPathPrefix: pointer.StringPtr("/pd-dashboard"),
+			expectPrefix:     "/pd-dashboard",
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"dashboard.public-path-prefix": "/dashboard",
+			},
+			publicPathPrefix: pointer.StringPtr("/pd-dashboard"),
+			expectPrefix:     "/dashboard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.DashboardPublicPathPrefix = tt.publicPathPrefix
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectPrefix == nil {
+				g.Expect(config.Get("dashboard.public-path-prefix")).To(BeNil())
+			} else {
+				g.Expect(config.Get("dashboard.public-path-prefix").Interface()).To(Equal(tt.expectPrefix))
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithScheduleConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                     string
+		config                   map[string]interface{}
+		hotRegionScheduleLimit   *int64
+		splitMergeInterval       *string
+		expectHotRegionLimit     interface{}
+		expectSplitMergeInterval interface{}
+	}{
+		{
+			name: "schedule config is not set",
+		},
+		{
+			name:                     "schedule config is mapped into config",
+			hotRegionScheduleLimit:   pointer.Int64Ptr(8),
+			splitMergeInterval:       pointer.StringPtr("30m"),
+			expectHotRegionLimit:     int64(8),
+			expectSplitMergeInterval: "30m",
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"schedule.hot-region-schedule-limit": int64(4),
+				"schedule.split-merge-interval":      "1h",
+			},
+			hotRegionScheduleLimit:   pointer.Int64Ptr(8),
+			splitMergeInterval:       pointer.StringPtr("30m"),
+			expectHotRegionLimit:     int64(4),
+			expectSplitMergeInterval: "1h",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.HotRegionScheduleLimit = tt.hotRegionScheduleLimit
+			tc.Spec.PD.SplitMergeInterval = tt.splitMergeInterval
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expected := range map[string]interface{}{
+				"schedule.hot-region-schedule-limit": tt.expectHotRegionLimit,
+				"schedule.split-merge-interval":      tt.expectSplitMergeInterval,
+			} {
+				if expected == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).Interface()).To(Equal(expected), key)
+				}
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDScheduleConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                   string
+		hotRegionScheduleLimit *int64
+		splitMergeInterval     *string
+		config                 map[string]interface{}
+		paused                 bool
+		current                *pdapi.PDScheduleConfig
+		updateErr              error
+		expectErr              bool
+		expectCalls            []pdapi.PDScheduleConfig
+	}{
+		{
+			name:    "schedule config is not set",
+			current: &pdapi.PDScheduleConfig{HotRegionScheduleLimit: pointer.Uint64Ptr(4)},
+		},
+		{
+			name:                   "cluster is paused",
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			paused:                 true,
+			current:                &pdapi.PDScheduleConfig{HotRegionScheduleLimit: pointer.Uint64Ptr(4)},
+		},
+		{
+			name:                   "config is applied",
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			splitMergeInterval:     pointer.StringPtr("1h"),
+			current: &pdapi.PDScheduleConfig{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(8),
+				SplitMergeInterval:     "1h0m0s",
+			},
+		},
+		{
+			name:                   "config is hot reloaded",
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			splitMergeInterval:     pointer.StringPtr("30m"),
+			current: &pdapi.PDScheduleConfig{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(4),
+				SplitMergeInterval:     "1h0m0s",
+			},
+			expectCalls: []pdapi.PDScheduleConfig{{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(8),
+				SplitMergeInterval:     "30m",
+			}},
+		},
+		{
+			name:                   "explicit pd config is not overridden",
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			splitMergeInterval:     pointer.StringPtr("30m"),
+			config: map[string]interface{}{
+				"schedule.split-merge-interval": "1h",
+			},
+			current: &pdapi.PDScheduleConfig{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(4),
+				SplitMergeInterval:     "1h0m0s",
+			},
+			expectCalls: []pdapi.PDScheduleConfig{{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(8),
+			}},
+		},
+		{
+			name:                   "failed to hot reload config",
+			hotRegionScheduleLimit: pointer.Int64Ptr(8),
+			current:                &pdapi.PDScheduleConfig{},
+			updateErr:              fmt.Errorf("failed to update schedule config"),
+			expectErr:              true,
+			expectCalls: []pdapi.PDScheduleConfig{{
+				HotRegionScheduleLimit: pointer.Uint64Ptr(8),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.HotRegionScheduleLimit = tt.hotRegionScheduleLimit
+			tc.Spec.PD.SplitMergeInterval = tt.splitMergeInterval
+			if tt.config != nil {
+				tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+				for k, v := range tt.config {
+					tc.Spec.PD.Config.Set(k, v)
+				}
+			}
+			tc.Spec.Paused = tt.paused
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.PDConfigFromAPI{Schedule: tt.current}, nil
+			})
+			var calls []pdapi.PDScheduleConfig
+			pdClient.AddReaction(pdapi.UpdateScheduleActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls = append(calls, action.Schedule)
+				return nil, tt.updateErr
+			})
+
+			err := pmm.syncPDScheduleConfig(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(calls).To(Equal(tt.expectCalls))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithTSOIntervalsChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.TSOSaveInterval = pointer.StringPtr("3s")
+	tc.Spec.PD.TSOUpdatePhysicalInterval = pointer.StringPtr("50ms")
+	pmm, _, _ := newFakePDMemberManager()
+
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// a change of the intervals produces a new configmap, which rolls out the statefulset
+	tc.Spec.PD.TSOUpdatePhysicalInterval = pointer.StringPtr("10ms")
+	newCm, err := pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+	newSet, err := getNewPDSetForTidbCluster(tc, newCm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(checkPDConfigMapVolumes(newSet, newCm.Name)).To(Succeed())
+	g.Expect(checkPDConfigMapVolumes(set, newCm.Name)).NotTo(Succeed())
+}
+
+func TestGetPDConfigMapWithReplicationMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		config          map[string]interface{}
+		replicationMode *v1alpha1.PDReplicationModeConfig
+		expect          map[string]interface{}
+	}{
+		{
+			name: "replication mode is not set",
+			expect: map[string]interface{}{
+				"replication-mode.replication-mode": nil,
+			},
+		},
+		{
+			name: "majority is mapped into config",
+			replicationMode: &v1alpha1.PDReplicationModeConfig{
+				Mode: v1alpha1.PDReplicationModeMajority,
+			},
+			expect: map[string]interface{}{
+				"replication-mode.replication-mode":       "majority",
+				"replication-mode.dr-auto-sync.label-key": nil,
+			},
+		},
+		{
+			name: "dr-auto-sync is mapped into config",
+			replicationMode: &v1alpha1.PDReplicationModeConfig{
+				Mode:             v1alpha1.PDReplicationModeDRAutoSync,
+				LabelKey:         pointer.StringPtr("zone"),
+				Primary:          pointer.StringPtr("east"),
+				DR:               pointer.StringPtr("west"),
+				PrimaryReplicas:  pointer.Int32Ptr(2),
+				DRReplicas:       pointer.Int32Ptr(1),
+				WaitStoreTimeout: pointer.StringPtr("1m"),
+			},
+			expect: map[string]interface{}{
+				"replication-mode.replication-mode":                "dr-auto-sync",
+				"replication-mode.dr-auto-sync.label-key":          "zone",
+				"replication-mode.dr-auto-sync.primary":            "east",
+				"replication-mode.dr-auto-sync.dr":                 "west",
+				"replication-mode.dr-auto-sync.primary-replicas":   int64(2),
+				"replication-mode.dr-auto-sync.dr-replicas":        int64(1),
+				"replication-mode.dr-auto-sync.wait-store-timeout": "1m",
+			},
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"replication-mode.dr-auto-sync.wait-store-timeout": "5m",
+			},
+			replicationMode: &v1alpha1.PDReplicationModeConfig{
+				Mode:             v1alpha1.PDReplicationModeDRAutoSync,
+				LabelKey:         pointer.StringPtr("zone"),
+				Primary:          pointer.StringPtr("east"),
+				DR:               pointer.StringPtr("west"),
+				WaitStoreTimeout: pointer.StringPtr("1m"),
+			},
+			expect: map[string]interface{}{
+				"replication-mode.replication-mode":                "dr-auto-sync",
+				"replication-mode.dr-auto-sync.label-key":          "zone",
+				"replication-mode.dr-auto-sync.primary-replicas":   nil,
+				"replication-mode.dr-auto-sync.wait-store-timeout": "5m",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.ReplicationMode = tt.replicationMode
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expected := range tt.expect {
+				if expected == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).Interface()).To(Equal(expected), key)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithRegionMerge(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		regionMerge *v1alpha1.PDRegionMergeConfig
+		expect      map[string]interface{}
+	}{
+		{
+			name: "region merge is not set",
+			expect: map[string]interface{}{
+				"schedule.enable-cross-table-merge": nil,
+				"schedule.max-merge-region-size":    nil,
+				"schedule.max-merge-region-keys":    nil,
+			},
+		},
+		{
+			name: "region merge is mapped into config",
+			regionMerge: &v1alpha1.PDRegionMergeConfig{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionSize:    pointer.Int64Ptr(54),
+				MaxMergeRegionKeys:    pointer.Int64Ptr(540000),
+			},
+			expect: map[string]interface{}{
+				"schedule.enable-cross-table-merge": true,
+				"schedule.max-merge-region-size":    int64(54),
+				"schedule.max-merge-region-keys":    int64(540000),
+			},
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"schedule.max-merge-region-size": int64(20),
+			},
+			regionMerge: &v1alpha1.PDRegionMergeConfig{
+				EnableCrossTableMerge: pointer.BoolPtr(false),
+				MaxMergeRegionSize:    pointer.Int64Ptr(54),
+			},
+			expect: map[string]interface{}{
+				"schedule.enable-cross-table-merge": false,
+				"schedule.max-merge-region-size":    int64(20),
+				"schedule.max-merge-region-keys":    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.RegionMerge = tt.regionMerge
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expected := range tt.expect {
+				if expected == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).Interface()).To(Equal(expected), key)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithMaxGRPCMessageSize(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name       string
+		config     map[string]interface{}
+		size       *string
+		expectSend int64
+		expectRecv int64
+		expectErr  bool
+	}{
+		{
+			name: "size is not set",
+		},
+		{
+			name:       "size is mapped into config",
+			size:       pointer.StringPtr("16Mi"),
+			expectSend: 16 * 1024 * 1024,
+			expectRecv: 16 * 1024 * 1024,
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"pd-server.max-grpc-recv-msg-size": int64(64 * 1024 * 1024),
+			},
+			size:       pointer.StringPtr("16Mi"),
+			expectSend: 16 * 1024 * 1024,
+			expectRecv: 64 * 1024 * 1024,
+		},
+		{
+			name:      "invalid size",
+			size:      pointer.StringPtr("16 MiB"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.MaxGRPCMessageSize = tt.size
+
+			cm, err := getPDConfigMap(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expect := range map[string]int64{
+				"pd-server.max-grpc-send-msg-size": tt.expectSend,
+				"pd-server.max-grpc-recv-msg-size": tt.expectRecv,
+			} {
+				if expect == 0 {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).MustInt()).To(Equal(expect), key)
+				}
+			}
+		})
+	}
+
+	// the config changes with the size, which rolls out PD
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.MaxGRPCMessageSize = pointer.StringPtr("16Mi")
+	oldCm, err := getPDConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	tc.Spec.PD.MaxGRPCMessageSize = pointer.StringPtr("32Mi")
+	newCm, err := getPDConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Data["config-file"]).NotTo(Equal(oldCm.Data["config-file"]))
+}
+
+func TestGetPDConfigMapWithDisableTelemetry(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		disable       *bool
+		expectEnable  *bool
+		expectDisable *bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:    "telemetry is not disabled",
+			disable: pointer.BoolPtr(false),
+		},
+		{
+			name:          "telemetry is disabled",
+			disable:       pointer.BoolPtr(true),
+			expectEnable:  pointer.BoolPtr(false),
+			expectDisable: pointer.BoolPtr(true),
+		},
+		{
+			name: "explicit enable-telemetry wins",
+			config: map[string]interface{}{
+				"dashboard.enable-telemetry": true,
+			},
+			disable:      pointer.BoolPtr(true),
+			expectEnable: pointer.BoolPtr(true),
+		},
+		{
+			name: "explicit disable-telemetry wins",
+			config: map[string]interface{}{
+				"dashboard.disable-telemetry": false,
+			},
+			disable:       pointer.BoolPtr(true),
+			expectDisable: pointer.BoolPtr(false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.DisableTelemetry = tt.disable
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expect := range map[string]*bool{
+				"dashboard.enable-telemetry":  tt.expectEnable,
+				"dashboard.disable-telemetry": tt.expectDisable,
+			} {
+				if expect == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).Interface()).To(Equal(*expect), key)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithInitialClusterToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		uid         types.UID
+		config      map[string]interface{}
+		token       *string
+		expectToken string
+	}{
+		{
+			name:        "token is derived from the uid of the cluster",
+			uid:         types.UID("8f2c4d7a-1b3e-4f5a-9c6d-0e1f2a3b4c5d"),
+			expectToken: "test-8f2c4d7a-1b3e-4f5a-9c6d-0e1f2a3b4c5d",
+		},
+		{
+			name:        "token is overridden in spec",
+			uid:         types.UID("8f2c4d7a-1b3e-4f5a-9c6d-0e1f2a3b4c5d"),
+			token:       pointer.StringPtr("pd-cluster-a"),
+			expectToken: "pd-cluster-a",
+		},
+		{
+			name: "explicit pd config wins",
+			uid:  types.UID("8f2c4d7a-1b3e-4f5a-9c6d-0e1f2a3b4c5d"),
+			config: map[string]interface{}{
+				"initial-cluster-token": "pd-cluster-b",
+			},
+			token:       pointer.StringPtr("pd-cluster-a"),
+			expectToken: "pd-cluster-b",
+		},
+		{
+			name: "token is not set if the uid is unknown",
+			uid:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.UID = tt.uid
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.InitialClusterToken = tt.token
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectToken == "" {
+				g.Expect(config.Get("initial-cluster-token")).To(BeNil())
+				return
+			}
+			g.Expect(config.Get("initial-cluster-token").MustString()).To(Equal(tt.expectToken))
+
+			// the token is stable for the cluster
+			again, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(again.Data["config-file"]).To(Equal(cm.Data["config-file"]))
+		})
+	}
+}
+
+func TestGetPDConfigMapWithLocationLabels(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		locationLabels []string
+		expectLabels   []string
+	}{
+		{
+			name: "location labels are not set",
+		},
+		{
+			name:           "location labels are mapped into pd config",
+			locationLabels: []string{"region", "zone", "host"},
+			expectLabels:   []string{"region", "zone", "host"},
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"replication.location-labels": []string{"zone", "rack"},
+			},
+			locationLabels: []string{"region", "zone", "host"},
+			expectLabels:   []string{"zone", "rack"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.LocationLabels = tt.locationLabels
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectLabels == nil {
+				g.Expect(config.Get("replication.location-labels")).To(BeNil())
+				return
+			}
+			g.Expect(config.Get("replication.location-labels").MustStringSlice()).To(Equal(tt.expectLabels))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithEnableGRPCGateway(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		enable        *bool
+		expectEnabled *bool
+		expectWarning bool
+	}{
+		{
+			name: "grpc gateway is not set",
+		},
+		{
+			name:          "grpc gateway is enabled",
+			enable:        pointer.BoolPtr(true),
+			expectEnabled: pointer.BoolPtr(true),
+		},
+		{
+			name:          "grpc gateway is disabled",
+			enable:        pointer.BoolPtr(false),
+			expectEnabled: pointer.BoolPtr(false),
+			expectWarning: true,
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"enable-grpc-gateway": true,
+			},
+			enable:        pointer.BoolPtr(false),
+			expectEnabled: pointer.BoolPtr(true),
+		},
+		{
+			name: "grpc gateway is disabled by explicit pd config",
+			config: map[string]interface{}{
+				"enable-grpc-gateway": false,
+			},
+			expectEnabled: pointer.BoolPtr(false),
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.EnableGRPCGateway = tt.enable
+			pmm, _, _ := newFakePDMemberManager()
+
+			cm, err := pmm.syncPDConfigMap(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectEnabled == nil {
+				g.Expect(config.Get("enable-grpc-gateway")).To(BeNil())
+			} else {
+				g.Expect(config.Get("enable-grpc-gateway").Interface()).To(Equal(*tt.expectEnabled))
+			}
+
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.expectWarning {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("Warning PDGRPCGatewayDisabled"))
+			} else {
+				g.Expect(events).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestGetPDConfigMapWithEnableDiagnostic(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		enable        *bool
+		expectEnabled *bool
+	}{
+		{
+			name: "diagnostic is not set",
+		},
+		{
+			name:          "diagnostic is enabled",
+			enable:        pointer.BoolPtr(true),
+			expectEnabled: pointer.BoolPtr(true),
+		},
+		{
+			name:          "diagnostic is disabled",
+			enable:        pointer.BoolPtr(false),
+			expectEnabled: pointer.BoolPtr(false),
+		},
+		{
+			name: "explicit pd config wins",
+			config: map[string]interface{}{
+				"schedule.enable-diagnostic": false,
+			},
+			enable:        pointer.BoolPtr(true),
+			expectEnabled: pointer.BoolPtr(false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.EnableDiagnostic = tt.enable
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectEnabled == nil {
+				g.Expect(config.Get("schedule.enable-diagnostic")).To(BeNil())
+			} else {
+				g.Expect(config.Get("schedule.enable-diagnostic").Interface()).To(Equal(*tt.expectEnabled))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithEnableDiagnosticChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	updateStrategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.PD.ConfigUpdateStrategy = &updateStrategy
+	pmm, _, _ := newFakePDMemberManager()
+
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// enabling the diagnostic rolls out a new ConfigMap
+	tc.Spec.PD.EnableDiagnostic = pointer.BoolPtr(true)
+	newCm, err := pmm.syncPDConfigMap(tc, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+	g.Expect(newCm.Data["config-file"]).To(ContainSubstring("enable-diagnostic = true"))
+	g.Expect(tc.Status.PD.ConfigUpdatePending).To(BeTrue())
+}
+
+func TestValidatePDConfigSchema(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the embedded schema is valid for the versions covered
+	for _, version := range []string{"v6.5.0", "v7.5.0", "v8.1.0"} {
+		schema, err := getPDConfigSchema(version)
+		g.Expect(err).NotTo(HaveOccurred(), version)
+		g.Expect(schema).NotTo(BeNil(), version)
+	}
+
+	tests := []struct {
+		name         string
+		version      string
+		config       map[string]interface{}
+		expectErrors []string
+	}{
+		{
+			name:    "valid config",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"lease":                                  int64(3),
+				"log.level":                              "info",
+				"replication.max-replicas":               int64(3),
+				"replication.location-labels":            []interface{}{"zone", "host"},
+				"schedule.leader-schedule-limit":         int64(4),
+				"schedule.split-merge-interval":          "1h",
+				"pd-server.metric-storage":               "http://prometheus:9090",
+				"controller.degraded-mode-wait-duration": "0s",
+			},
+		},
+		{
+			name:    "unknown key in section",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+			expectErrors: []string{"replication.max-replica"},
+		},
+		{
+			name:    "unknown top level key",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"enable-grpc-gatway": true,
+			},
+			expectErrors: []string{"enable-grpc-gatway"},
+		},
+		{
+			name:    "value of wrong type",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"schedule.leader-schedule-limit": "4",
+				"replication.max-replicas":       int64(0),
+			},
+			expectErrors: []string{"schedule.leader-schedule-limit", "replication.max-replicas"},
+		},
+		{
+			name:    "version is not covered by the schema",
+			version: "v5.4.0",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+		},
+		{
+			name:    "version is later than the ones covered by the schema",
+			version: "v9.0.0",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+		},
+		{
+			name:    "version is not semantic versioning compatible",
+			version: "latest",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				config.Set(k, v)
+			}
+
+			err := validatePDConfigSchema(config, tt.version)
+			if len(tt.expectErrors) == 0 {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(isPDConfigSchemaError(err)).To(BeTrue())
+			for _, expected := range tt.expectErrors {
+				g.Expect(err.Error()).To(ContainSubstring(expected))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithConfigUpdateStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	inPlace := v1alpha1.ConfigUpdateStrategyInPlace
+	rollingUpdate := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tests := []struct {
+		name            string
+		clusterStrategy v1alpha1.ConfigUpdateStrategy
+		pdStrategy      *v1alpha1.ConfigUpdateStrategy
+		expectSuffix    bool
+	}{
+		{
+			name:            "cluster-level in place",
+			clusterStrategy: inPlace,
+		},
+		{
+			name:            "cluster-level rolling update",
+			clusterStrategy: rollingUpdate,
+			expectSuffix:    true,
+		},
+		{
+			name:            "pd in place overrides cluster-level rolling update",
+			clusterStrategy: rollingUpdate,
+			pdStrategy:      &inPlace,
+		},
+		{
+			name:            "pd rolling update overrides cluster-level in place",
+			clusterStrategy: inPlace,
+			pdStrategy:      &rollingUpdate,
+			expectSuffix:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			tc.Spec.ConfigUpdateStrategy = tt.clusterStrategy
+			tc.Spec.PD.ConfigUpdateStrategy = tt.pdStrategy
+			pmm, _, _ := newFakePDMemberManager()
+
+			cm, err := pmm.syncPDConfigMap(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.expectSuffix {
+				// a ConfigMap named after the digest of the config is created on each config change
+				g.Expect(cm.Name).To(HavePrefix("test-pd-"))
+			} else {
+				// the ConfigMap is updated in place
+				g.Expect(cm.Name).To(Equal("test-pd"))
+			}
+
+			// the config change is handled by the same strategy
+			tc.Spec.PD.Config.Set("lease", int64(5))
+			set, err := getNewPDSetForTidbCluster(tc, cm)
+			g.Expect(err).NotTo(HaveOccurred())
+			newCm, err := pmm.syncPDConfigMap(tc, set)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.expectSuffix {
+				g.Expect(newCm.Name).To(HavePrefix("test-pd-"))
+				g.Expect(newCm.Name).NotTo(Equal(cm.Name))
+			} else {
+				g.Expect(newCm.Name).To(Equal(cm.Name))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithConfigSchema(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		validate      *bool
+		expectErr     bool
+		expectWarning bool
+	}{
+		{
+			name: "invalid config is not validated by default",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+		},
+		{
+			name: "valid config",
+			config: map[string]interface{}{
+				"replication.max-replicas": int64(3),
+			},
+			validate: pointer.BoolPtr(true),
+		},
+		{
+			name: "invalid config is rejected",
+			config: map[string]interface{}{
+				"replication.max-replica": int64(3),
+			},
+			validate:      pointer.BoolPtr(true),
+			expectErr:     true,
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Image = "pingcap/pd:v7.5.0"
+			// the config set by the operator conforms to the schema
+			tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			tc.Spec.PD.ValidateConfigSchema = tt.validate
+			pmm, _, _ := newFakePDMemberManager()
+
+			_, err := pmm.syncPDConfigMap(tc, nil)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("replication.max-replica"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.expectWarning {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("Warning PDConfigSchemaInvalid"))
+			} else {
+				g.Expect(events).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDConfigMapWithTLSConflicts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		tlsCluster      bool
+		config          map[string]interface{}
+		expectConflicts []string
+	}{
+		{
+			name:       "no security config is set",
+			tlsCluster: true,
+		},
+		{
+			name:       "security config is the same as the injected one",
+			tlsCluster: true,
+			config: map[string]interface{}{
+				"security.cacert-path": "/var/lib/pd-tls/ca.crt",
+			},
+		},
+		{
+			name:       "security config conflicts with the injected one",
+			tlsCluster: true,
+			config: map[string]interface{}{
+				"security.cacert-path": "/custom/ca.crt",
+				"security.key-path":    "/custom/tls.key",
+				"security.cert-path":   "/var/lib/pd-tls/tls.crt",
+			},
+			expectConflicts: []string{"security.cacert-path", "security.key-path"},
+		},
+		{
+			name:       "security config is kept if tls is disabled",
+			tlsCluster: false,
+			config: map[string]interface{}{
+				"security.cacert-path": "/custom/ca.crt",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			if tt.tlsCluster {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+			}
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			for k, v := range tt.config {
+				tc.Spec.PD.Config.Set(k, v)
+			}
+			pmm, _, _ := newFakePDMemberManager()
+
+			g.Expect(getPDClusterTLSConfigConflicts(tc)).To(Equal(tt.expectConflicts))
+
+			cm, err := pmm.syncPDConfigMap(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if len(tt.expectConflicts) == 0 {
+				g.Expect(events).To(BeEmpty())
+			} else {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("Warning PDConfigConflict"))
+				g.Expect(events[0]).To(ContainSubstring(strings.Join(tt.expectConflicts, ", ")))
+			}
+
+			// the injected paths always win if tls is enabled
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.tlsCluster {
+				g.Expect(config.Get("security.cacert-path").MustString()).To(Equal("/var/lib/pd-tls/ca.crt"))
+				g.Expect(config.Get("security.key-path").MustString()).To(Equal("/var/lib/pd-tls/tls.key"))
+			} else {
+				g.Expect(config.Get("security.cacert-path").MustString()).To(Equal("/custom/ca.crt"))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDStoreLimits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type setCall struct {
+		limitType string
+		rate      float64
+	}
+	tests := []struct {
+		name          string
+		storeLimits   *v1alpha1.PDStoreLimits
+		currentLimits map[uint64]*pdapi.StoreLimit
+		getErr        error
+		setErr        error
+		expectErr     bool
+		expectCalls   []setCall
+	}{
+		{
+			name: "store limits are not set",
+			currentLimits: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 15},
+			},
+			expectCalls: []setCall{},
+		},
+		{
+			name:        "store limits are in sync",
+			storeLimits: &v1alpha1.PDStoreLimits{AddPeer: pointer.Int32Ptr(15), RemovePeer: pointer.Int32Ptr(20)},
+			currentLimits: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 20},
+				2: {AddPeer: 15, RemovePeer: 20},
+			},
+			expectCalls: []setCall{},
+		},
+		{
+			name:        "drifted store limit is applied",
+			storeLimits: &v1alpha1.PDStoreLimits{AddPeer: pointer.Int32Ptr(30), RemovePeer: pointer.Int32Ptr(20)},
+			currentLimits: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 30, RemovePeer: 20},
+				2: {AddPeer: 15, RemovePeer: 20},
+			},
+			expectCalls: []setCall{{limitType: pdapi.StoreLimitTypeAddPeer, rate: 30}},
+		},
+		{
+			name:        "only the store limits in spec are reconciled",
+			storeLimits: &v1alpha1.PDStoreLimits{RemovePeer: pointer.Int32Ptr(40)},
+			currentLimits: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 20},
+			},
+			expectCalls: []setCall{{limitType: pdapi.StoreLimitTypeRemovePeer, rate: 40}},
+		},
+		{
+			name:        "pd is unreachable",
+			storeLimits: &v1alpha1.PDStoreLimits{AddPeer: pointer.Int32Ptr(30)},
+			getErr:      fmt.Errorf("pd is unreachable"),
+			expectCalls: []setCall{},
+		},
+		{
+			name:        "failed to apply store limit",
+			storeLimits: &v1alpha1.PDStoreLimits{AddPeer: pointer.Int32Ptr(30)},
+			currentLimits: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 15},
+			},
+			setErr:      fmt.Errorf("failed to set store limit"),
+			expectErr:   true,
+			expectCalls: []setCall{{limitType: pdapi.StoreLimitTypeAddPeer, rate: 30}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.StoreLimits = tt.storeLimits
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetStoresLimitActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return tt.currentLimits, nil
+			})
+			calls := []setCall{}
+			pdClient.AddReaction(pdapi.SetAllStoresLimitActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls = append(calls, setCall{limitType: action.LimitType, rate: action.Rate})
+				return nil, tt.setErr
+			})
+
+			err := pmm.syncPDStoreLimits(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(calls).To(Equal(tt.expectCalls))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDLabelProperties(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	rejectLeader := func(value string) v1alpha1.PDLabelProperty {
+		return v1alpha1.PDLabelProperty{Type: "reject-leader", Key: "zone", Value: value}
+	}
+	tests := []struct {
+		name          string
+		properties    []v1alpha1.PDLabelProperty
+		applied       []v1alpha1.PDLabelProperty
+		current       pdapi.PDLabelPropertyConfig
+		getErr        error
+		setErr        error
+		expectErr     bool
+		expectSet     []string
+		expectDeleted []string
+		expectApplied []v1alpha1.PDLabelProperty
+	}{
+		{
+			name:          "no label properties",
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z9"}}},
+			expectSet:     []string{},
+			expectDeleted: []string{},
+		},
+		{
+			name:          "add label properties",
+			properties:    []v1alpha1.PDLabelProperty{rejectLeader("z1"), rejectLeader("z2")},
+			applied:       []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z1"}}},
+			expectSet:     []string{"zone=z2"},
+			expectDeleted: []string{},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z1"), rejectLeader("z2")},
+		},
+		{
+			name:          "label properties already set by others are not recorded",
+			properties:    []v1alpha1.PDLabelProperty{rejectLeader("z1"), rejectLeader("z9")},
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z9"}}},
+			expectSet:     []string{"zone=z1"},
+			expectDeleted: []string{},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+		},
+		{
+			name:          "label properties are in sync",
+			properties:    []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			applied:       []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z1"}}},
+			expectSet:     []string{},
+			expectDeleted: []string{},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+		},
+		{
+			name:       "remove label properties removed from spec and keep the ones set by others",
+			properties: []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			applied:    []v1alpha1.PDLabelProperty{rejectLeader("z1"), rejectLeader("z2"), rejectLeader("z3")},
+			current: pdapi.PDLabelPropertyConfig{"reject-leader": {
+				{Key: "zone", Value: "z1"},
+				{Key: "zone", Value: "z2"},
+				{Key: "zone", Value: "z9"},
+			}},
+			expectSet:     []string{},
+			expectDeleted: []string{"zone=z2"},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+		},
+		{
+			name:          "remove all label properties",
+			applied:       []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z1"}}},
+			expectSet:     []string{},
+			expectDeleted: []string{"zone=z1"},
+		},
+		{
+			name:          "pd is unreachable",
+			properties:    []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			applied:       []v1alpha1.PDLabelProperty{rejectLeader("z2")},
+			getErr:        fmt.Errorf("pd is unreachable"),
+			expectSet:     []string{},
+			expectDeleted: []string{},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z2")},
+		},
+		{
+			name:          "failed to set label property",
+			properties:    []v1alpha1.PDLabelProperty{rejectLeader("z1")},
+			applied:       []v1alpha1.PDLabelProperty{rejectLeader("z2")},
+			current:       pdapi.PDLabelPropertyConfig{"reject-leader": {{Key: "zone", Value: "z2"}}},
+			setErr:        fmt.Errorf("failed to set label property"),
+			expectErr:     true,
+			expectSet:     []string{"zone=z1"},
+			expectDeleted: []string{},
+			expectApplied: []v1alpha1.PDLabelProperty{rejectLeader("z2")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.LabelProperties = tt.properties
+			tc.Status.PD.AppliedLabelProperties = tt.applied
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetLabelPropertiesActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return tt.current, nil
+			})
+			set := []string{}
+			pdClient.AddReaction(pdapi.SetLabelPropertyActionType, func(action *pdapi.Action) (interface{}, error) {
+				g.Expect(action.Property.Type).To(Equal("reject-leader"))
+				set = append(set, action.Property.Key+"="+action.Property.Value)
+				return nil, tt.setErr
+			})
+			deleted := []string{}
+			pdClient.AddReaction(pdapi.DeleteLabelPropertyActionType, func(action *pdapi.Action) (interface{}, error) {
+				g.Expect(action.Property.Type).To(Equal("reject-leader"))
+				deleted = append(deleted, action.Property.Key+"="+action.Property.Value)
+				return nil, nil
+			})
+
+			err := pmm.syncPDLabelProperties(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(set).To(Equal(tt.expectSet))
+			g.Expect(deleted).To(Equal(tt.expectDeleted))
+			if len(tt.expectApplied) == 0 {
+				g.Expect(tc.Status.PD.AppliedLabelProperties).To(BeEmpty())
+			} else {
+				g.Expect(tc.Status.PD.AppliedLabelProperties).To(Equal(tt.expectApplied))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDRegionLabelRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	specRule := func(id, value string, keyRanges ...v1alpha1.PDKeyRange) v1alpha1.PDRegionLabelRule {
+		return v1alpha1.PDRegionLabelRule{
+			ID:        id,
+			Labels:    []v1alpha1.PDRegionLabel{{Key: "tenant", Value: value}},
+			KeyRanges: keyRanges,
+		}
+	}
+	pdRule := func(id, value string, keyRanges ...pdapi.KeyRange) *pdapi.RegionLabelRule {
+		return &pdapi.RegionLabelRule{
+			ID:       id,
+			Labels:   []pdapi.RegionLabel{{Key: "tenant", Value: value}},
+			RuleType: pdapi.RegionLabelRuleTypeKeyRange,
+			Data:     keyRanges,
+		}
+	}
+	tests := []struct {
+		name          string
+		rules         []v1alpha1.PDRegionLabelRule
+		applied       []string
+		currentRules  []*pdapi.RegionLabelRule
+		getErr        error
+		setErr        error
+		expectErr     bool
+		expectSet     []string
+		expectDeleted []string
+		expectApplied []string
+	}{
+		{
+			name:          "no region label rules",
+			currentRules:  []*pdapi.RegionLabelRule{pdRule("schema/test/t1", "x", pdapi.KeyRange{StartKey: "74"})},
+			expectSet:     []string{},
+			expectDeleted: []string{},
+		},
+		{
+			name:          "add region label rules",
+			rules:         []v1alpha1.PDRegionLabelRule{specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480", EndKey: "7490"})},
+			currentRules:  []*pdapi.RegionLabelRule{},
+			expectSet:     []string{"tenant-a"},
+			expectDeleted: []string{},
+			expectApplied: []string{"tenant-a"},
+		},
+		{
+			name:          "region label rules are in sync",
+			rules:         []v1alpha1.PDRegionLabelRule{specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480", EndKey: "7490"})},
+			applied:       []string{"tenant-a"},
+			currentRules:  []*pdapi.RegionLabelRule{pdRule("tenant-a", "a", pdapi.KeyRange{StartKey: "7480", EndKey: "7490"})},
+			expectSet:     []string{},
+			expectDeleted: []string{},
+			expectApplied: []string{"tenant-a"},
+		},
+		{
+			name: "update drifted region label rules",
+			rules: []v1alpha1.PDRegionLabelRule{
+				specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480", EndKey: "7500"}),
+				specRule("tenant-b", "b", v1alpha1.PDKeyRange{StartKey: "7500"}),
+			},
+			applied: []string{"tenant-a", "tenant-b"},
+			currentRules: []*pdapi.RegionLabelRule{
+				pdRule("tenant-a", "a", pdapi.KeyRange{StartKey: "7480", EndKey: "7490"}),
+				pdRule("tenant-b", "c", pdapi.KeyRange{StartKey: "7500"}),
+			},
+			expectSet:     []string{"tenant-a", "tenant-b"},
+			expectDeleted: []string{},
+			expectApplied: []string{"tenant-a", "tenant-b"},
+		},
+		{
+			name:    "delete region label rules removed from spec and keep the ones created by others",
+			rules:   []v1alpha1.PDRegionLabelRule{specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480", EndKey: "7490"})},
+			applied: []string{"tenant-a", "tenant-b", "tenant-c"},
+			currentRules: []*pdapi.RegionLabelRule{
+				pdRule("tenant-a", "a", pdapi.KeyRange{StartKey: "7480", EndKey: "7490"}),
+				pdRule("tenant-b", "b", pdapi.KeyRange{StartKey: "7500"}),
+				pdRule("schema/test/t1", "x", pdapi.KeyRange{StartKey: "74"}),
+			},
+			expectSet:     []string{},
+			expectDeleted: []string{"tenant-b"},
+			expectApplied: []string{"tenant-a"},
+		},
+		{
+			name:          "delete all region label rules",
+			applied:       []string{"tenant-a"},
+			currentRules:  []*pdapi.RegionLabelRule{pdRule("tenant-a", "a", pdapi.KeyRange{StartKey: "7480", EndKey: "7490"})},
+			expectSet:     []string{},
+			expectDeleted: []string{"tenant-a"},
+		},
+		{
+			name:          "pd is unreachable",
+			rules:         []v1alpha1.PDRegionLabelRule{specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480"})},
+			applied:       []string{"tenant-b"},
+			getErr:        fmt.Errorf("pd is unreachable"),
+			expectSet:     []string{},
+			expectDeleted: []string{},
+			expectApplied: []string{"tenant-b"},
+		},
+		{
+			name: "failed to set region label rule",
+			rules: []v1alpha1.PDRegionLabelRule{
+				specRule("tenant-a", "a", v1alpha1.PDKeyRange{StartKey: "7480"}),
+			},
+			applied:       []string{"tenant-b"},
+			currentRules:  []*pdapi.RegionLabelRule{pdRule("tenant-b", "b", pdapi.KeyRange{StartKey: "7500"})},
+			setErr:        fmt.Errorf("failed to set region label rule"),
+			expectErr:     true,
+			expectSet:     []string{"tenant-a"},
+			expectDeleted: []string{},
+			expectApplied: []string{"tenant-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.RegionLabelRules = tt.rules
+			tc.Status.PD.AppliedRegionLabelRules = tt.applied
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetRegionLabelRulesActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return tt.currentRules, nil
+			})
+			set := []string{}
+			pdClient.AddReaction(pdapi.SetRegionLabelRuleActionType, func(action *pdapi.Action) (interface{}, error) {
+				set = append(set, action.LabelRule.ID)
+				g.Expect(action.LabelRule.RuleType).To(Equal(pdapi.RegionLabelRuleTypeKeyRange))
+				return nil, tt.setErr
+			})
+			deleted := []string{}
+			pdClient.AddReaction(pdapi.DeleteRegionLabelRuleActionType, func(action *pdapi.Action) (interface{}, error) {
+				deleted = append(deleted, action.Name)
+				return nil, nil
+			})
+
+			err := pmm.syncPDRegionLabelRules(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(set).To(Equal(tt.expectSet))
+			g.Expect(deleted).To(Equal(tt.expectDeleted))
+			if len(tt.expectApplied) == 0 {
+				g.Expect(tc.Status.PD.AppliedRegionLabelRules).To(BeEmpty())
+			} else {
+				g.Expect(tc.Status.PD.AppliedRegionLabelRules).To(Equal(tt.expectApplied))
+			}
+		})
+	}
+}
+
+func TestComputePDSchedulerLimits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		upStores    int
+		leaderLimit uint64
+		regionLimit uint64
+	}{
+		{upStores: 0, leaderLimit: 4, regionLimit: 2048},
+		{upStores: 3, leaderLimit: 4, regionLimit: 2048},
+		{upStores: 8, leaderLimit: 8, regionLimit: 2048},
+		{upStores: 16, leaderLimit: 16, regionLimit: 4096},
+		{upStores: 50, leaderLimit: 50, regionLimit: 12800},
+		{upStores: 64, leaderLimit: 64, regionLimit: 16384},
+		{upStores: 200, leaderLimit: 64, regionLimit: 16384},
+	}
+
+	for _, tt := range tests {
+		leaderLimit, regionLimit := computePDSchedulerLimits(tt.upStores)
+		g.Expect(leaderLimit).To(Equal(tt.leaderLimit), "up stores: %d", tt.upStores)
+		g.Expect(regionLimit).To(Equal(tt.regionLimit), "up stores: %d", tt.upStores)
+	}
+}
+
+func TestPDMemberManagerSyncPDSchedulerLimits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newStores := func(up, offline int) *pdapi.StoresInfo {
+		info := &pdapi.StoresInfo{}
+		for i := 0; i < up+offline; i++ {
+			state := v1alpha1.TiKVStateUp
+			if i >= up {
+				state = v1alpha1.TiKVStateOffline
+			}
+			info.Stores = append(info.Stores, &pdapi.StoreInfo{
+				Store: &pdapi.MetaStore{Store: &metapb.Store{Id: uint64(i + 1)}, StateName: state},
+			})
+		}
+		info.Count = len(info.Stores)
+		return info
+	}
+	type limits struct {
+		leader uint64
+		region uint64
+	}
+	tests := []struct {
+		name        string
+		autoTune    *bool
+		paused      bool
+		stores      *pdapi.StoresInfo
+		current     *pdapi.PDScheduleConfig
+		getErr      error
+		updateErr   error
+		expectErr   bool
+		expectCalls []limits
+	}{
+		{
+			name:        "auto-tuning is not enabled",
+			stores:      newStores(16, 0),
+			current:     &pdapi.PDScheduleConfig{},
+			expectCalls: []limits{},
+		},
+		{
+			name:        "cluster is paused",
+			autoTune:    pointer.BoolPtr(true),
+			paused:      true,
+			stores:      newStores(16, 0),
+			current:     &pdapi.PDScheduleConfig{},
+			expectCalls: []limits{},
+		},
+		{
+			name:        "small cluster uses the defaults of pd",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(3, 0),
+			current:     &pdapi.PDScheduleConfig{LeaderScheduleLimit: pointer.Uint64Ptr(8), RegionScheduleLimit: pointer.Uint64Ptr(2048)},
+			expectCalls: []limits{{leader: 4, region: 2048}},
+		},
+		{
+			name:        "limits grow with the number of up stores",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(16, 4),
+			current:     &pdapi.PDScheduleConfig{LeaderScheduleLimit: pointer.Uint64Ptr(8), RegionScheduleLimit: pointer.Uint64Ptr(2048)},
+			expectCalls: []limits{{leader: 16, region: 4096}},
+		},
+		{
+			name:        "limits are capped for large clusters",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(100, 0),
+			current:     &pdapi.PDScheduleConfig{},
+			expectCalls: []limits{{leader: 64, region: 16384}},
+		},
+		{
+			name:        "limits are in sync",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(16, 0),
+			current:     &pdapi.PDScheduleConfig{LeaderScheduleLimit: pointer.Uint64Ptr(16), RegionScheduleLimit: pointer.Uint64Ptr(4096)},
+			expectCalls: []limits{},
+		},
+		{
+			name:        "no up stores",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(0, 3),
+			current:     &pdapi.PDScheduleConfig{},
+			expectCalls: []limits{},
+		},
+		{
+			name:        "pd is unreachable",
+			autoTune:    pointer.BoolPtr(true),
+			getErr:      fmt.Errorf("pd is unreachable"),
+			expectCalls: []limits{},
+		},
+		{
+			name:        "failed to apply limits",
+			autoTune:    pointer.BoolPtr(true),
+			stores:      newStores(16, 0),
+			current:     &pdapi.PDScheduleConfig{},
+			updateErr:   fmt.Errorf("failed to update schedule config"),
+			expectErr:   true,
+			expectCalls: []limits{{leader: 16, region: 4096}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.AutoTuneSchedulerLimits = tt.autoTune
+			tc.Spec.Paused = tt.paused
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return tt.stores, nil
+			})
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.PDConfigFromAPI{Schedule: tt.current}, nil
+			})
+			calls := []limits{}
+			pdClient.AddReaction(pdapi.UpdateScheduleActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls = append(calls, limits{leader: *action.Schedule.LeaderScheduleLimit, region: *action.Schedule.RegionScheduleLimit})
+				return nil, tt.updateErr
+			})
+
+			err := pmm.syncPDSchedulerLimits(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(calls).To(Equal(tt.expectCalls))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDSchedulers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		schedulers    *v1alpha1.PDSchedulers
+		paused        bool
+		current       []string
+		getErr        error
+		addErr        error
+		expectErr     bool
+		expectAdded   []string
+		expectRemoved []string
+	}{
+		{
+			name:          "schedulers are not set",
+			current:       []string{"balance-leader-scheduler"},
+			expectAdded:   []string{},
+			expectRemoved: []string{},
+		},
+		{
+			name: "cluster is paused",
+			schedulers: &v1alpha1.PDSchedulers{
+				Add: []string{"balance-hot-region-scheduler"},
+			},
+			paused:        true,
+			expectAdded:   []string{},
+			expectRemoved: []string{},
+		},
+		{
+			name: "add absent and remove present schedulers",
+			schedulers: &v1alpha1.PDSchedulers{
+				Add:    []string{"balance-hot-region-scheduler", "balance-region-scheduler"},
+				Remove: []string{"balance-leader-scheduler", "shuffle-leader-scheduler"},
+			},
+			current:       []string{"balance-leader-scheduler", "balance-region-scheduler", "evict-leader-scheduler-1"},
+			expectAdded:   []string{"balance-hot-region-scheduler"},
+			expectRemoved: []string{"balance-leader-scheduler"},
+		},
+		{
+			name: "schedulers are in sync",
+			schedulers: &v1alpha1.PDSchedulers{
+				Add:    []string{"balance-hot-region-scheduler"},
+				Remove: []string{"balance-leader-scheduler"},
+			},
+			current:       []string{"balance-hot-region-scheduler", "balance-region-scheduler"},
+			expectAdded:   []string{},
+			expectRemoved: []string{},
+		},
+		{
+			name: "pd is unreachable",
+			schedulers: &v1alpha1.PDSchedulers{
+				Add: []string{"balance-hot-region-scheduler"},
+			},
+			getErr:        fmt.Errorf("pd is unreachable"),
+			expectAdded:   []string{},
+			expectRemoved: []string{},
+		},
+		{
+			name: "failed to add scheduler",
+			schedulers: &v1alpha1.PDSchedulers{
+				Add:    []string{"balance-hot-region-scheduler"},
+				Remove: []string{"balance-leader-scheduler"},
+			},
+			current:       []string{"balance-leader-scheduler"},
+			addErr:        fmt.Errorf("failed to add scheduler"),
+			expectErr:     true,
+			expectAdded:   []string{"balance-hot-region-scheduler"},
+			expectRemoved: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Schedulers = tt.schedulers
+			tc.Spec.Paused = tt.paused
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetSchedulersActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return tt.current, nil
+			})
+			added := []string{}
+			pdClient.AddReaction(pdapi.AddSchedulerActionType, func(action *pdapi.Action) (interface{}, error) {
+				added = append(added, action.Name)
+				return nil, tt.addErr
+			})
+			removed := []string{}
+			pdClient.AddReaction(pdapi.RemoveSchedulerActionType, func(action *pdapi.Action) (interface{}, error) {
+				removed = append(removed, action.Name)
+				return nil, nil
+			})
+
+			err := pmm.syncPDSchedulers(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(added).To(Equal(tt.expectAdded))
+			g.Expect(removed).To(Equal(tt.expectRemoved))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDPlacementRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		desired     *bool
+		current     *bool
+		allow       bool
+		tiflash     bool
+		getErr      error
+		updateErr   error
+		expectErr   bool
+		expectCalls []bool
+		expectEvent string
+	}{
+		{
+			name:        "toggle is not set",
+			current:     pointer.BoolPtr(false),
+			expectCalls: []bool{},
+		},
+		{
+			name:        "enable placement rules",
+			desired:     pointer.BoolPtr(true),
+			current:     pointer.BoolPtr(false),
+			expectCalls: []bool{true},
+		},
+		{
+			name:        "placement rules are in sync",
+			desired:     pointer.BoolPtr(true),
+			current:     pointer.BoolPtr(true),
+			expectCalls: []bool{},
+		},
+		{
+			name:        "disabling without override is refused",
+			desired:     pointer.BoolPtr(false),
+			current:     pointer.BoolPtr(true),
+			expectCalls: []bool{},
+			expectEvent: "may be dropped",
+		},
+		{
+			name:        "disabling with override",
+			desired:     pointer.BoolPtr(false),
+			current:     pointer.BoolPtr(true),
+			allow:       true,
+			expectCalls: []bool{false},
+		},
+		{
+			name:        "disabling with tiflash is refused",
+			desired:     pointer.BoolPtr(false),
+			current:     pointer.BoolPtr(true),
+			allow:       true,
+			tiflash:     true,
+			expectCalls: []bool{},
+			expectEvent: "TiFlash is deployed",
+		},
+		{
+			name:        "pd is unreachable",
+			desired:     pointer.BoolPtr(true),
+			getErr:      fmt.Errorf("pd is unreachable"),
+			expectCalls: []bool{},
+		},
+		{
+			name:        "failed to apply toggle",
+			desired:     pointer.BoolPtr(true),
+			current:     pointer.BoolPtr(false),
+			updateErr:   fmt.Errorf("failed to update replication config"),
+			expectErr:   true,
+			expectCalls: []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.EnablePlacementRules = tt.desired
+			if tt.allow {
+				tc.Annotations = map[string]string{label.AnnPDAllowDisablePlacementRules: "true"}
+			}
+			if tt.tiflash {
+				tc.Spec.TiFlash = &v1alpha1.TiFlashSpec{}
+			}
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				if tt.getErr != nil {
+					return nil, tt.getErr
+				}
+				return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{EnablePlacementRules: tt.current}}, nil
+			})
+			calls := []bool{}
+			pdClient.AddReaction(pdapi.UpdateReplicationActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls = append(calls, *action.Replication.EnablePlacementRules)
+				return nil, tt.updateErr
+			})
+
+			err := pmm.syncPDPlacementRules(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(calls).To(Equal(tt.expectCalls))
+
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.expectEvent == "" {
+				g.Expect(events).To(BeEmpty())
+			} else {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("PlacementRulesDisableRefused"))
+				g.Expect(events[0]).To(ContainSubstring(tt.expectEvent))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncStatusClampFutureTransitionTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	future := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		name               string
+		lastTransitionTime metav1.Time
+		lastHealth         bool
+		expectPreserved    bool
+	}{
+		{
+			name:               "past transition time is preserved",
+			lastTransitionTime: past,
+			lastHealth:         true,
+			expectPreserved:    true,
+		},
+		{
+			name:               "future transition time is clamped to now",
+			lastTransitionTime: future,
+			lastHealth:         true,
+			expectPreserved:    false,
+		},
+		{
+			name:               "transition time is updated when health changes",
+			lastTransitionTime: past,
+			lastHealth:         false,
+			expectPreserved:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+				"test-pd-0": {Name: "test-pd-0", Health: tt.lastHealth, LastTransitionTime: tt.lastTransitionTime},
+			}
+			tc.Status.PD.PeerMembers = map[string]v1alpha1.PDMember{
+				"peer-pd-0": {Name: "peer-pd-0", Health: tt.lastHealth, LastTransitionTime: tt.lastTransitionTime},
+			}
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+					{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+					{Name: "peer-pd-0", MemberID: uint64(2), ClientUrls: []string{"http://peer-pd-0.peer-pd-peer.default.svc:2379"}, Health: true},
+				}}, nil
+			})
+			pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &metapb.Cluster{Id: uint64(1)}, nil
+			})
+
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			before := time.Now().Truncate(time.Second)
+			g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+			after := time.Now()
+
+			for _, member := range []v1alpha1.PDMember{tc.Status.PD.Members["test-pd-0"], tc.Status.PD.PeerMembers["peer-pd-0"]} {
+				if tt.expectPreserved {
+					g.Expect(member.LastTransitionTime.Equal(&tt.lastTransitionTime)).To(BeTrue())
+					continue
+				}
+				g.Expect(member.LastTransitionTime.Time.Before(before)).To(BeFalse())
+				g.Expect(member.LastTransitionTime.Time.After(after)).To(BeFalse())
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDPreferredLeader(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		preferred      string
+		priorities     map[string]int32
+		changeTc       func(tc *v1alpha1.TidbCluster)
+		setErr         error
+		expectErr      bool
+		expectMember   string
+		expectPriority int
+		expectEvent    string
+	}{
+		{
+			name:           "set leader priority of the preferred ordinal",
+			preferred:      "1",
+			expectMember:   "test-pd-1",
+			expectPriority: 1,
+			expectEvent:    "Normal SetPDLeaderPriority",
+		},
+		{
+			name:           "set leader priority of the preferred member name above other members",
+			preferred:      "test-pd-2",
+			priorities:     map[string]int32{"test-pd-0": 3, "test-pd-2": 2},
+			expectMember:   "test-pd-2",
+			expectPriority: 4,
+			expectEvent:    "Normal SetPDLeaderPriority",
+		},
+		{
+			name:       "preferred member already has the highest priority",
+			preferred:  "1",
+			priorities: map[string]int32{"test-pd-0": 1, "test-pd-1": 2},
+		},
+		{
+			name:      "preferred member doesn't exist",
+			preferred: "5",
+		},
+		{
+			name:      "pd is scaling",
+			preferred: "1",
+			changeTc: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.ScalePhase
+			},
+		},
+		{
+			name:           "failed to set leader priority",
+			preferred:      "1",
+			setErr:         fmt.Errorf("failed to set leader priority"),
+			expectErr:      true,
+			expectMember:   "test-pd-1",
+			expectPriority: 1,
+			expectEvent:    "Warning FailedSetPDLeaderPriority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.PreferredLeader = tt.preferred
+			tc.Status.PD.Synced = true
+			tc.Status.PD.Phase = v1alpha1.NormalPhase
+			tc.Status.PD.Leader = v1alpha1.PDMember{Name: "test-pd-0", Health: true}
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+				"test-pd-0": {Name: "test-pd-0", Health: true},
+				"test-pd-1": {Name: "test-pd-1", Health: true},
+				"test-pd-2": {Name: "test-pd-2", Health: true},
+			}
+			if tt.changeTc != nil {
+				tt.changeTc(tc)
+			}
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+				members := &pdapi.MembersInfo{}
+				for _, name := range []string{"test-pd-0", "test-pd-1", "test-pd-2"} {
+					members.Members = append(members.Members, &pdpb.Member{Name: name, LeaderPriority: tt.priorities[name]})
+				}
+				return members, nil
+			})
+			member, priority := "", 0
+			pdClient.AddReaction(pdapi.SetMemberLeaderPriorityActionType, func(action *pdapi.Action) (interface{}, error) {
+				member, priority = action.Name, action.Priority
+				return nil, tt.setErr
+			})
+
+			err := pmm.syncPDPreferredLeader(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(member).To(Equal(tt.expectMember))
+			g.Expect(priority).To(Equal(tt.expectPriority))
+
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.expectEvent == "" {
+				g.Expect(events).To(BeEmpty())
+			} else {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(HavePrefix(tt.expectEvent))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncStatusWithZone(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-2": {Name: "test-pd-2", Health: true, Zone: "zone-c"},
+	}
+	pmm, podIndexer, _ := newFakePDMemberManager()
+	nodeIndexer := pmm.deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "zone-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{corev1.LabelZoneFailureDomain: "zone-b"}}},
+	}
+	for _, node := range nodes {
+		g.Expect(nodeIndexer.Add(node)).To(Succeed())
+	}
+	// the node of test-pd-2 is unavailable
+	for podName, nodeName := range map[string]string{"test-pd-0": "node-a", "test-pd-1": "node-b", "test-pd-2": "node-c"} {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+
+	fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+	pdClient := controller.NewFakePDClient(fakePDControl, tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+			{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "test-pd-2", MemberID: uint64(3), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "peer-pd-0", MemberID: uint64(4), ClientUrls: []string{"http://peer-pd-0.peer-pd-peer.default.svc:2379"}, Health: true},
+		}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: uint64(1)}, nil
+	})
+
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+
+	g.Expect(tc.Status.PD.Members["test-pd-0"].Zone).To(Equal("zone-a"))
+	g.Expect(tc.Status.PD.Members["test-pd-1"].Zone).To(Equal("zone-b"))
+	g.Expect(tc.Status.PD.Members["test-pd-2"].Zone).To(Equal("zone-c"))
+	g.Expect(tc.Status.PD.PeerMembers["peer-pd-0"].Zone).To(BeEmpty())
+}
+
+func TestPDMemberManagerSyncStatusUpdatePartition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		updateStrategy  apps.StatefulSetUpdateStrategy
+		expectPartition *int32
+	}{
+		{
+			name: "rolling update without partition",
+			updateStrategy: apps.StatefulSetUpdateStrategy{
+				Type: apps.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+		{
+			name: "rolling update with all pods updated",
+			updateStrategy: apps.StatefulSetUpdateStrategy{
+				Type:          apps.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32Ptr(0)},
+			},
+			expectPartition: pointer.Int32Ptr(0),
+		},
+		{
+			name: "rolling update in progress",
+			updateStrategy: apps.StatefulSetUpdateStrategy{
+				Type:          apps.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32Ptr(2)},
+			},
+			expectPartition: pointer.Int32Ptr(2),
+		},
+		{
+			name: "on delete",
+			updateStrategy: apps.StatefulSetUpdateStrategy{
+				Type:          apps.OnDeleteStatefulSetStrategyType,
+				RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32Ptr(2)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			// the partition recorded in the last sync is overwritten
+			tc.Status.PD.UpdatePartition = pointer.Int32Ptr(1)
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+					{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+				}}, nil
+			})
+			pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &metapb.Cluster{Id: uint64(1)}, nil
+			})
+
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			set.Spec.UpdateStrategy = tt.updateStrategy
+			g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+
+			if tt.expectPartition == nil {
+				g.Expect(tc.Status.PD.UpdatePartition).To(BeNil())
+			} else {
+				g.Expect(tc.Status.PD.UpdatePartition).To(Equal(tt.expectPartition))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncStatusImageWithContainerName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		containerName *string
+		stsContainer  string
+		expectImage   string
+	}{
+		{
+			name:         "default container name",
+			stsContainer: "pd",
+			expectImage:  "pd-test-image",
+		},
+		{
+			name:          "custom container name",
+			containerName: pointer.StringPtr("placement-driver"),
+			stsContainer:  "placement-driver",
+			expectImage:   "pd-test-image",
+		},
+		{
+			name:          "custom container name not rolled out yet",
+			containerName: pointer.StringPtr("placement-driver"),
+			stsContainer:  "pd",
+			expectImage:   "pd-test-image",
+		},
+		{
+			name:          "container not found",
+			containerName: pointer.StringPtr("placement-driver"),
+			stsContainer:  "other",
+			expectImage:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ContainerName = tt.containerName
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+					{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+				}}, nil
+			})
+			pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &metapb.Cluster{Id: uint64(1)}, nil
+			})
+
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(set.Spec.Template.Spec.Containers[0].Name).To(Equal(tc.PDContainerName()))
+			set.Spec.Template.Spec.Containers[0].Name = tt.stsContainer
+
+			g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+			g.Expect(tc.Status.PD.Image).To(Equal(tt.expectImage))
+		})
+	}
+}
+
+func TestPDMemberManagerOrdinalsStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Replicas = 3
+	tc.Spec.PD.OrdinalsStart = pointer.Int32Ptr(3)
+	pmm, _, _ := newFakePDMemberManager()
+
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.PDStsDesiredOrdinals(true)).To(Equal(sets.NewInt32(3, 4, 5)))
+	g.Expect(getStsPodOrdinals(set)).To(Equal(tc.PDStsDesiredOrdinals(true)))
+
+	fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+	pdClient := controller.NewFakePDClient(fakePDControl, tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		var healths []pdapi.MemberHealth
+		for _, ordinal := range tc.PDStsDesiredOrdinals(true).List() {
+			name := PdName(tc.Name, ordinal, tc.Namespace, tc.Spec.ClusterDomain, tc.Spec.AcrossK8s)
+			healths = append(healths, pdapi.MemberHealth{
+				Name:       name,
+				MemberID:   uint64(ordinal),
+				ClientUrls: []string{fmt.Sprintf("http://%s.test-pd-peer.default.svc:2379", PdPodName(tc.Name, ordinal))},
+				Health:     true,
+			})
+		}
+		return &pdapi.HealthInfo{Healths: healths}, nil
+	})
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: uint64(1)}, nil
+	})
+
+	g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+	g.Expect(tc.Status.PD.PeerMembers).To(BeEmpty())
+	g.Expect(tc.Status.PD.Members).To(HaveLen(3))
+	for _, name := range []string{"test-pd-3", "test-pd-4", "test-pd-5"} {
+		g.Expect(tc.Status.PD.Members).To(HaveKey(name))
+	}
+
+	// the ordinals start can't be changed after the statefulset is created
+	g.Expect(pmm.checkPDOrdinalsStart(tc, set)).To(Succeed())
+	tc.Spec.PD.OrdinalsStart = pointer.Int32Ptr(0)
+	err = pmm.checkPDOrdinalsStart(tc, set)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("can't be changed from 3 to 0"))
+	events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(ContainElement(ContainSubstring("FailedUpdatePDSTS")))
+}
+
+func TestPDMemberManagerSyncPDPVCDeletionProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name              string
+		protection        bool
+		confirmed         bool
+		paused            bool
+		hasFinalizer      bool
+		deleting          bool
+		deletedBy         string
+		expectFinalizer   bool
+		expectPVCUpdated  bool
+		expectTCFinalizer bool
+	}{
+		{
+			name:              "add finalizer if protection is enabled",
+			protection:        true,
+			expectFinalizer:   true,
+			expectPVCUpdated:  true,
+			expectTCFinalizer: true,
+		},
+		{
+			name:              "keep finalizer of the deleting pvc until the deletion is confirmed",
+			protection:        true,
+			hasFinalizer:      true,
+			deleting:          true,
+			expectFinalizer:   true,
+			expectTCFinalizer: true,
+		},
+		{
+			name:             "remove finalizer of the deleting pvc after the deletion is confirmed",
+			protection:       true,
+			confirmed:        true,
+			hasFinalizer:     true,
+			deleting:         true,
+			expectFinalizer:  false,
+			expectPVCUpdated: true,
+		},
+		{
+			name:             "remove finalizer if protection is disabled",
+			hasFinalizer:     true,
+			expectFinalizer:  false,
+			expectPVCUpdated: true,
+		},
+		{
+			name:            "do nothing if protection is disabled",
+			expectFinalizer: false,
+		},
+		{
+			name:              "remove finalizer of the pvc deleted by scale-in",
+			protection:        true,
+			hasFinalizer:      true,
+			deleting:          true,
+			deletedBy:         "scale-in",
+			expectFinalizer:   false,
+			expectPVCUpdated:  true,
+			expectTCFinalizer: true,
+		},
+		{
+			name:              "don't protect the pvc deleted by failover",
+			protection:        true,
+			deletedBy:         "failover",
+			expectFinalizer:   false,
+			expectTCFinalizer: true,
+		},
+		{
+			name:            "do nothing if paused",
+			protection:      true,
+			paused:          true,
+			expectFinalizer: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.DeletionProtection = pointer.BoolPtr(tt.protection)
+			tc.Spec.Paused = tt.paused
+			if tt.confirmed {
+				tc.Annotations = map[string]string{label.AnnPDPVCDeletionConfirmed: "true"}
+			}
+			pmm, _, pvcIndexer := newFakePDMemberManager()
+
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "pd-test-pd-0",
+					Namespace:       metav1.NamespaceDefault,
+					UID:             "pd-test-pd-0",
+					Labels:          label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+					ResourceVersion: "1",
+				},
+			}
+			if tt.hasFinalizer {
+				pvc.Finalizers = []string{"kubernetes.io/pvc-protection", label.PDPVCProtectionFinalizer}
+				tc.Finalizers = []string{label.PDPVCProtectionFinalizer}
+			}
+			if tt.deleting {
+				now := metav1.Now()
+				pvc.DeletionTimestamp = &now
+			}
+			switch tt.deletedBy {
+			case "scale-in":
+				pvc.Annotations = map[string]string{label.AnnPVCDeferDeleting: time.Now().Format(time.RFC3339)}
+			case "failover":
+				tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{
+					"test-pd-0": {PodName: "test-pd-0", PVCUIDSet: map[types.UID]v1alpha1.EmptyStruct{pvc.UID: {}}},
+				}
+			}
+			// pvc of other components is not touched
+			tikvPVC := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tikv-test-tikv-0",
+					Namespace: metav1.NamespaceDefault,
+					Labels:    label.New().Instance(tc.GetInstanceName()).TiKV().Labels(),
+				},
+			}
+			g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+			g.Expect(pvcIndexer.Add(tikvPVC)).To(Succeed())
+
+			g.Expect(pmm.syncPDPVCDeletionProtection(tc)).To(Succeed())
+
+			got, err := pmm.deps.PVCLister.PersistentVolumeClaims(metav1.NamespaceDefault).Get(pvc.Name)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.expectFinalizer {
+				g.Expect(got.Finalizers).To(ContainElement(label.PDPVCProtectionFinalizer))
+			} else {
+				g.Expect(got.Finalizers).NotTo(ContainElement(label.PDPVCProtectionFinalizer))
+			}
+			if tt.hasFinalizer {
+				g.Expect(got.Finalizers).To(ContainElement("kubernetes.io/pvc-protection"))
+			}
+			g.Expect(got != pvc).To(Equal(tt.expectPVCUpdated))
+			if tt.expectTCFinalizer {
+				g.Expect(tc.Finalizers).To(ContainElement(label.PDPVCProtectionFinalizer))
+			} else if !tt.paused {
+				g.Expect(tc.Finalizers).NotTo(ContainElement(label.PDPVCProtectionFinalizer))
+			}
+
+			gotTiKV, err := pmm.deps.PVCLister.PersistentVolumeClaims(metav1.NamespaceDefault).Get(tikvPVC.Name)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(gotTiKV.Finalizers).To(BeEmpty())
+		})
+	}
+}
+
+func TestPDMemberManagerReleasePDPVCDeletionProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.DeletionProtection = pointer.BoolPtr(true)
+	tc.Finalizers = []string{"example.com/other", label.PDPVCProtectionFinalizer}
+	pmm, _, pvcIndexer := newFakePDMemberManager()
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "pd-test-pd-0",
+			Namespace:  metav1.NamespaceDefault,
+			Labels:     label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+			Finalizers: []string{label.PDPVCProtectionFinalizer},
+		},
+	}
+	g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+
+	// the protection is released by the sync of the deleted tidb cluster, before anything else is synced
+	now := metav1.Now()
+	tc.DeletionTimestamp = &now
+	g.Expect(pmm.Sync(tc)).To(Succeed())
+	got, err := pmm.deps.PVCLister.PersistentVolumeClaims(metav1.NamespaceDefault).Get(pvc.Name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Finalizers).To(BeEmpty())
+	g.Expect(tc.Finalizers).To(Equal([]string{"example.com/other"}))
+	_, err = pmm.deps.ServiceLister.Services(tc.Namespace).Get(controller.PDMemberName(tc.Name))
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestPDMemberManagerSyncPDPVCAuditAnnotations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name              string
+		enabled           bool
+		phase             corev1.PersistentVolumeClaimPhase
+		annotations       map[string]string
+		expectAnnotations map[string]string
+		expectPVCUpdated  bool
+	}{
+		{
+			name:    "patch annotations to the bound pvc",
+			enabled: true,
+			phase:   corev1.ClaimBound,
+			annotations: map[string]string{
+				label.AnnPodNameKey: "test-pd-2",
+			},
+			expectAnnotations: map[string]string{
+				label.AnnPodNameKey:     "test-pd-2",
+				label.AnnPVCClusterName: "test",
+				label.AnnPVCComponent:   "pd",
+				label.AnnPVCPodOrdinal:  "2",
+			},
+			expectPVCUpdated: true,
+		},
+		{
+			name:    "skip the pending pvc",
+			enabled: true,
+			phase:   corev1.ClaimPending,
+		},
+		{
+			name:    "skip the pvc with annotations synced",
+			enabled: true,
+			phase:   corev1.ClaimBound,
+			annotations: map[string]string{
+				label.AnnPVCClusterName: "test",
+				label.AnnPVCComponent:   "pd",
+				label.AnnPVCPodOrdinal:  "2",
+			},
+			expectAnnotations: map[string]string{
+				label.AnnPVCClusterName: "test",
+				label.AnnPVCComponent:   "pd",
+				label.AnnPVCPodOrdinal:  "2",
+			},
+		},
+		{
+			name:  "do nothing if it's disabled",
+			phase: corev1.ClaimBound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.PVCAuditAnnotations = pointer.BoolPtr(tt.enabled)
+			pmm, _, pvcIndexer := newFakePDMemberManager()
+
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pd-test-pd-2",
+					Namespace:   metav1.NamespaceDefault,
+					Labels:      label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Phase: tt.phase,
+				},
+			}
+			g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+
+			g.Expect(pmm.syncPDPVCAuditAnnotations(tc)).To(Succeed())
+
+			got, err := pmm.deps.PVCLister.PersistentVolumeClaims(metav1.NamespaceDefault).Get(pvc.Name)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got != pvc).To(Equal(tt.expectPVCUpdated))
+			if tt.expectAnnotations != nil {
+				g.Expect(got.Annotations).To(Equal(tt.expectAnnotations))
+			} else {
+				g.Expect(got.Annotations).NotTo(HaveKey(label.AnnPVCPodOrdinal))
+			}
+		})
+	}
+}
+
+type fakeVolumeUsageGetter struct {
+	usages map[string]map[string]volumeUsage
+	errs   map[string]error
+}
+
+func (g *fakeVolumeUsageGetter) GetPVCUsage(_ context.Context, pod *corev1.Pod) (map[string]volumeUsage, error) {
+	if err := g.errs[pod.Name]; err != nil {
+		return nil, err
+	}
+	return g.usages[pod.Name], nil
+}
+
+func TestNewPDMemberManagerWithDiskUsageCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	pmm := NewPDMemberManager(deps, nil, nil, nil, nil, nil).(*pdMemberManager)
+	g.Expect(pmm.volumeUsageGetter).To(BeNil())
+
+	// the kubelet is only queried if the operator is allowed to
+	deps.CLIConfig.PDDiskUsageCheck = true
+	pmm = NewPDMemberManager(deps, nil, nil, nil, nil, nil).(*pdMemberManager)
+	g.Expect(pmm.volumeUsageGetter).NotTo(BeNil())
+}
+
+func TestPDMemberManagerSyncPDDiskPressureCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	usage := func(used uint64) map[string]volumeUsage {
+		return map[string]volumeUsage{"pd-test-pd-0": {UsedBytes: used, CapacityBytes: 100}}
+	}
+	tests := []struct {
+		name            string
+		threshold       *int32
+		usages          map[string]map[string]volumeUsage
+		errs            map[string]error
+		oldCondition    *metav1.ConditionStatus
+		expectCondition *metav1.ConditionStatus
+		expectMessage   string
+	}{
+		{
+			name:         "check is disabled",
+			usages:       map[string]map[string]volumeUsage{"test-pd-0": usage(99)},
+			oldCondition: conditionStatusPtr(metav1.ConditionTrue),
+		},
+		{
+			name:            "disk usage is below the threshold",
+			threshold:       pointer.Int32Ptr(90),
+			usages:          map[string]map[string]volumeUsage{"test-pd-0": usage(50), "test-pd-1": usage(89), "test-pd-2": usage(10)},
+			expectCondition: conditionStatusPtr(metav1.ConditionFalse),
+		},
+		{
+			name:            "disk usage exceeds the threshold",
+			threshold:       pointer.Int32Ptr(90),
+			usages:          map[string]map[string]volumeUsage{"test-pd-0": usage(50), "test-pd-1": usage(95), "test-pd-2": usage(90)},
+			expectCondition: conditionStatusPtr(metav1.ConditionTrue),
+			expectMessage:   "disk usage of pd members exceeds 90%: test-pd-1(95%), test-pd-2(90%)",
+		},
+		{
+			name:            "usage of part of the members is unavailable",
+			threshold:       pointer.Int32Ptr(90),
+			usages:          map[string]map[string]volumeUsage{"test-pd-0": usage(99)},
+			errs:            map[string]error{"test-pd-1": fmt.Errorf("node is unreachable"), "test-pd-2": fmt.Errorf("node is unreachable")},
+			expectCondition: conditionStatusPtr(metav1.ConditionTrue),
+			expectMessage:   "disk usage of pd members exceeds 90%: test-pd-0(99%)",
+		},
+		{
+			name:      "usage of all members is unavailable",
+			threshold: pointer.Int32Ptr(90),
+			errs: map[string]error{
+				"test-pd-0": fmt.Errorf("node is unreachable"),
+				"test-pd-1": fmt.Errorf("node is unreachable"),
+				"test-pd-2": fmt.Errorf("node is unreachable"),
+			},
+			oldCondition:    conditionStatusPtr(metav1.ConditionTrue),
+			expectCondition: conditionStatusPtr(metav1.ConditionUnknown),
+			expectMessage:   "volume usage of no pd member is available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.DiskPressureThreshold = tt.threshold
+			if tt.oldCondition != nil {
+				tc.Status.PD.SetCondition(metav1.Condition{
+					Type:   v1alpha1.PDDiskPressure,
+					Status: *tt.oldCondition,
+					Reason: "Test",
+				})
+			}
+			pmm, podIndexer, _ := newFakePDMemberManager()
+			pmm.volumeUsageGetter = &fakeVolumeUsageGetter{usages: tt.usages, errs: tt.errs}
+			for i := 0; i < 3; i++ {
+				podIndexer.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      PdPodName(tc.Name, int32(i)),
+						Namespace: tc.Namespace,
+					},
+					Spec: corev1.PodSpec{NodeName: "node-1"},
+				})
+			}
+
+			set, err := getNewPDSetForTidbCluster(tc, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			pmm.syncPDDiskPressureCondition(tc, set)
+			condition := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.PDDiskPressure)
+			if tt.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(*tt.expectCondition))
+			if tt.expectMessage != "" {
+				g.Expect(condition.Message).To(Equal(tt.expectMessage))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDClockSkewCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		threshold       *string
+		offsets         map[string]time.Duration
+		errs            map[string]error
+		unhealthy       []string
+		oldCondition    *metav1.ConditionStatus
+		expectCondition *metav1.ConditionStatus
+		expectMessage   string
+	}{
+		{
+			name:         "check is disabled",
+			offsets:      map[string]time.Duration{"test-pd-0": time.Minute},
+			oldCondition: conditionStatusPtr(metav1.ConditionTrue),
+		},
+		{
+			name:            "clock offset is within the threshold",
+			threshold:       pointer.StringPtr("2s"),
+			offsets:         map[string]time.Duration{"test-pd-0": 500 * time.Millisecond, "test-pd-1": -1500 * time.Millisecond, "test-pd-2": 0},
+			expectCondition: conditionStatusPtr(metav1.ConditionFalse),
+			expectMessage:   "clock offset of all pd members is within 2s",
+		},
+		{
+			name:            "clock offset exceeds the threshold",
+			threshold:       pointer.StringPtr("2s"),
+			offsets:         map[string]time.Duration{"test-pd-0": 500 * time.Millisecond, "test-pd-1": 3 * time.Second, "test-pd-2": -5 * time.Second},
+			expectCondition: conditionStatusPtr(metav1.ConditionTrue),
+			expectMessage:   "clock offset of pd members exceeds 2s: test-pd-1(3s), test-pd-2(-5s)",
+		},
+		{
+			name:            "unhealthy members are skipped",
+			threshold:       pointer.StringPtr("2s"),
+			offsets:         map[string]time.Duration{"test-pd-0": 0, "test-pd-1": 0, "test-pd-2": time.Minute},
+			unhealthy:       []string{"test-pd-2"},
+			expectCondition: conditionStatusPtr(metav1.ConditionFalse),
+		},
+		{
+			name:            "offset of part of the members is unavailable",
+			threshold:       pointer.StringPtr("2s"),
+			offsets:         map[string]time.Duration{"test-pd-0": 10 * time.Second},
+			errs:            map[string]error{"test-pd-1": fmt.Errorf("timeout"), "test-pd-2": fmt.Errorf("timeout")},
+			expectCondition: conditionStatusPtr(metav1.ConditionTrue),
+			expectMessage:   "clock offset of pd members exceeds 2s: test-pd-0(10s)",
+		},
+		{
+			name:      "offset of all members is unavailable",
+			threshold: pointer.StringPtr("2s"),
+			errs: map[string]error{
+				"test-pd-0": fmt.Errorf("timeout"),
+				"test-pd-1": fmt.Errorf("timeout"),
+				"test-pd-2": fmt.Errorf("timeout"),
+			},
+			oldCondition:    conditionStatusPtr(metav1.ConditionTrue),
+			expectCondition: conditionStatusPtr(metav1.ConditionTrue),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ClockSkewThreshold = tt.threshold
+			if tt.oldCondition != nil {
+				tc.Status.PD.SetCondition(metav1.Condition{
+					Type:   v1alpha1.PDClockSkew,
+					Status: *tt.oldCondition,
+					Reason: "Test",
+				})
+			}
+			pmm, _, _ := newFakePDMemberManager()
+			pdControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+			for i := 0; i < 3; i++ {
+				name := PdPodName(tc.Name, int32(i))
+				tc.Status.PD.Members[name] = v1alpha1.PDMember{
+					Name:      name,
+					ClientURL: fmt.Sprintf("http://%s.%s-pd-peer.%s.svc:2379", name, tc.Name, tc.Namespace),
+					Health:    !sets.NewString(tt.unhealthy...).Has(name),
+				}
+				offset, offsetErr := tt.offsets[name], tt.errs[name]
+				pdClient := pdapi.NewFakePDClient()
+				pdClient.AddReaction(pdapi.GetClockOffsetActionType, func(action *pdapi.Action) (interface{}, error) {
+					if offsetErr != nil {
+						return nil, offsetErr
+					}
+					return offset, nil
+				})
+				pdControl.SetPDClientWithAddress(name, pdClient)
+			}
+
+			pmm.syncPDClockSkewCondition(tc)
+			condition := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.PDClockSkew)
+			if tt.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(*tt.expectCondition))
+			if tt.expectMessage != "" {
+				g.Expect(condition.Message).To(Equal(tt.expectMessage))
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDMismatchedClusterIDMembers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		disabled       bool
+		clusterIDs     map[string]uint64
+		errs           map[string]error
+		unhealthy      []string
+		oldMismatched  map[string]string
+		expectMismatch map[string]string
+		expectEvents   int
+	}{
+		{
+			name:       "all members report the cluster id",
+			clusterIDs: map[string]uint64{"test-pd-0": 1, "test-pd-1": 1, "test-pd-2": 1},
+		},
+		{
+			name:           "a member reports a mismatched cluster id",
+			clusterIDs:     map[string]uint64{"test-pd-0": 1, "test-pd-1": 2, "test-pd-2": 1},
+			expectMismatch: map[string]string{"test-pd-1": "2"},
+			expectEvents:   1,
+		},
+		{
+			name:           "the mismatched member is reported only once",
+			clusterIDs:     map[string]uint64{"test-pd-0": 1, "test-pd-1": 2, "test-pd-2": 1},
+			oldMismatched:  map[string]string{"test-pd-1": "2"},
+			expectMismatch: map[string]string{"test-pd-1": "2"},
+		},
+		{
+			name:           "the mismatched member is kept if its cluster id is unavailable",
+			clusterIDs:     map[string]uint64{"test-pd-0": 1, "test-pd-2": 1},
+			errs:           map[string]error{"test-pd-1": fmt.Errorf("timeout")},
+			oldMismatched:  map[string]string{"test-pd-1": "2"},
+			expectMismatch: map[string]string{"test-pd-1": "2"},
+		},
+		{
+			name:          "the member reporting the cluster id again is removed",
+			clusterIDs:    map[string]uint64{"test-pd-0": 1, "test-pd-1": 1, "test-pd-2": 1},
+			oldMismatched: map[string]string{"test-pd-1": "2"},
+		},
+		{
+			name:       "unhealthy members are skipped",
+			clusterIDs: map[string]uint64{"test-pd-0": 1, "test-pd-1": 2, "test-pd-2": 1},
+			unhealthy:  []string{"test-pd-1"},
+		},
+		{
+			name:          "the check is disabled",
+			disabled:      true,
+			clusterIDs:    map[string]uint64{"test-pd-0": 1, "test-pd-1": 2, "test-pd-2": 1},
+			oldMismatched: map[string]string{"test-pd-1": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.CheckMemberClusterID = pointer.BoolPtr(!tt.disabled)
+			tc.Status.PD.MismatchedClusterIDMembers = tt.oldMismatched
+			pmm, _, _ := newFakePDMemberManager()
+			pdControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+			for i := 0; i < 3; i++ {
+				name := PdPodName(tc.Name, int32(i))
+				tc.Status.PD.Members[name] = v1alpha1.PDMember{
+					Name:      name,
+					ClientURL: fmt.Sprintf("http://%s.%s-pd-peer.%s.svc:2379", name, tc.Name, tc.Namespace),
+					Health:    !sets.NewString(tt.unhealthy...).Has(name),
+				}
+				clusterID, clusterErr := tt.clusterIDs[name], tt.errs[name]
+				pdClient := pdapi.NewFakePDClient()
+				pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+					if clusterErr != nil {
+						return nil, clusterErr
+					}
+					return &metapb.Cluster{Id: clusterID}, nil
+				})
+				pdControl.SetPDClientWithAddress(name, pdClient)
+			}
+
+			pmm.syncPDMismatchedClusterIDMembers(tc, 1)
+			if len(tt.expectMismatch) == 0 {
+				g.Expect(tc.Status.PD.MismatchedClusterIDMembers).To(BeEmpty())
+			} else {
+				g.Expect(tc.Status.PD.MismatchedClusterIDMembers).To(Equal(tt.expectMismatch))
+			}
+			recorder := pmm.deps.Recorder.(*record.FakeRecorder)
+			g.Expect(recorder.Events).To(HaveLen(tt.expectEvents))
+			if tt.expectEvents > 0 {
+				g.Expect(<-recorder.Events).To(ContainSubstring("PDClusterIDMismatch"))
+			}
+		})
+	}
+}
+
+func TestPDDashboardTiDBCAProjected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	projected := &corev1.ProjectedVolumeSource{
+		Sources: []corev1.VolumeProjection{
+			{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "tidb-ca-bundle"},
+					Items:                []corev1.KeyToPath{{Key: "bundle.pem", Path: "ca.crt"}},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		projected            *corev1.ProjectedVolumeSource
+		skipInternalClientCA bool
+		expectCAPath         string
+		expectProjected      bool
+	}{
+		{
+			name:         "CA in the client secret",
+			expectCAPath: "/var/lib/tidb-client-tls/ca.crt",
+		},
+		{
+			name:            "CA in the projected volume",
+			projected:       projected,
+			expectCAPath:    "/var/lib/tidb-client-ca/ca.crt",
+			expectProjected: true,
+		},
+		{
+			name:                 "internal client CA is skipped",
+			projected:            projected,
+			skipInternalClientCA: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.Image = "pingcap/pd:v7.5.0"
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			tc.Spec.PD.DashboardTiDBCAProjected = tt.projected
+			tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+				TLSClient: &v1alpha1.TiDBTLSClient{
+					Enabled:              true,
+					SkipInternalClientCA: tt.skipInternalClientCA,
+				},
+			}
+
+			cm, err := getPDConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewPDConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			if tt.expectCAPath == "" {
+				g.Expect(config.Get("dashboard.tidb-cacert-path")).To(BeNil())
+			} else {
+				g.Expect(config.Get("dashboard.tidb-cacert-path").MustString()).To(Equal(tt.expectCAPath))
+			}
+
+			sts, err := getNewPDSetForTidbCluster(tc, cm)
+			g.Expect(err).NotTo(HaveOccurred())
+			var vol *corev1.Volume
+			for i := range sts.Spec.Template.Spec.Volumes {
+				if sts.Spec.Template.Spec.Volumes[i].Name == "tidb-client-ca" {
+					vol = &sts.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			var mount *corev1.VolumeMount
+			for _, c := range sts.Spec.Template.Spec.Containers {
+				if c.Name != v1alpha1.PDMemberType.String() {
+					continue
+				}
+				for i := range c.VolumeMounts {
+					if c.VolumeMounts[i].Name == "tidb-client-ca" {
+						mount = &c.VolumeMounts[i]
+					}
+				}
+			}
+			if tt.expectProjected {
+				g.Expect(vol).NotTo(BeNil())
+				g.Expect(vol.Projected).To(Equal(tt.projected))
+				g.Expect(mount).NotTo(BeNil())
+				g.Expect(mount.MountPath).To(Equal("/var/lib/tidb-client-ca"))
+			} else {
+				g.Expect(vol).To(BeNil())
+				g.Expect(mount).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestPDMemberManagerSyncStatusWithUpgradeStabilizationChecks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.UpgradeStabilizationChecks = pointer.Int32Ptr(2)
+	tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	pmm, _, _ := newFakePDMemberManager()
+
+	healthy := true
+	fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+	pdClient := controller.NewFakePDClient(fakePDControl, tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+			{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: healthy},
+			{Name: "test-pd-2", MemberID: uint64(3), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: true},
+		}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: uint64(1)}, nil
+	})
+	pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdpb.Member{Name: "test-pd-0"}, nil
+	})
+
+	// the rollout has completed
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	steps := []struct {
+		healthy            bool
+		expectPhase        v1alpha1.MemberPhase
+		expectStableChecks int32
+	}{
+		{healthy: true, expectPhase: v1alpha1.UpgradePhase, expectStableChecks: 1},
+		// an unhealthy member resets the count
+		{healthy: false, expectPhase: v1alpha1.UpgradePhase, expectStableChecks: 0},
+		{healthy: true, expectPhase: v1alpha1.UpgradePhase, expectStableChecks: 1},
+		{healthy: true, expectPhase: v1alpha1.NormalPhase, expectStableChecks: 0},
+		{healthy: true, expectPhase: v1alpha1.NormalPhase, expectStableChecks: 0},
+	}
+	for i, step := range steps {
+		healthy = step.healthy
+		g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+		g.Expect(tc.Status.PD.Phase).To(Equal(step.expectPhase), "step %d", i)
+		g.Expect(tc.Status.PD.UpgradeStableChecks).To(Equal(step.expectStableChecks), "step %d", i)
+	}
+
+	// the phase leaves Upgrade once the rollout completes by default
+	tc.Spec.PD.UpgradeStabilizationChecks = nil
+	tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+	g.Expect(tc.Status.PD.Phase).To(Equal(v1alpha1.NormalPhase))
+}
+
+func TestPDMemberManagerSyncStatusWithLeaderHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, _, _ := newFakePDMemberManager()
+
+	leaderName := "test-pd-0"
+	var leaderErr error
+	fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+	pdClient := controller.NewFakePDClient(fakePDControl, tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+			{Name: "test-pd-0", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-0.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "test-pd-1", MemberID: uint64(2), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: true},
+			{Name: "test-pd-2", MemberID: uint64(3), ClientUrls: []string{"http://test-pd-2.test-pd-peer.default.svc:2379"}, Health: true},
+		}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: uint64(1)}, nil
+	})
+	pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdpb.Member{Name: leaderName}, leaderErr
+	})
+
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sync := func(leader string) []string {
+		leaderName = leader
+		g.Expect(pmm.syncTidbClusterStatus(tc, set)).To(Succeed())
+		var names []string
+		for _, change := range tc.Status.PD.LeaderHistory {
+			g.Expect(change.Time.IsZero()).To(BeFalse())
+			names = append(names, change.Name)
+		}
+		return names
+	}
+	g.Expect(sync("test-pd-0")).To(Equal([]string{"test-pd-0"}))
+	g.Expect(tc.Status.PD.LeaderHistory[0].ID).To(Equal("1"))
+	// nothing is recorded if the leader doesn't change
+	g.Expect(sync("test-pd-0")).To(Equal([]string{"test-pd-0"}))
+	g.Expect(sync("test-pd-1")).To(Equal([]string{"test-pd-0", "test-pd-1"}))
+	g.Expect(sync("test-pd-0")).To(Equal([]string{"test-pd-0", "test-pd-1", "test-pd-0"}))
+}
+
+func TestRecordPDLeaderChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	// nothing is recorded if the leader is unknown
+	recordPDLeaderChange(tc, metav1.Now())
+	g.Expect(tc.Status.PD.LeaderHistory).To(BeEmpty())
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		tc.Status.PD.Leader = v1alpha1.PDMember{Name: fmt.Sprintf("test-pd-%d", i%3), ID: fmt.Sprintf("%d", i%3+1)}
+		recordPDLeaderChange(tc, metav1.NewTime(start.Add(time.Duration(i)*time.Second)))
+		// the same leader is recorded once
+		recordPDLeaderChange(tc, metav1.NewTime(start.Add(time.Duration(i)*time.Second+time.Millisecond)))
+	}
+
+	// the history is bounded and the oldest changes are dropped
+	history := tc.Status.PD.LeaderHistory
+	g.Expect(history).To(HaveLen(pdLeaderHistoryLimit))
+	for i, change := range history {
+		n := 25 - pdLeaderHistoryLimit + i
+		g.Expect(change.Name).To(Equal(fmt.Sprintf("test-pd-%d", n%3)))
+		g.Expect(change.ID).To(Equal(fmt.Sprintf("%d", n%3+1)))
+		g.Expect(change.Time.Time).To(Equal(start.Add(time.Duration(n) * time.Second)))
+	}
+
+	// a recreated member with the same name is a new leader
+	tc.Status.PD.Leader = v1alpha1.PDMember{Name: history[len(history)-1].Name, ID: "100"}
+	recordPDLeaderChange(tc, metav1.Now())
+	g.Expect(tc.Status.PD.LeaderHistory).To(HaveLen(pdLeaderHistoryLimit))
+	g.Expect(tc.Status.PD.LeaderHistory[pdLeaderHistoryLimit-1].ID).To(Equal("100"))
+}
+
+func TestGetNewPDNetworkPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	operatorPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "tidb-admin"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{label.NameLabelKey: "tidb-operator"}},
+	}
+	tc.Spec.PD.NetworkPolicy = &v1alpha1.PDNetworkPolicy{
+		Enabled: pointer.BoolPtr(true),
+		From:    []networkingv1.NetworkPolicyPeer{operatorPeer},
+	}
+	policy := getNewPDNetworkPolicy(tc)
+
+	g.Expect(policy.Name).To(Equal("test-pd"))
+	g.Expect(policy.Namespace).To(Equal(tc.Namespace))
+	g.Expect(policy.OwnerReferences).To(Equal([]metav1.OwnerReference{controller.GetOwnerRef(tc)}))
+	g.Expect(policy.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+	g.Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+		label.NameLabelKey:      "tidb-cluster",
+		label.ManagedByLabelKey: label.TiDBOperator,
+		label.InstanceLabelKey:  "test",
+		label.ComponentLabelKey: label.PDLabelVal,
+	}))
+
+	g.Expect(policy.Spec.Ingress).To(HaveLen(1))
+	rule := policy.Spec.Ingress[0]
+	g.Expect(rule.Ports).To(BeEmpty())
+
+	// the configured peers are appended to the members of the cluster
+	g.Expect(rule.From[len(rule.From)-1]).To(Equal(operatorPeer))
+
+	var components []string
+	for _, peer := range rule.From[:len(rule.From)-1] {
+		g.Expect(peer.NamespaceSelector).To(BeNil())
+		g.Expect(peer.IPBlock).To(BeNil())
+		g.Expect(peer.PodSelector.MatchLabels).To(HaveKeyWithValue(label.InstanceLabelKey, "test"))
+		g.Expect(peer.PodSelector.MatchLabels).To(HaveKeyWithValue(label.ManagedByLabelKey, label.TiDBOperator))
+		components = append(components, peer.PodSelector.MatchLabels[label.ComponentLabelKey])
+	}
+	g.Expect(components).To(ConsistOf(label.PDLabelVal, label.TiKVLabelVal, label.TiDBLabelVal, label.TiFlashLabelVal,
+		label.TiCDCLabelVal, label.TiProxyLabelVal, label.PumpLabelVal, label.DiscoveryLabelVal))
+}
+
+func TestPDMemberManagerSyncPDNetworkPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, _, _ := newFakePDMemberManager()
+	fakeCli := pmm.deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	key := client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMemberName(tc.Name)}
+
+	// nothing is created if the network policy is not enabled
+	g.Expect(pmm.syncPDNetworkPolicy(tc)).To(Succeed())
+	err := fakeCli.Get(context.TODO(), key, &networkingv1.NetworkPolicy{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	tc.Spec.PD.NetworkPolicy = &v1alpha1.PDNetworkPolicy{Enabled: pointer.BoolPtr(true)}
+	g.Expect(pmm.syncPDNetworkPolicy(tc)).To(Succeed())
+	policy := &networkingv1.NetworkPolicy{}
+	g.Expect(fakeCli.Get(context.TODO(), key, policy)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(policy, tc)).To(BeTrue())
+	g.Expect(policy.Spec).To(Equal(getNewPDNetworkPolicy(tc).Spec))
+	policyIndexer := pmm.deps.LabelFilterKubeInformerFactory.Networking().V1().NetworkPolicies().Informer().GetIndexer()
+	g.Expect(policyIndexer.Add(policy)).To(Succeed())
+
+	// the network policy not created by the operator is kept
+	tc.Spec.PD.NetworkPolicy.Enabled = pointer.BoolPtr(false)
+	foreign := policy.DeepCopy()
+	foreign.OwnerReferences = nil
+	g.Expect(policyIndexer.Update(foreign)).To(Succeed())
+	g.Expect(pmm.syncPDNetworkPolicy(tc)).To(Succeed())
+	g.Expect(fakeCli.Get(context.TODO(), key, &networkingv1.NetworkPolicy{})).To(Succeed())
+	g.Expect(policyIndexer.Update(policy)).To(Succeed())
+
+	// the network policy is deleted when it's disabled
+	g.Expect(pmm.syncPDNetworkPolicy(tc)).To(Succeed())
+	err = fakeCli.Get(context.TODO(), key, &networkingv1.NetworkPolicy{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestGetNewPDSetWithImagePullPolicyOverride(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.ImagePullPolicy = corev1.PullAlways
+
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findPDContainer(tc, set).ImagePullPolicy).To(Equal(corev1.PullAlways))
+
+	pullPolicy := corev1.PullIfNotPresent
+	tc.Spec.PD.ImagePullPolicy = &pullPolicy
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findPDContainer(tc, set).ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+	// the override of PD does not leak to other components
+	g.Expect(tc.BaseTiKVSpec().ImagePullPolicy()).To(Equal(corev1.PullAlways))
+}
+
+func TestGetPDBootstrapPodManagementPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newSet := func(policy apps.PodManagementPolicyType) *apps.StatefulSet {
+		return &apps.StatefulSet{Spec: apps.StatefulSetSpec{PodManagementPolicy: policy}}
+	}
+	tests := []struct {
+		name         string
+		oldSet       *apps.StatefulSet
+		bootstrapped bool
+		expect       apps.PodManagementPolicyType
+	}{
+		{
+			name:   "bootstrap a new cluster",
+			expect: apps.ParallelPodManagement,
+		},
+		{
+			name:         "recreate the statefulset of a bootstrapped cluster",
+			bootstrapped: true,
+			expect:       apps.OrderedReadyPodManagement,
+		},
+		{
+			name:         "keep the policy of an existing parallel statefulset",
+			oldSet:       newSet(apps.ParallelPodManagement),
+			bootstrapped: true,
+			expect:       apps.ParallelPodManagement,
+		},
+		{
+			name:         "keep the policy of an existing ordered statefulset",
+			oldSet:       newSet(apps.OrderedReadyPodManagement),
+			bootstrapped: true,
+			expect:       apps.OrderedReadyPodManagement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ParallelBootstrap = pointer.BoolPtr(true)
+			if tt.bootstrapped {
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{"test-pd-0": {Name: "test-pd-0", Health: true}}
+			}
+			g.Expect(getPDBootstrapPodManagementPolicy(tc, tt.oldSet)).To(Equal(tt.expect))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncStatefulSetWithParallelBootstrap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name              string
+		parallelBootstrap *bool
+		policy            apps.PodManagementPolicyType
+		bootstrapped      bool
+		expect            apps.PodManagementPolicyType
+	}{
+		{
+			name:   "parallel bootstrap is not enabled",
+			policy: apps.OrderedReadyPodManagement,
+			expect: apps.OrderedReadyPodManagement,
+		},
+		{
+			name:              "bootstrap with parallel policy",
+			parallelBootstrap: pointer.BoolPtr(true),
+			policy:            apps.OrderedReadyPodManagement,
+			expect:            apps.ParallelPodManagement,
+		},
+		{
+			name:              "recreate with ordered policy",
+			parallelBootstrap: pointer.BoolPtr(true),
+			bootstrapped:      true,
+			expect:            apps.OrderedReadyPodManagement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ParallelBootstrap = tt.parallelBootstrap
+			tc.Spec.PD.PodManagementPolicy = tt.policy
+			if tt.bootstrapped {
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{"test-pd-0": {Name: "test-pd-0", Health: true}}
+			}
+			pmm, _, _ := newFakePDMemberManager()
+
+			err := pmm.syncPDStatefulSetForTidbCluster(tc)
+			g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			set, err := pmm.deps.StatefulSetLister.StatefulSets(tc.Namespace).Get(controller.PDMemberName(tc.Name))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(set.Spec.PodManagementPolicy).To(Equal(tt.expect))
+		})
+	}
+}
+
+type recordingPDFailover struct {
+	fakePDFailover
+	failoverCalls int
+}
+
+func (f *recordingPDFailover) Failover(_ *v1alpha1.TidbCluster) error {
+	f.failoverCalls++
+	return nil
+}
+
+func TestPDMemberManagerSyncStatefulSetWithFailoverDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectCalls int
+	}{
+		{
+			name:        "failover is not disabled",
+			expectCalls: 1,
+		},
+		{
+			name:        "failover is disabled by annotation",
+			annotations: map[string]string{label.AnnPDDisableFailover: ""},
+			expectCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.MaxFailoverCount = pointer.Int32Ptr(3)
+			pmm, _, _ := newFakePDMemberManager()
+			pmm.deps.CLIConfig.AutoFailover = true
+			failover := &recordingPDFailover{}
+			pmm.failover = failover
+
+			// create the statefulset
+			err := pmm.syncPDStatefulSetForTidbCluster(tc)
+			g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+			tc.Annotations = tt.annotations
+			tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{
+				"test-pd-0": {PodName: "test-pd-0"},
+			}
+			err = pmm.syncPDStatefulSetForTidbCluster(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(failover.failoverCalls).To(Equal(tt.expectCalls))
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDMemberHealthConditions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	pmm, podIndexer, _ := newFakePDMemberManager()
+	for i := 0; i < 4; i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-pd-%d", i),
+				Namespace: tc.Namespace,
+			},
+		}
+		if i == 2 {
+			pod.Status.Conditions = []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				{Type: v1alpha1.PDMemberHealthy, Status: corev1.ConditionTrue},
+			}
+		}
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-0":                          {Name: "test-pd-0", Health: true},
+		"test-pd-1.test-pd-peer.default.svc": {Name: "test-pd-1.test-pd-peer.default.svc", Health: false},
+		"test-pd-2":                          {Name: "test-pd-2", Health: false},
+		// the pod of the member doesn't exist
+		"test-pd-5": {Name: "test-pd-5", Health: true},
+	}
+
+	g.Expect(pmm.syncPDMemberHealthConditions(tc)).To(Succeed())
+
+	for podName, expect := range map[string]corev1.ConditionStatus{
+		"test-pd-0": corev1.ConditionTrue,
+		"test-pd-1": corev1.ConditionFalse,
+		"test-pd-2": corev1.ConditionFalse,
+		"test-pd-3": "",
+	} {
+		pod, err := pmm.deps.PodLister.Pods(tc.Namespace).Get(podName)
+		g.Expect(err).NotTo(HaveOccurred())
+		_, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDMemberHealthy)
+		if expect == "" {
+			g.Expect(cond).To(BeNil(), podName)
+			continue
+		}
+		g.Expect(cond).NotTo(BeNil(), podName)
+		g.Expect(cond.Status).To(Equal(expect), podName)
+	}
+	// the other conditions are kept
+	pod, err := pmm.deps.PodLister.Pods(tc.Namespace).Get("test-pd-2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(k8s.IsPodReady(pod)).To(BeTrue())
+
+	// the pod is not updated if the condition doesn't change
+	pmm.deps.PodControl.(*controller.FakePodControl).SetUpdatePodError(fmt.Errorf("API server failed"), 0)
+	g.Expect(pmm.syncPDMemberHealthConditions(tc)).To(Succeed())
+}
+
+func TestGetNewPDSetForTidbClusterWithFailoverReadinessInitialDelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	set, err := getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Spec.Template.Spec.ReadinessGates).To(BeEmpty())
+
+	tc.Spec.PD.FailoverReadinessInitialDelaySeconds = pointer.Int32Ptr(120)
+	set, err = getNewPDSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Spec.Template.Spec.ReadinessGates).To(Equal([]corev1.PodReadinessGate{
+		{ConditionType: v1alpha1.PDFailoverReady},
+	}))
+}
+
+func TestPDMemberManagerSyncPDFailoverReadyConditions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.FailoverReadinessInitialDelaySeconds = pointer.Int32Ptr(120)
+	// test-pd-3 and test-pd-4 are the failover pods
+	tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{
+		"test-pd-0": {PodName: "test-pd-0", MemberDeleted: true},
+		"test-pd-1": {PodName: "test-pd-1", MemberDeleted: true},
+	}
+	pmm, podIndexer, _ := newFakePDMemberManager()
+
+	running := func(d time.Duration) []corev1.ContainerStatus {
+		return []corev1.ContainerStatus{{
+			Name: v1alpha1.PDMemberType.String(),
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-d))},
+			},
+		}}
+	}
+	pods := map[string]corev1.PodStatus{
+		"test-pd-0": {ContainerStatuses: running(time.Second)},
+		"test-pd-3": {ContainerStatuses: running(time.Minute)},
+		"test-pd-4": {ContainerStatuses: running(5 * time.Minute)},
+		// the pod without the readiness gate is left untouched
+		"test-pd-5": {ContainerStatuses: running(time.Second)},
+	}
+	for name, status := range pods {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: tc.Namespace,
+				Labels:    label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+			},
+			Status: status,
+		}
+		if name != "test-pd-5" {
+			pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: v1alpha1.PDFailoverReady}}
+		}
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+
+	requeueAfter, err := pmm.syncPDFailoverReadyConditions(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	// the sync is requeued when the initial delay of test-pd-3 elapses
+	g.Expect(requeueAfter).To(BeNumerically("~", time.Minute, 10*time.Second))
+
+	for podName, expect := range map[string]corev1.ConditionStatus{
+		"test-pd-0": corev1.ConditionTrue,
+		"test-pd-3": corev1.ConditionFalse,
+		"test-pd-4": corev1.ConditionTrue,
+		"test-pd-5": "",
+	} {
+		pod, err := pmm.deps.PodLister.Pods(tc.Namespace).Get(podName)
+		g.Expect(err).NotTo(HaveOccurred())
+		_, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDFailoverReady)
+		if expect == "" {
+			g.Expect(cond).To(BeNil(), podName)
+			continue
+		}
+		g.Expect(cond).NotTo(BeNil(), podName)
+		g.Expect(cond.Status).To(Equal(expect), podName)
+	}
+
+	// the condition is set once the initial delay of the failover pod elapses
+	pod, err := pmm.deps.PodLister.Pods(tc.Namespace).Get("test-pd-3")
+	g.Expect(err).NotTo(HaveOccurred())
+	pod = pod.DeepCopy()
+	pod.Status.ContainerStatuses = running(3 * time.Minute)
+	g.Expect(podIndexer.Update(pod)).To(Succeed())
+	requeueAfter, err = pmm.syncPDFailoverReadyConditions(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeueAfter).To(BeZero())
+	pod, err = pmm.deps.PodLister.Pods(tc.Namespace).Get("test-pd-3")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDFailoverReady)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal("InitialDelayElapsed"))
+}
+
+func TestPDMemberManagerSyncPDFailoverReadyConditionsWhenPaused(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.Paused = true
+	tc.Spec.PD.FailoverReadinessInitialDelaySeconds = pointer.Int32Ptr(120)
+	tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{
+		"test-pd-0": {PodName: "test-pd-0", MemberDeleted: true},
+	}
+	pmm, podIndexer, _ := newFakePDMemberManager()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pd-3",
+			Namespace: tc.Namespace,
+			Labels:    label.New().Instance(tc.GetInstanceName()).PD().Labels(),
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: v1alpha1.PDFailoverReady}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: v1alpha1.PDMemberType.String(),
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-time.Minute))},
+				},
+			}},
+		},
+	}
+	g.Expect(podIndexer.Add(pod)).To(Succeed())
+
+	// the condition is synced and the sync is requeued when the initial delay elapses though it's paused
+	err := pmm.syncPDStatefulSetForTidbCluster(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(controller.GetRequeueAfter(err)).To(BeNumerically("~", time.Minute, 10*time.Second))
+	pod, err = pmm.deps.PodLister.Pods(tc.Namespace).Get("test-pd-3")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, cond := k8s.GetPodCondition(&pod.Status, v1alpha1.PDFailoverReady)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+}
+
+func TestGetPDContainerStartedAt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	startedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	running := func(name string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}},
+		}
+	}
+	waiting := func(name string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		containerName *string
+		statuses      []corev1.ContainerStatus
+		expectStarted bool
+	}{
+		{
+			name:          "default container name",
+			statuses:      []corev1.ContainerStatus{running("istio-proxy"), running("pd")},
+			expectStarted: true,
+		},
+		{
+			name:          "custom container name",
+			containerName: pointer.StringPtr("pd-server"),
+			statuses:      []corev1.ContainerStatus{running("istio-proxy"), running("pd-server")},
+			expectStarted: true,
+		},
+		{
+			name:          "custom container name not rolled out yet",
+			containerName: pointer.StringPtr("pd-server"),
+			statuses:      []corev1.ContainerStatus{running("pd")},
+			expectStarted: true,
+		},
+		{
+			name:          "custom container name not running",
+			containerName: pointer.StringPtr("pd-server"),
+			statuses:      []corev1.ContainerStatus{running("istio-proxy"), waiting("pd-server")},
+		},
+		{
+			name:          "other containers only",
+			containerName: pointer.StringPtr("pd-server"),
+			statuses:      []corev1.ContainerStatus{running("istio-proxy")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ContainerName = tt.containerName
+			pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: tt.statuses}}
+
+			got := getPDContainerStartedAt(tc, pod)
+			if !tt.expectStarted {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).NotTo(BeNil())
+			g.Expect(*got).To(Equal(startedAt))
+		})
+	}
+}
+
+func TestGetNewPDServiceWithTopologyAwareRouting(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		enabled     *bool
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		{
+			name: "topology aware routing is not enabled",
+		},
+		{
+			name:    "topology aware routing is disabled",
+			enabled: pointer.BoolPtr(false),
+		},
+		{
+			name:    "topology aware routing is enabled",
+			enabled: pointer.BoolPtr(true),
+			expected: map[string]string{
+				label.AnnServiceTopologyMode:       "Auto",
+				label.AnnServiceTopologyAwareHints: "auto",
 			},
-			pods: pods,
-			want: false,
 		},
 		{
-			name: "PD url is misleading",
-			tc: &v1alpha1.TidbCluster{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "failover",
-					Namespace: v1.NamespaceDefault,
-				},
-				Spec: v1alpha1.TidbClusterSpec{
-					PD: &v1alpha1.PDSpec{
-						Replicas: 2,
-					},
-				},
-				Status: v1alpha1.TidbClusterStatus{
-					PD: v1alpha1.PDStatus{
-						Members: map[string]v1alpha1.PDMember{
-							"err-pd-0": {
-								Name:   "err-pd-0",
-								Health: true,
-							},
-							"failover-pd-1": {
-								Name:   "failover-pd-1",
-								Health: true,
-							},
-						},
-						FailureMembers: map[string]v1alpha1.PDFailureMember{
-							"failover-pd-0": {
-								PodName: "failover-pd-0",
-							},
-						},
-					},
-				},
+			name:    "annotations of users take precedence",
+			enabled: pointer.BoolPtr(true),
+			annotations: map[string]string{
+				label.AnnServiceTopologyMode: "Disabled",
+				"foo":                        "bar",
 			},
-			pods: []*v1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "failover-pd-0",
-						Namespace: v1.NamespaceDefault,
-					},
-					Status: v1.PodStatus{
-						Conditions: []v1.PodCondition{
-							{
-								Type:   corev1.PodReady,
-								Status: corev1.ConditionTrue,
-							},
-						},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "err-pd-1",
-						Namespace: v1.NamespaceDefault,
-					},
-					Status: v1.PodStatus{
-						Conditions: []v1.PodCondition{
-							{
-								Type:   corev1.PodReady,
-								Status: corev1.ConditionTrue,
-							},
-						},
-					},
-				},
+			expected: map[string]string{
+				label.AnnServiceTopologyMode:       "Disabled",
+				label.AnnServiceTopologyAwareHints: "auto",
+				"foo":                              "bar",
 			},
-			want: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			fakeDeps := controller.NewFakeDependencies()
-			for _, pod := range tt.pods {
-				fakeDeps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.TopologyAwareRouting = tt.enabled
+			if tt.annotations != nil {
+				tc.Spec.PD.Service = &v1alpha1.ServiceSpec{Annotations: tt.annotations}
 			}
-			kubeInformerFactory := fakeDeps.KubeInformerFactory
-			kubeInformerFactory.Start(ctx.Done())
-			kubeInformerFactory.WaitForCacheSync(ctx.Done())
-			pdMemberManager := &pdMemberManager{deps: fakeDeps}
-			got := pdMemberManager.shouldRecover(tt.tc)
-			if got != tt.want {
-				t.Fatalf("wants %v, got %v", tt.want, got)
+			pmm, _, _ := newFakePDMemberManager()
+
+			svc := pmm.getNewPDServiceForTidbCluster(tc)
+			if tt.expected == nil {
+				g.Expect(svc.Annotations).To(BeEmpty())
+			} else {
+				g.Expect(svc.Annotations).To(Equal(tt.expected))
 			}
 		})
 	}
 }
 
-func hasTLSVol(sts *apps.StatefulSet) bool {
-	for _, vol := range sts.Spec.Template.Spec.Volumes {
-		if vol.Name == "tidb-client-tls" {
-			return true
-		}
+func TestGetNewPDServicesWithPreferIPv6(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		clusterIPv6 bool
+		pdIPv6      *bool
+		expected    bool
+	}{
+		{
+			name: "ipv6 is not preferred",
+		},
+		{
+			name:        "ipv6 is preferred by the cluster",
+			clusterIPv6: true,
+			expected:    true,
+		},
+		{
+			name:     "ipv6 is preferred by pd only",
+			pdIPv6:   pointer.BoolPtr(true),
+			expected: true,
+		},
+		{
+			name:        "pd overrides the cluster",
+			clusterIPv6: true,
+			pdIPv6:      pointer.BoolPtr(false),
+		},
 	}
-	return false
-}
 
-func hasTLSVolMount(sts *apps.StatefulSet) bool {
-	for _, container := range sts.Spec.Template.Spec.Containers {
-		if container.Name == v1alpha1.PDMemberType.String() {
-			for _, vm := range container.VolumeMounts {
-				if vm.Name == "tidb-client-tls" {
-					return true
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PreferIPv6 = tt.clusterIPv6
+			tc.Spec.PD.PreferIPv6 = tt.pdIPv6
+			tc.Spec.PD.EnableLeaderService = pointer.BoolPtr(true)
+			tc.Spec.PD.PprofPort = pointer.Int32Ptr(6060)
+			tc.Spec.PD.EnablePprofService = pointer.BoolPtr(true)
+			pmm, _, _ := newFakePDMemberManager()
+
+			for _, svc := range []*corev1.Service{
+				pmm.getNewPDServiceForTidbCluster(tc),
+				getNewPDHeadlessServiceForTidbCluster(tc),
+				getNewPDLeaderServiceForTidbCluster(tc),
+				getNewPDPprofServiceForTidbCluster(tc),
+			} {
+				if tt.expected {
+					g.Expect(svc.Spec.IPFamilyPolicy).NotTo(BeNil(), svc.Name)
+					g.Expect(*svc.Spec.IPFamilyPolicy).To(Equal(corev1.IPFamilyPolicyPreferDualStack), svc.Name)
+				} else {
+					g.Expect(svc.Spec.IPFamilyPolicy).To(BeNil(), svc.Name)
 				}
 			}
+		})
+	}
+}
+
+func TestPDMemberManagerCheckPDMaxReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newStore := func(id uint64, state string, labels map[string]string) *pdapi.StoreInfo {
+		store := &metapb.Store{Id: id}
+		for k, v := range labels {
+			store.Labels = append(store.Labels, &metapb.StoreLabel{Key: k, Value: v})
 		}
+		return &pdapi.StoreInfo{Store: &pdapi.MetaStore{Store: store, StateName: state}}
 	}
-	return false
+	zoneStores := []*pdapi.StoreInfo{
+		newStore(1, v1alpha1.TiKVStateUp, map[string]string{"zone": "z1", "host": "h1"}),
+		newStore(2, v1alpha1.TiKVStateUp, map[string]string{"zone": "z1", "host": "h2"}),
+		newStore(3, v1alpha1.TiKVStateUp, map[string]string{"zone": "z2", "host": "h3"}),
+		newStore(4, v1alpha1.TiKVStateOffline, map[string]string{"zone": "z3", "host": "h4"}),
+		newStore(5, v1alpha1.TiKVStateUp, map[string]string{"zone": "z3", "host": "h5", "engine": "tiflash"}),
+	}
+
+	tests := []struct {
+		name           string
+		maxReplicas    *uint64
+		isolationLevel *string
+		stores         []*pdapi.StoreInfo
+		expectEvent    string
+	}{
+		{
+			name:   "max-replicas is not reported",
+			stores: zoneStores,
+		},
+		{
+			name:        "stores are enough",
+			maxReplicas: pointer.Uint64Ptr(3),
+			stores:      zoneStores,
+		},
+		{
+			name:        "stores are not enough",
+			maxReplicas: pointer.Uint64Ptr(5),
+			stores:      zoneStores,
+			expectEvent: "max-replicas 5 of PD exceeds the 3 failure domains",
+		},
+		{
+			name:           "zones are enough",
+			maxReplicas:    pointer.Uint64Ptr(2),
+			isolationLevel: pointer.StringPtr("zone"),
+			stores:         zoneStores,
+		},
+		{
+			name:           "zones are not enough",
+			maxReplicas:    pointer.Uint64Ptr(3),
+			isolationLevel: pointer.StringPtr("zone"),
+			stores:         zoneStores,
+			expectEvent:    "max-replicas 3 of PD exceeds the 2 failure domains",
+		},
+		{
+			name:           "isolation level is not a location label",
+			maxReplicas:    pointer.Uint64Ptr(3),
+			isolationLevel: pointer.StringPtr("rack"),
+			stores:         zoneStores,
+		},
+		{
+			name:        "cluster is not bootstrapped",
+			maxReplicas: pointer.Uint64Ptr(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+			pmm, _, _ := newFakePDMemberManager()
+			pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{
+					MaxReplicas:    tt.maxReplicas,
+					LocationLabels: []string{"zone", "host"},
+					IsolationLevel: tt.isolationLevel,
+				}}, nil
+			})
+			pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.StoresInfo{Count: len(tt.stores), Stores: tt.stores}, nil
+			})
+
+			// the event is emitted only once as the condition doesn't change in the second check
+			g.Expect(pmm.checkPDMaxReplicas(tc)).To(Succeed())
+			g.Expect(pmm.checkPDMaxReplicas(tc)).To(Succeed())
+
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			condition := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.PDMaxReplicasExceeded)
+			if tt.expectEvent == "" {
+				g.Expect(events).To(BeEmpty())
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("PDMaxReplicasExceedFailureDomains"))
+				g.Expect(events[0]).To(ContainSubstring(tt.expectEvent))
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(condition.Message).To(ContainSubstring(tt.expectEvent))
+			}
+		})
+	}
+
+	t.Run("condition is removed when the stores are enough again", func(t *testing.T) {
+		tc := newTidbClusterForPD()
+		tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+		tc.Status.PD.SetCondition(metav1.Condition{
+			Type:   v1alpha1.PDMaxReplicasExceeded,
+			Status: metav1.ConditionTrue,
+			Reason: "ExceedFailureDomains",
+		})
+		pmm, _, _ := newFakePDMemberManager()
+		pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: pointer.Uint64Ptr(3)}}, nil
+		})
+		pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &pdapi.StoresInfo{Count: len(zoneStores), Stores: zoneStores}, nil
+		})
+
+		g.Expect(pmm.checkPDMaxReplicas(tc)).To(Succeed())
+		g.Expect(meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.PDMaxReplicasExceeded)).To(BeNil())
+		g.Expect(collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)).To(BeEmpty())
+	})
 }
 
-func mustPDConfig(x interface{}) *v1alpha1.PDConfigWraper {
-	data, err := toml.Marshal(x)
-	if err != nil {
-		panic(err)
+func TestPDMemberManagerSyncPDForceNewCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	requested := &v1alpha1.PDForceNewCluster{Member: "test-pd-2", RequestTime: metav1.Unix(1700000000, 0)}
+	lostQuorum := map[string]v1alpha1.PDMember{
+		"test-pd-0": {Name: "test-pd-0", Health: false},
+		"test-pd-1": {Name: "test-pd-1", Health: true},
+		"test-pd-2": {Name: "test-pd-2", Health: false},
+	}
+	recovered := map[string]v1alpha1.PDMember{
+		"test-pd-1": {Name: "test-pd-1", Health: true},
 	}
 
-	c := v1alpha1.NewPDConfig()
-	c.UnmarshalTOML(data)
+	tests := []struct {
+		name         string
+		annotation   string
+		synced       bool
+		members      map[string]v1alpha1.PDMember
+		status       *v1alpha1.PDForceNewCluster
+		expectMember string
+		expectEvent  string
+	}{
+		{
+			name:         "quorum lost",
+			annotation:   "test-pd-1",
+			members:      lostQuorum,
+			expectMember: "test-pd-1",
+			expectEvent:  "ForceNewCluster",
+		},
+		{
+			name:        "not a pd pod",
+			annotation:  "test-tikv-1",
+			members:     lostQuorum,
+			expectEvent: "FailedForceNewCluster",
+		},
+		{
+			name:        "pd pod out of replicas",
+			annotation:  "test-pd-3",
+			members:     lostQuorum,
+			expectEvent: "FailedForceNewCluster",
+		},
+		{
+			name:        "pd has quorum",
+			annotation:  "test-pd-1",
+			synced:      true,
+			members:     recovered,
+			expectEvent: "FailedForceNewCluster",
+		},
+		{
+			name:         "force new cluster in progress",
+			annotation:   "test-pd-1",
+			members:      lostQuorum,
+			status:       requested,
+			expectMember: "test-pd-2",
+			expectEvent:  "FailedForceNewCluster",
+		},
+		{
+			name:         "waiting for recovery",
+			members:      lostQuorum,
+			status:       requested,
+			expectMember: "test-pd-2",
+		},
+		{
+			name:        "recovered",
+			synced:      true,
+			members:     recovered,
+			status:      requested,
+			expectEvent: "ForceNewClusterCompleted",
+		},
+	}
 
-	return c
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			if tt.annotation != "" {
+				tc.Annotations = map[string]string{label.AnnPDForceNewCluster: tt.annotation}
+			}
+			tc.Status.PD.Synced = tt.synced
+			tc.Status.PD.Members = tt.members
+			tc.Status.PD.ForceNewCluster = tt.status.DeepCopy()
+			pmm, _, _ := newFakePDMemberManager()
+
+			g.Expect(pmm.syncPDForceNewCluster(tc)).To(Succeed())
+			// the annotation is consumed whether the request is accepted or not
+			g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnPDForceNewCluster))
+			if tt.expectMember == "" {
+				g.Expect(tc.Status.PD.ForceNewCluster).To(BeNil())
+			} else {
+				g.Expect(tc.Status.PD.ForceNewCluster).NotTo(BeNil())
+				g.Expect(tc.Status.PD.ForceNewCluster.Member).To(Equal(tt.expectMember))
+			}
+			events := collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.expectEvent == "" {
+				g.Expect(events).To(BeEmpty())
+			} else {
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring(" " + tt.expectEvent + " "))
+			}
+
+			// the request is applied only once
+			last := tc.Status.PD.ForceNewCluster.DeepCopy()
+			g.Expect(pmm.syncPDForceNewCluster(tc)).To(Succeed())
+			g.Expect(tc.Status.PD.ForceNewCluster).To(Equal(last))
+			g.Expect(collectEvents(pmm.deps.Recorder.(*record.FakeRecorder).Events)).To(BeEmpty())
+		})
+	}
+}
+
+func TestPDMemberManagerSyncPDForceNewClusterWithoutRollout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-0": {Name: "test-pd-0", Health: false},
+		"test-pd-1": {Name: "test-pd-1", Health: true},
+		"test-pd-2": {Name: "test-pd-2", Health: false},
+	}
+	pmm, podIndexer, _ := newFakePDMemberManager()
+	fakeCli := pmm.deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	for i := int32(0); i < 3; i++ {
+		g.Expect(podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: PdPodName(tc.Name, i), Namespace: tc.Namespace},
+		})).To(Succeed())
+	}
+
+	cm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tc.Annotations = map[string]string{label.AnnPDForceNewCluster: "test-pd-1"}
+	g.Expect(pmm.syncPDForceNewCluster(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.ForceNewCluster).NotTo(BeNil())
+
+	// the request is delivered to the designated member by the file named after the pod
+	key := client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDForceNewClusterConfigMapName(tc.Name)}
+	fncCm := &corev1.ConfigMap{}
+	g.Expect(fakeCli.Get(context.TODO(), key, fncCm)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(fncCm, tc)).To(BeTrue())
+	g.Expect(fncCm.Data).To(Equal(map[string]string{
+		"test-pd-1": strconv.FormatInt(tc.Status.PD.ForceNewCluster.RequestTime.Unix(), 10),
+	}))
+	g.Expect(set.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+		Name: pdForceNewClusterVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: fncCm.Name},
+				Optional:             pointer.BoolPtr(true),
+			},
+		},
+	}))
+	g.Expect(set.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name: pdForceNewClusterVolumeName, ReadOnly: true, MountPath: pdForceNewClusterPath,
+	}))
+
+	// only the designated member is restarted to pick up the request
+	_, exist, err := podIndexer.GetByKey(tc.Namespace + "/test-pd-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exist).To(BeFalse())
+	for _, name := range []string{"test-pd-0", "test-pd-2"} {
+		_, exist, err := podIndexer.GetByKey(tc.Namespace + "/" + name)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exist).To(BeTrue())
+	}
+
+	// the ConfigMap and the StatefulSet shared by the PD pods are unchanged, so no rollout is required
+	newCm, err := pmm.syncPDConfigMap(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newCm.Name).To(Equal(cm.Name))
+	g.Expect(newCm.Data).To(Equal(cm.Data))
+	newSet, err := getNewPDSetForTidbCluster(tc, newCm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.Template).To(Equal(set.Spec.Template))
+
+	// the request is deleted once PD recovers
+	cmIndexer := pmm.deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+	g.Expect(cmIndexer.Add(fncCm)).To(Succeed())
+	tc.Status.PD.Synced = true
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-1": {Name: "test-pd-1", Health: true},
+	}
+	g.Expect(pmm.syncPDForceNewCluster(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.ForceNewCluster).To(BeNil())
+	err = fakeCli.Get(context.TODO(), key, &corev1.ConfigMap{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestPDMemberManagerSyncPDRegionMerge(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	regionMerge := &v1alpha1.PDRegionMergeConfig{
+		EnableCrossTableMerge: pointer.BoolPtr(true),
+		MaxMergeRegionSize:    pointer.Int64Ptr(54),
+		MaxMergeRegionKeys:    pointer.Int64Ptr(540000),
+		HotReload:             pointer.BoolPtr(true),
+	}
+
+	tests := []struct {
+		name        string
+		regionMerge *v1alpha1.PDRegionMergeConfig
+		config      map[string]interface{}
+		paused      bool
+		current     *pdapi.PDScheduleConfig
+		updateErr   error
+		expectErr   bool
+		expectCalls []pdapi.PDScheduleConfig
+	}{
+		{
+			name: "region merge is not set",
+		},
+		{
+			name: "hot reload is disabled",
+			regionMerge: &v1alpha1.PDRegionMergeConfig{
+				MaxMergeRegionSize: pointer.Int64Ptr(54),
+			},
+			current: &pdapi.PDScheduleConfig{MaxMergeRegionSize: pointer.Uint64Ptr(20)},
+		},
+		{
+			name:        "cluster is paused",
+			regionMerge: regionMerge,
+			paused:      true,
+			current:     &pdapi.PDScheduleConfig{MaxMergeRegionSize: pointer.Uint64Ptr(20)},
+		},
+		{
+			name:        "config is applied",
+			regionMerge: regionMerge,
+			current: &pdapi.PDScheduleConfig{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionSize:    pointer.Uint64Ptr(54),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(540000),
+			},
+		},
+		{
+			name:        "config is hot reloaded",
+			regionMerge: regionMerge,
+			current: &pdapi.PDScheduleConfig{
+				EnableCrossTableMerge: pointer.BoolPtr(false),
+				MaxMergeRegionSize:    pointer.Uint64Ptr(20),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(200000),
+			},
+			expectCalls: []pdapi.PDScheduleConfig{{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionSize:    pointer.Uint64Ptr(54),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(540000),
+			}},
+		},
+		{
+			name:        "explicit pd config is not overridden",
+			regionMerge: regionMerge,
+			config: map[string]interface{}{
+				"schedule.max-merge-region-size": int64(20),
+			},
+			current: &pdapi.PDScheduleConfig{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionSize:    pointer.Uint64Ptr(20),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(200000),
+			},
+			expectCalls: []pdapi.PDScheduleConfig{{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(540000),
+			}},
+		},
+		{
+			name:        "failed to hot reload config",
+			regionMerge: regionMerge,
+			current:     &pdapi.PDScheduleConfig{},
+			updateErr:   fmt.Errorf("failed to update schedule config"),
+			expectErr:   true,
+			expectCalls: []pdapi.PDScheduleConfig{{
+				EnableCrossTableMerge: pointer.BoolPtr(true),
+				MaxMergeRegionSize:    pointer.Uint64Ptr(54),
+				MaxMergeRegionKeys:    pointer.Uint64Ptr(540000),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.RegionMerge = tt.regionMerge
+			if tt.config != nil {
+				tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+				for k, v := range tt.config {
+					tc.Spec.PD.Config.Set(k, v)
+				}
+			}
+			tc.Spec.Paused = tt.paused
+			pmm, _, _ := newFakePDMemberManager()
+			fakePDControl := pmm.deps.PDControl.(*pdapi.FakePDControl)
+			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.PDConfigFromAPI{Schedule: tt.current}, nil
+			})
+			var calls []pdapi.PDScheduleConfig
+			pdClient.AddReaction(pdapi.UpdateScheduleActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls = append(calls, action.Schedule)
+				return nil, tt.updateErr
+			})
+
+			err := pmm.syncPDRegionMerge(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(calls).To(Equal(tt.expectCalls))
+		})
+	}
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
@@ -27,6 +28,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
@@ -1760,3 +1762,83 @@ func TestGetNewPdMSServiceForTidbCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNewPDMSSetWithResourcesPerMicroService(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPDMS()
+	tc.Spec.PDMS[0].ResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:     resource.MustParse("1"),
+			corev1.ResourceMemory:  resource.MustParse("1Gi"),
+			corev1.ResourceStorage: resource.MustParse("1Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+	tc.Spec.PDMS = append(tc.Spec.PDMS, &v1alpha1.PDMSSpec{
+		Name: "scheduling",
+		ComponentSpec: v1alpha1.ComponentSpec{
+			Image: "pingcap/pd:v7.3.0",
+			Annotations: map[string]string{
+				label.AnnSysctlInit: label.AnnSysctlInitVal,
+			},
+			PodSecurityContext: &corev1.PodSecurityContext{
+				Sysctls: []corev1.Sysctl{{Name: "net.core.somaxconn", Value: "32768"}},
+			},
+		},
+		Replicas: 2,
+		ResourceRequirements: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	})
+
+	expected := map[string]corev1.ResourceRequirements{
+		tsoService: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
+		"scheduling": {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+
+	pmm, _, _ := newFakePDMSMemberManager()
+	for _, curSpec := range tc.Spec.PDMS {
+		sts, err := pmm.getNewPDMSStatefulSet(tc, nil, curSpec)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(sts.Name).To(Equal(controller.PDMSMemberName(tc.Name, curSpec.Name)))
+
+		podSpec := sts.Spec.Template.Spec
+		containerName := v1alpha1.PDMSMemberType(curSpec.Name).String()
+		var found bool
+		for _, c := range podSpec.Containers {
+			if c.Name == containerName {
+				found = true
+				g.Expect(c.Resources).To(Equal(expected[curSpec.Name]), "component %s", curSpec.Name)
+			}
+		}
+		g.Expect(found).To(BeTrue(), "container of component %s", curSpec.Name)
+		// the init container reserves the same resources as the component
+		for _, c := range podSpec.InitContainers {
+			g.Expect(c.Resources).To(Equal(expected[curSpec.Name]), "init container of component %s", curSpec.Name)
+		}
+		if curSpec.Name == "scheduling" {
+			g.Expect(podSpec.InitContainers).To(HaveLen(1))
+		}
+	}
+}
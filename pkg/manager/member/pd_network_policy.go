@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// syncPDNetworkPolicy creates or updates the NetworkPolicy of PD if it's enabled,
+// and deletes the NetworkPolicy created by the operator if it's disabled.
+func (m *pdMemberManager) syncPDNetworkPolicy(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	name := controller.PDMemberName(tc.GetName())
+
+	if !tc.PDNetworkPolicyEnabled() {
+		existing, err := m.deps.NetworkPolicyLister.NetworkPolicies(ns).Get(name)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("syncPDNetworkPolicy: failed to get networkpolicy %s for cluster %s/%s, error: %s", name, ns, tc.GetName(), err)
+		}
+		// never delete the networkpolicy not created by the operator
+		if !metav1.IsControlledBy(existing, tc) {
+			return nil
+		}
+		return m.deps.TypedControl.Delete(tc, existing)
+	}
+
+	_, err := m.deps.TypedControl.CreateOrUpdateNetworkPolicy(tc, getNewPDNetworkPolicy(tc))
+	return err
+}
+
+// getNewPDNetworkPolicy returns the NetworkPolicy which only allows the members of the cluster and the
+// peers in `spec.pd.networkPolicy.from` to reach PD. The discovery service is allowed too because it
+// queries the members of PD when a PD member starts.
+func getNewPDNetworkPolicy(tc *v1alpha1.TidbCluster) *networkingv1.NetworkPolicy {
+	instanceName := tc.GetInstanceName()
+	pdLabels := label.New().Instance(instanceName).PD()
+
+	var from []networkingv1.NetworkPolicyPeer
+	for _, l := range []label.Label{
+		label.New().Instance(instanceName).PD(),
+		label.New().Instance(instanceName).TiKV(),
+		label.New().Instance(instanceName).TiDB(),
+		label.New().Instance(instanceName).TiFlash(),
+		label.New().Instance(instanceName).TiCDC(),
+		label.New().Instance(instanceName).TiProxy(),
+		label.New().Instance(instanceName).Pump(),
+		label.New().Instance(instanceName).Discovery(),
+	} {
+		from = append(from, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: l.Labels()},
+		})
+	}
+	for _, peer := range tc.Spec.PD.NetworkPolicy.From {
+		from = append(from, *peer.DeepCopy())
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDMemberName(tc.GetName()),
+			Namespace:       tc.GetNamespace(),
+			Labels:          pdLabels.Copy().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: pdLabels.Labels()},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					// all the ports of PD are allowed, the peers are restricted only
+					From: from,
+				},
+			},
+		},
+	}
+}
@@ -15,6 +15,7 @@ package member
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	apps "k8s.io/api/apps/v1"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/util"
 )
 
@@ -69,6 +71,16 @@ func (s *pdScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return fmt.Errorf("TidbCluster: %s/%s's pd status sync failed, can't scale out now", ns, tcName)
 	}
 
+	if tc.PDSafeScaleOutEnabled() {
+		if err := s.preCheckHealthyMembers(tc, PdPodName(tcName, ordinal)); err != nil {
+			return err
+		}
+	}
+
+	if err := s.preCheckStorageUtilization(tc, PdPodName(tcName, ordinal)); err != nil {
+		return err
+	}
+
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -99,6 +111,10 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return nil
 	}
 
+	if err := s.preCheckQuorum(tc, memberName, pdPodName); err != nil {
+		return err
+	}
+
 	pdClient := controller.GetPDClient(s.deps.PDControl, tc)
 	leader, err := pdClient.GetPDLeader()
 	if err != nil {
@@ -109,8 +125,8 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	// we would directly delete the member without the leader transferring
 	if leader.Name == memberName || leader.Name == pdPodName {
 		if *newSet.Spec.Replicas > 1 {
-			minOrdinal := helper.GetMinPodOrdinal(*newSet.Spec.Replicas, newSet)
-			targetOrdinal := helper.GetMaxPodOrdinal(*newSet.Spec.Replicas, newSet)
+			minOrdinal := helper.GetMinPodOrdinal(*newSet.Spec.Replicas, newSet) + getStsOrdinalsStart(newSet)
+			targetOrdinal := helper.GetMaxPodOrdinal(*newSet.Spec.Replicas, newSet) + getStsOrdinalsStart(newSet)
 			if ordinal > minOrdinal {
 				targetOrdinal = minOrdinal
 			}
@@ -136,7 +152,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		}
 	}
 
-	err = pdClient.DeleteMember(memberName)
+	err = deletePDMember(tc, pdClient, memberName)
 	if err != nil {
 		klog.Errorf("pdScaler.ScaleIn: failed to delete member %s, %v", memberName, err)
 		return err
@@ -163,6 +179,107 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	return nil
 }
 
+// deletePDMember deletes the PD member. A transient error of PD is returned as a RequeueError, so that
+// the deletion is retried in a later sync with the backoff of the work queue rather than failing the
+// scaling in. The attempts and the last error are recorded in `.status.pd.memberRemoval` until the
+// member is deleted.
+func deletePDMember(tc *v1alpha1.TidbCluster, pdClient pdapi.PDClient, memberName string) error {
+	removal := tc.Status.PD.MemberRemoval
+	if removal == nil || removal.Name != memberName {
+		removal = &v1alpha1.PDMemberRemoval{Name: memberName}
+		tc.Status.PD.MemberRemoval = removal
+	}
+	removal.Attempts++
+	if err := pdClient.DeleteMember(memberName); err != nil {
+		removal.LastError = err.Error()
+		if pdapi.IsClientError(err) {
+			return err
+		}
+		return controller.RequeueErrorf("tc[%s/%s]'s attempt %d to delete pd member %s failed, retry later: %v",
+			tc.GetNamespace(), tc.GetName(), removal.Attempts, memberName, err)
+	}
+	tc.Status.PD.MemberRemoval = nil
+	return nil
+}
+
+// preCheckQuorum refuses to remove the member if the remaining members would lose the quorum,
+// i.e. fewer than a majority of them are healthy. The health of members is read from the status.
+// Removing the last member is not blocked here, which is guarded by preCheckUpMembers.
+func (s *pdScaler) preCheckQuorum(tc *v1alpha1.TidbCluster, memberName, podName string) error {
+	remaining, healthy := 0, 0
+	count := func(members map[string]v1alpha1.PDMember) {
+		for name, member := range members {
+			if name == memberName || name == podName {
+				continue
+			}
+			remaining++
+			if member.Health {
+				healthy++
+			}
+		}
+	}
+	count(tc.Status.PD.Members)
+	count(tc.Status.PD.PeerMembers)
+	if remaining == 0 || healthy >= remaining/2+1 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("removing pd member %s would leave %d healthy member(s) out of %d, fewer than the quorum %d", memberName, healthy, remaining, remaining/2+1)
+	s.deps.Recorder.Event(tc, v1.EventTypeWarning, "FailedScaleIn", msg)
+	return controller.RequeueErrorf("TidbCluster: %s/%s's %s, can't scale in now", tc.GetNamespace(), tc.GetName(), msg)
+}
+
+// preCheckHealthyMembers defers adding the new member until all existing members of the cluster are healthy.
+func (s *pdScaler) preCheckHealthyMembers(tc *v1alpha1.TidbCluster, podName string) error {
+	var unhealthy []string
+	for name, member := range tc.Status.PD.Members {
+		if !member.Health {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	if len(unhealthy) == 0 {
+		return nil
+	}
+
+	sort.Strings(unhealthy)
+	msg := fmt.Sprintf("adding pd member %s is deferred until the unhealthy member(s) %v are healthy", podName, unhealthy)
+	s.deps.Recorder.Event(tc, v1.EventTypeWarning, "FailedScaleOut", msg)
+	return controller.RequeueErrorf("TidbCluster: %s/%s's %s, can't scale out now", tc.GetNamespace(), tc.GetName(), msg)
+}
+
+// preCheckStorageUtilization refuses adding the new member if the used capacity of all stores reported by PD
+// exceeds `.spec.pd.maxStorageUtilizationForScale`, the tombstone stores are not counted.
+func (s *pdScaler) preCheckStorageUtilization(tc *v1alpha1.TidbCluster, podName string) error {
+	threshold := tc.Spec.PD.MaxStorageUtilizationForScale
+	if threshold == nil {
+		return nil
+	}
+
+	storesInfo, err := controller.GetPDClient(s.deps.PDControl, tc).GetStores()
+	if err != nil {
+		return fmt.Errorf("TidbCluster: %s/%s's pd stores are unknown, can't check the storage utilization to scale out now, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+	var capacity, available uint64
+	for _, store := range storesInfo.Stores {
+		if store.Store == nil || store.Status == nil || store.Store.StateName == v1alpha1.TiKVStateTombstone {
+			continue
+		}
+		capacity += uint64(store.Status.Capacity)
+		available += uint64(store.Status.Available)
+	}
+	if capacity == 0 || available >= capacity {
+		return nil
+	}
+
+	utilization := float64(capacity-available) * 100 / float64(capacity)
+	if utilization <= float64(*threshold) {
+		return nil
+	}
+	msg := fmt.Sprintf("adding pd member %s is refused as the storage utilization %.1f%% of the stores exceeds %d%%", podName, utilization, *threshold)
+	s.deps.Recorder.Event(tc, v1.EventTypeWarning, "FailedScaleOut", msg)
+	return controller.RequeueErrorf("TidbCluster: %s/%s's %s, can't scale out now", tc.GetNamespace(), tc.GetName(), msg)
+}
+
 func (s *pdScaler) preCheckUpMembers(tc *v1alpha1.TidbCluster, podName string) bool {
 	upComponents := 0
 
@@ -19,16 +19,19 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/tikv/pd/pkg/typeutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 )
 
@@ -175,6 +178,40 @@ func TestPDScalerScaleOut(t *testing.T) {
 			err:              false,
 			changed:          true,
 		},
+		{
+			name: "safe scale out with all members healthy",
+			update: func(tc *v1alpha1.TidbCluster) {
+				normalPDMember(tc)
+				tc.Spec.PD.SafeScaleOut = pointer.BoolPtr(true)
+			},
+			pdUpgrading:      false,
+			hasPVC:           true,
+			hasDeferAnn:      false,
+			annoIsNil:        true,
+			pvcDeleteErr:     false,
+			statusSyncFailed: false,
+			err:              false,
+			changed:          true,
+		},
+		{
+			name: "safe scale out with an unhealthy member",
+			update: func(tc *v1alpha1.TidbCluster) {
+				normalPDMember(tc)
+				tc.Spec.PD.SafeScaleOut = pointer.BoolPtr(true)
+				podName := ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), 1)
+				pd := tc.Status.PD.Members[podName]
+				pd.Health = false
+				tc.Status.PD.Members[podName] = pd
+			},
+			pdUpgrading:      false,
+			hasPVC:           true,
+			hasDeferAnn:      false,
+			annoIsNil:        true,
+			pvcDeleteErr:     false,
+			statusSyncFailed: false,
+			err:              true,
+			changed:          false,
+		},
 		{
 			name:             "pd status sync failed",
 			update:           normalPDMember,
@@ -196,6 +233,103 @@ func TestPDScalerScaleOut(t *testing.T) {
 	}
 }
 
+func TestPDScalerScaleOutDeferredByUnhealthyMember(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	normalPDMember(tc)
+	tc.Status.PD.Synced = true
+	tc.Spec.PD.SafeScaleOut = pointer.BoolPtr(true)
+	podName := ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), 2)
+	pd := tc.Status.PD.Members[podName]
+	pd.Health = false
+	tc.Status.PD.Members[podName] = pd
+
+	oldSet := newStatefulSetForPDScale()
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+
+	scaler, _, _, _, _ := newFakePDScaler()
+	err := scaler.ScaleOut(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+	events := collectEvents(scaler.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring("FailedScaleOut"))
+	g.Expect(events[0]).To(ContainSubstring(podName))
+
+	// the scale out continues once the member is healthy again
+	pd.Health = true
+	tc.Status.PD.Members[podName] = pd
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+	g.Expect(scaler.ScaleOut(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(6))
+}
+
+func TestPDScalerScaleOutRefusedByStorageUtilization(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	normalPDMember(tc)
+	tc.Status.PD.Synced = true
+	tc.Spec.PD.MaxStorageUtilizationForScale = pointer.Int32Ptr(80)
+	podName := ordinalPodName(v1alpha1.PDMemberType, tc.GetName(), 5)
+
+	oldSet := newStatefulSetForPDScale()
+	scaler, pdControl, _, _, _ := newFakePDScaler()
+
+	var available typeutil.ByteSize
+	var storesErr error
+	pdClient := controller.NewFakePDClient(pdControl, tc)
+	pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		store := func(id uint64, state string, capacity, available typeutil.ByteSize) *pdapi.StoreInfo {
+			return &pdapi.StoreInfo{
+				Store:  &pdapi.MetaStore{Store: &metapb.Store{Id: id}, StateName: state},
+				Status: &pdapi.StoreStatus{Capacity: capacity, Available: available},
+			}
+		}
+		return &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{
+			store(1, v1alpha1.TiKVStateUp, 100<<30, available),
+			store(2, v1alpha1.TiKVStateUp, 100<<30, available),
+			// the tombstone store is not counted
+			store(3, v1alpha1.TiKVStateTombstone, 100<<30, 0),
+		}}, storesErr
+	})
+
+	// 90% of the capacity is used
+	available = 10 << 30
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+	err := scaler.ScaleOut(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+	events := collectEvents(scaler.deps.Recorder.(*record.FakeRecorder).Events)
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(ContainSubstring("FailedScaleOut"))
+	g.Expect(events[0]).To(ContainSubstring(podName))
+	g.Expect(events[0]).To(ContainSubstring("90.0%"))
+
+	// the scale out is refused if the stores are unknown
+	storesErr = fmt.Errorf("pd is unavailable")
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+	g.Expect(scaler.ScaleOut(tc, oldSet, newSet)).NotTo(Succeed())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+	storesErr = nil
+
+	// the scale out continues once the utilization drops below the threshold
+	available = 30 << 30
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+	g.Expect(scaler.ScaleOut(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(6))
+
+	// no stores are queried if the threshold is not set
+	tc.Spec.PD.MaxStorageUtilizationForScale = nil
+	available = 0
+	newSet.Spec.Replicas = pointer.Int32Ptr(6)
+	g.Expect(scaler.ScaleOut(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(int(*newSet.Spec.Replicas)).To(Equal(6))
+}
+
 func TestPDScalerScaleIn(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
@@ -381,6 +515,218 @@ func TestPDScalerScaleIn(t *testing.T) {
 	}
 }
 
+func TestPDScalerScaleInBlockByQuorum(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		healthy     []int32
+		unhealthy   []int32
+		peerHealthy bool
+		blocked     bool
+	}{
+		{
+			name:      "the only healthy member is the scale-in target",
+			healthy:   []int32{4},
+			unhealthy: []int32{0, 1, 2, 3},
+			blocked:   true,
+		},
+		{
+			name:      "only one healthy member is left",
+			healthy:   []int32{0, 4},
+			unhealthy: []int32{1, 2, 3},
+			blocked:   true,
+		},
+		{
+			name:      "remaining healthy members keep the quorum",
+			healthy:   []int32{0, 1, 2},
+			unhealthy: []int32{3, 4},
+			blocked:   false,
+		},
+		{
+			name:        "healthy peer members count for the quorum",
+			healthy:     []int32{0, 1, 4},
+			unhealthy:   []int32{2, 3},
+			peerHealthy: true,
+			blocked:     false,
+		},
+		{
+			name:      "the last member is removed",
+			healthy:   []int32{4},
+			unhealthy: []int32{},
+			blocked:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Status.PD.Synced = true
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+			for _, ordinal := range tt.healthy {
+				name := PdPodName(tc.GetName(), ordinal)
+				tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: true}
+			}
+			for _, ordinal := range tt.unhealthy {
+				name := PdPodName(tc.GetName(), ordinal)
+				tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: false}
+			}
+			if tt.peerHealthy {
+				tc.Status.PD.PeerMembers = map[string]v1alpha1.PDMember{
+					"peer-pd-0": {Name: "peer-pd-0", Health: true},
+				}
+			}
+
+			oldSet := newStatefulSetForPDScale()
+			newSet := oldSet.DeepCopy()
+			newSet.Spec.Replicas = pointer.Int32Ptr(3)
+
+			scaler, pdControl, pvcIndexer, podIndexer, _ := newFakePDScaler()
+			pvc := newScaleInPVCForStatefulSet(oldSet, v1alpha1.PDMemberType, tc.Name)
+			pvcIndexer.Add(pvc)
+			podIndexer.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PdPodName(tc.GetName(), 4),
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+						},
+					}},
+				},
+			})
+			pdClient := controller.NewFakePDClient(pdControl, tc)
+			pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdpb.Member{Name: PdPodName(tc.GetName(), 0)}, nil
+			})
+			deleted := false
+			pdClient.AddReaction(pdapi.DeleteMemberActionType, func(action *pdapi.Action) (interface{}, error) {
+				deleted = true
+				return nil, nil
+			})
+
+			err := scaler.ScaleIn(tc, oldSet, newSet)
+			events := collectEvents(scaler.deps.Recorder.(*record.FakeRecorder).Events)
+			if tt.blocked {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+				g.Expect(deleted).To(BeFalse())
+				g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+				g.Expect(events).To(HaveLen(1))
+				g.Expect(events[0]).To(ContainSubstring("FailedScaleIn"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(deleted).To(BeTrue())
+				g.Expect(int(*newSet.Spec.Replicas)).To(Equal(4))
+				g.Expect(events).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestPDScalerScaleInRetryDeleteMember(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	memberName := PdName("test", 4, corev1.NamespaceDefault, "", false)
+	tests := []struct {
+		name           string
+		removal        *v1alpha1.PDMemberRemoval
+		deleteErr      error
+		expectErr      bool
+		expectRequeue  bool
+		expectAttempts int32
+	}{
+		{
+			name:           "transient error",
+			deleteErr:      pdapi.StatusErrorf(503, "failed 503 to delete member %s: service unavailable", memberName),
+			expectErr:      true,
+			expectRequeue:  true,
+			expectAttempts: 1,
+		},
+		{
+			name:           "transient error again",
+			removal:        &v1alpha1.PDMemberRemoval{Name: memberName, Attempts: 2, LastError: "timeout"},
+			deleteErr:      fmt.Errorf("Delete %q: context deadline exceeded", memberName),
+			expectErr:      true,
+			expectRequeue:  true,
+			expectAttempts: 3,
+		},
+		{
+			name:           "removal of another member is reset",
+			removal:        &v1alpha1.PDMemberRemoval{Name: "test-pd-3", Attempts: 2},
+			deleteErr:      pdapi.StatusErrorf(503, "failed 503 to delete member %s: service unavailable", memberName),
+			expectErr:      true,
+			expectRequeue:  true,
+			expectAttempts: 1,
+		},
+		{
+			name:           "error is not transient",
+			deleteErr:      pdapi.StatusErrorf(400, "failed 400 to delete member %s: bad request", memberName),
+			expectErr:      true,
+			expectAttempts: 1,
+		},
+		{
+			name:    "success after failed attempts",
+			removal: &v1alpha1.PDMemberRemoval{Name: memberName, Attempts: 2, LastError: "timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Status.PD.Synced = true
+			tc.Status.PD.MemberRemoval = tt.removal
+			oldSet := newStatefulSetForPDScale()
+			newSet := oldSet.DeepCopy()
+			newSet.Spec.Replicas = pointer.Int32Ptr(3)
+
+			scaler, pdControl, pvcIndexer, podIndexer, _ := newFakePDScaler()
+			pvc := newScaleInPVCForStatefulSet(oldSet, v1alpha1.PDMemberType, tc.Name)
+			pvcIndexer.Add(pvc)
+			podIndexer.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PdPodName(tc.GetName(), 4),
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+						},
+					}},
+				},
+			})
+			pdClient := controller.NewFakePDClient(pdControl, tc)
+			pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdpb.Member{Name: PdPodName(tc.GetName(), 0)}, nil
+			})
+			calls := 0
+			pdClient.AddReaction(pdapi.DeleteMemberActionType, func(action *pdapi.Action) (interface{}, error) {
+				calls++
+				return nil, tt.deleteErr
+			})
+
+			err := scaler.ScaleIn(tc, oldSet, newSet)
+			g.Expect(calls).To(Equal(1))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(controller.IsRequeueError(err)).To(Equal(tt.expectRequeue))
+				g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+				removal := tc.Status.PD.MemberRemoval
+				g.Expect(removal).NotTo(BeNil())
+				g.Expect(removal.Name).To(Equal(memberName))
+				g.Expect(removal.Attempts).To(Equal(tt.expectAttempts))
+				g.Expect(removal.LastError).To(Equal(tt.deleteErr.Error()))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(int(*newSet.Spec.Replicas)).To(Equal(4))
+				g.Expect(tc.Status.PD.MemberRemoval).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestPDScalerScaleInBlockByOtherComponents(t *testing.T) {
 	// check if PD scale in is blocked when other components are using PD
 	g := NewGomegaWithT(t)
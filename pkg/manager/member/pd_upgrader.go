@@ -30,6 +30,9 @@ import (
 const (
 	// set this PD clustre annotation to true to fail cluster upgrade if PD loose the quorum during one pod restart
 	annoKeyPDPeersCheck = "tidb.pingcap.com/pd-check-quorum-before-upgrade"
+	// set this PD cluster annotation to true to advance the upgrade without waiting for the upgraded pods to be ready and healthy.
+	// It's unsafe and only meant for test environments, never set it in production.
+	annoKeyPDFastRollout = "pingcap.com/pd-fast-rollout"
 )
 
 type pdUpgrader struct {
@@ -56,7 +59,7 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 	if tc.PDScaling() {
 		klog.Infof("TidbCluster: [%s/%s]'s pd status is %v, can not upgrade pd",
 			ns, tcName, tc.Status.PD.Phase)
-		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		podSpec, err := getLastAppliedPodSpec(oldSet, u.deps.CLIConfig.PDServerSideApply)
 		if err != nil {
 			return err
 		}
@@ -65,7 +68,7 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 	}
 
 	tc.Status.PD.Phase = v1alpha1.UpgradePhase
-	if !templateEqual(newSet, oldSet) {
+	if !appliedTemplateEqual(newSet, oldSet, u.deps.CLIConfig.PDServerSideApply) {
 		return nil
 	}
 
@@ -79,8 +82,14 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		return nil
 	}
 
+	// fast rollout skips waiting for the upgraded pods to be ready and healthy, which is unsafe for production
+	fastRollout := tc.Annotations[annoKeyPDFastRollout] == "true"
+	if fastRollout {
+		klog.Warningf("tidbcluster: [%s/%s] pd fast rollout is enabled, the readiness of upgraded pd pods is not waited", ns, tcName)
+	}
+
 	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
-	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	podOrdinals := getStsPodOrdinals(oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
 		podName := PdPodName(tcName, i)
@@ -95,6 +104,9 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		}
 
 		if revision == tc.Status.PD.StatefulSet.UpdateRevision {
+			if fastRollout {
+				continue
+			}
 			if !k8s.IsPodReady(pod) {
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded pd pod: [%s] is not ready", ns, tcName, podName)
 			}
@@ -105,8 +117,10 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		}
 
 		// verify that no peers are unhealthy during restart
-		if unstableReason := u.isPDPeersStable(tc); unstableReason != "" {
-			return controller.RequeueErrorf("Peer PDs is unstable: %s", unstableReason)
+		if !fastRollout {
+			if unstableReason := u.isPDPeersStable(tc); unstableReason != "" {
+				return controller.RequeueErrorf("Peer PDs is unstable: %s", unstableReason)
+			}
 		}
 
 		return u.upgradePDPod(tc, i, newSet)
@@ -168,7 +182,7 @@ func (u *pdUpgrader) transferPDLeaderTo(tc *v1alpha1.TidbCluster, targetName str
 //  2. If no suitable ordinal, find the min suitable ordinal in [0, x) to reduce the count of transfer
 func choosePDToTransferFromMembers(tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet, ordinal int32) string {
 	tcName := tc.GetName()
-	ordinals := helper.GetPodOrdinals(*newSet.Spec.Replicas, newSet)
+	ordinals := getStsPodOrdinals(newSet)
 
 	genPDName := func(targetOrdinal int32) string {
 		pdName := PdName(tcName, targetOrdinal, tc.Namespace, tc.Spec.ClusterDomain, tc.Spec.AcrossK8s)
@@ -183,7 +197,7 @@ func choosePDToTransferFromMembers(tc *v1alpha1.TidbCluster, newSet *apps.Statef
 
 	// set ordinal to max ordinal if ordinal isn't exist
 	if !ordinals.Has(ordinal) {
-		ordinal = helper.GetMaxPodOrdinal(*newSet.Spec.Replicas, newSet)
+		ordinal = helper.GetMaxPodOrdinal(*newSet.Spec.Replicas, newSet) + getStsOrdinalsStart(newSet)
 	}
 
 	targetName := ""
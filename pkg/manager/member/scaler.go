@@ -187,7 +187,7 @@ func scaleOne(actual *apps.StatefulSet, desired *apps.StatefulSet) (scaling int,
 	if len(ordinals) == 0 {
 		ordinal = -1
 	} else {
-		ordinal = ordinals[0]
+		ordinal = ordinals[0] + getStsOrdinalsStart(actual)
 	}
 	return
 }
@@ -230,6 +230,37 @@ func TestScaleOne(t *testing.T) {
 				},
 			},
 		},
+		{
+			"scale out with ordinals start",
+			&apps.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: apps.StatefulSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+					Ordinals: &apps.StatefulSetOrdinals{Start: 3},
+				},
+			},
+			&apps.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: apps.StatefulSetSpec{
+					Replicas: pointer.Int32Ptr(3),
+					Ordinals: &apps.StatefulSetOrdinals{Start: 3},
+				},
+			},
+			[]scaleOp{
+				{
+					1,
+					4,
+					2,
+					sets.Int32{},
+				},
+				{
+					1,
+					5,
+					3,
+					sets.Int32{},
+				},
+			},
+		},
 		{
 			"scale in without delete slots (diff > 1)",
 			&apps.StatefulSet{
@@ -65,8 +65,21 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 			ClusterDomain: tc.Spec.ClusterDomain,
 		},
 		Scheme:         tc.Scheme(),
+		PeerScheme:     tc.PDPeerScheme(),
 		DataDir:        filepath.Join(constants.PDDataVolumeMountPath, tc.Spec.PD.DataSubDir),
+		ConfigFile:     "/etc/pd/pd.toml",
 		PDStartTimeout: tc.PDStartTimeout(),
+		ListenHost:     "0.0.0.0",
+	}
+	if tc.PDProjectedVolumeMountsEnabled() {
+		// the config file is in the directory named after the config volume in the projected volume
+		model.ConfigFile = filepath.Join(constants.PDProjectedVolumeMountPath, "config", "pd.toml")
+	}
+	if tc.PDPreferIPv6() {
+		model.ListenHost = "[::]"
+	}
+	if tc.Spec.PD.StartupStaggerSeconds != nil {
+		model.StartupStaggerSeconds = *tc.Spec.PD.StartupStaggerSeconds
 	}
 	if tc.Spec.PD.StartUpScriptVersion == "v1" {
 		model.CheckDomainScript = checkDNSV1
@@ -154,6 +154,12 @@ fi
 
 # Use HOSTNAME if POD_NAME is unset for backward compatibility.
 POD_NAME=${POD_NAME:-$HOSTNAME}
+{{- if .StartupStaggerSeconds }}
+# stagger the startup of the pods by their ordinals to avoid DNS and discovery storms on restarts
+startup_stagger=$(( ${POD_NAME##*-} % {{ .StartupStaggerSeconds }} ))
+echo "delay the startup for ${startup_stagger}s"
+sleep ${startup_stagger}
+{{- end }}
 # the general form of variable PEER_SERVICE_NAME is: "<clusterName>-pd-peer"
 cluster_name=` + "`" + `echo ${PEER_SERVICE_NAME} | sed 's/-pd-peer//'` + "`" +
 	`
@@ -185,11 +191,11 @@ done
 
 ARGS="--data-dir={{ .DataDir }} \
 --name={{- if or .AcrossK8s .ClusterDomain }}${domain}{{- else }}${POD_NAME}{{- end }} \
---peer-urls={{ .Scheme }}://0.0.0.0:2380 \
---advertise-peer-urls={{ .Scheme }}://${domain}:2380 \
---client-urls={{ .Scheme }}://0.0.0.0:2379 \
+--peer-urls={{ .PeerScheme }}://{{ .ListenHost }}:2380 \
+--advertise-peer-urls={{ .PeerScheme }}://${domain}:2380 \
+--client-urls={{ .Scheme }}://{{ .ListenHost }}:2379 \
 --advertise-client-urls={{ .Scheme }}://${domain}:2379 \
---config=/etc/pd/pd.toml \
+--config={{ .ConfigFile }} \
 "
 
 if [[ -f {{ .DataDir }}/join ]]
@@ -210,6 +216,18 @@ done
 ARGS="${ARGS}${result}"
 fi
 
+# the operator writes the force new cluster request of this member to the file named after the pod
+if [[ -f /etc/pd-force-new-cluster/${POD_NAME} ]]
+then
+# the marker file makes sure that --force-new-cluster is applied at most once for each request
+marker={{ .DataDir }}/force-new-cluster-$(cat /etc/pd-force-new-cluster/${POD_NAME})
+if [[ ! -f ${marker} ]]
+then
+touch ${marker}
+ARGS="${ARGS} --force-new-cluster"
+fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -249,9 +267,14 @@ type PDStartScriptModel struct {
 	CommonModel
 
 	Scheme            string
+	PeerScheme        string
 	DataDir           string
+	ConfigFile        string
 	CheckDomainScript string
 	PDStartTimeout    int
+	ListenHost        string
+
+	StartupStaggerSeconds int32
 }
 
 var tikvStartScriptTplText = `#!/bin/sh
@@ -31,6 +31,7 @@ type PDStartScriptModel struct {
 	PDDomain           string
 	PDName             string
 	DataDir            string
+	ConfigFile         string
 	PeerURL            string
 	AdvertisePeerURL   string
 	ClientURL          string
@@ -40,6 +41,8 @@ type PDStartScriptModel struct {
 	PDAddresses        string
 	PDStartTimeout     int
 	PDInitWaitTime     int
+
+	StartupStaggerSeconds int32
 }
 
 // PDMSStartScriptModel contain fields for rendering PD Micro Service start script
@@ -76,17 +79,27 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	preferPDAddressesOverDiscovery := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagPreferPDAddressesOverDiscovery)
 	if preferPDAddressesOverDiscovery {
-		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, tc.Scheme()+"://", v1alpha1.DefaultPDPeerPort)
+		pdAddressesWithSchemeAndPort := addressesWithSchemeAndPort(tc.Spec.PDAddresses, tc.PDPeerScheme()+"://", v1alpha1.DefaultPDPeerPort)
 		m.PDAddresses = strings.Join(pdAddressesWithSchemeAndPort, ",")
 	}
 
 	m.DataDir = filepath.Join(constants.PDDataVolumeMountPath, tc.Spec.PD.DataSubDir)
 
-	m.PeerURL = fmt.Sprintf("%s://0.0.0.0:%d", tc.Scheme(), v1alpha1.DefaultPDPeerPort)
+	m.ConfigFile = "/etc/pd/pd.toml"
+	if tc.PDProjectedVolumeMountsEnabled() {
+		// the config file is in the directory named after the config volume in the projected volume
+		m.ConfigFile = filepath.Join(constants.PDProjectedVolumeMountPath, "config", "pd.toml")
+	}
 
-	m.AdvertisePeerURL = fmt.Sprintf("%s://${PD_DOMAIN}:%d", tc.Scheme(), v1alpha1.DefaultPDPeerPort)
+	listenHost := "0.0.0.0"
+	if tc.PDPreferIPv6() {
+		listenHost = "[::]"
+	}
+	m.PeerURL = fmt.Sprintf("%s://%s:%d", tc.PDPeerScheme(), listenHost, v1alpha1.DefaultPDPeerPort)
 
-	m.ClientURL = fmt.Sprintf("%s://0.0.0.0:%d", tc.Scheme(), v1alpha1.DefaultPDClientPort)
+	m.AdvertisePeerURL = fmt.Sprintf("%s://${PD_DOMAIN}:%d", tc.PDPeerScheme(), v1alpha1.DefaultPDPeerPort)
+
+	m.ClientURL = fmt.Sprintf("%s://%s:%d", tc.Scheme(), listenHost, v1alpha1.DefaultPDClientPort)
 
 	m.AdvertiseClientURL = fmt.Sprintf("%s://${PD_DOMAIN}:%d", tc.Scheme(), v1alpha1.DefaultPDClientPort)
 
@@ -96,6 +109,10 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 
 	m.PDInitWaitTime = tc.PDInitWaitTime()
 
+	if tc.Spec.PD.StartupStaggerSeconds != nil {
+		m.StartupStaggerSeconds = *tc.Spec.PD.StartupStaggerSeconds
+	}
+
 	waitForDnsNameIpMatchOnStartup := slices.Contains(
 		tc.Spec.StartScriptV2FeatureFlags, v1alpha1.StartScriptV2FeatureFlagWaitForDnsNameIpMatch)
 
@@ -239,6 +256,12 @@ done
 	// pdStartScript is the template of start script.
 	pdStartScript = `
 PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+{{- if .StartupStaggerSeconds }}
+# stagger the startup of the pods by their ordinals to avoid DNS and discovery storms on restarts
+startup_stagger=$(( ${PD_POD_NAME##*-} % {{ .StartupStaggerSeconds }} ))
+echo "delay the startup for ${startup_stagger}s"
+sleep ${startup_stagger}
+{{- end }}
 PD_DOMAIN={{ .PDDomain }}` +
 		dnsAwaitPart + `
 ARGS="` + pdEnableMicroService + `--data-dir={{ .DataDir }} \
@@ -247,10 +270,20 @@ ARGS="` + pdEnableMicroService + `--data-dir={{ .DataDir }} \
 --advertise-peer-urls={{ .AdvertisePeerURL }} \
 --client-urls={{ .ClientURL }} \
 --advertise-client-urls={{ .AdvertiseClientURL }} \
---config=/etc/pd/pd.toml"
+--config={{ .ConfigFile }}"
 {{- if .ExtraArgs }}
 ARGS="${ARGS} {{ .ExtraArgs }}"
 {{- end }}
+
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker={{ .DataDir }}/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
 {{ if .PDAddresses }}
 ARGS="${ARGS} --join={{ .PDAddresses }}"
 {{- else }}
@@ -20,6 +20,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -96,6 +97,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -180,6 +191,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -267,6 +288,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 ARGS="${ARGS} --join=http://${PD_DOMAIN}:2380,http://another.pd:2380"
 
 echo "starting pd-server ..."
@@ -339,6 +370,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=https://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -423,6 +464,16 @@ ARGS="--data-dir=/var/lib/pd/pd-data \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/pd-data/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/pd-data/join ]]; then
     join=$(cat /var/lib/pd/pd-data/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -507,6 +558,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -592,6 +653,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -677,6 +748,302 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
+if [[ -f /var/lib/pd/join ]]; then
+    join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
+    join=${join%,}
+    ARGS="${ARGS} --join=${join}"
+elif [[ ! -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    until result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/new/${encoded_domain_url} 2>/dev/null); do
+        echo "waiting for discovery service to return start args ..."
+        sleep $((RANDOM % 5))
+    done
+    ARGS="${ARGS} ${result}"
+fi
+
+echo "starting pd-server ..."
+sleep $((RANDOM % 10))
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`,
+		},
+		{
+			name: "pd prefer ipv6",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.PreferIPv6 = pointer.BoolPtr(true)
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+PD_DOMAIN=${PD_POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc
+
+elapseTime=0
+period=1
+threshold=30
+while true; do
+    sleep ${period}
+    elapseTime=$(( elapseTime+period ))
+
+    if [[ ${elapseTime} -ge ${threshold} ]]; then
+        echo "waiting for pd cluster ready timeout" >&2
+        exit 1
+    fi
+
+    digRes=$(dig ${PD_DOMAIN} A ${PD_DOMAIN} AAAA +search +short)
+    if [ $? -ne 0  ]; then
+        echo "domain resolve ${PD_DOMAIN} failed"
+        echo "$digRes"
+        continue
+    fi
+
+    if [ -z "${digRes}" ]
+    then
+        echo "domain resolve ${PD_DOMAIN} no record return"
+    else
+        echo "domain resolve ${PD_DOMAIN} success"
+        echo "$digRes"
+        break
+    fi
+done
+
+ARGS="--data-dir=/var/lib/pd \
+--name=${PD_POD_NAME} \
+--peer-urls=http://[::]:2380 \
+--advertise-peer-urls=http://${PD_DOMAIN}:2380 \
+--client-urls=http://[::]:2379 \
+--advertise-client-urls=http://${PD_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
+if [[ -f /var/lib/pd/join ]]; then
+    join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
+    join=${join%,}
+    ARGS="${ARGS} --join=${join}"
+elif [[ ! -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    until result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/new/${encoded_domain_url} 2>/dev/null); do
+        echo "waiting for discovery service to return start args ..."
+        sleep $((RANDOM % 5))
+    done
+    ARGS="${ARGS} ${result}"
+fi
+
+echo "starting pd-server ..."
+sleep $((RANDOM % 10))
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`,
+		},
+		{
+			name: "pd peer url scheme",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.PeerURLScheme = pointer.StringPtr("https")
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+PD_DOMAIN=${PD_POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc
+
+elapseTime=0
+period=1
+threshold=30
+while true; do
+    sleep ${period}
+    elapseTime=$(( elapseTime+period ))
+
+    if [[ ${elapseTime} -ge ${threshold} ]]; then
+        echo "waiting for pd cluster ready timeout" >&2
+        exit 1
+    fi
+
+    digRes=$(dig ${PD_DOMAIN} A ${PD_DOMAIN} AAAA +search +short)
+    if [ $? -ne 0  ]; then
+        echo "domain resolve ${PD_DOMAIN} failed"
+        echo "$digRes"
+        continue
+    fi
+
+    if [ -z "${digRes}" ]
+    then
+        echo "domain resolve ${PD_DOMAIN} no record return"
+    else
+        echo "domain resolve ${PD_DOMAIN} success"
+        echo "$digRes"
+        break
+    fi
+done
+
+ARGS="--data-dir=/var/lib/pd \
+--name=${PD_POD_NAME} \
+--peer-urls=https://0.0.0.0:2380 \
+--advertise-peer-urls=https://${PD_DOMAIN}:2380 \
+--client-urls=http://0.0.0.0:2379 \
+--advertise-client-urls=http://${PD_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
+if [[ -f /var/lib/pd/join ]]; then
+    join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
+    join=${join%,}
+    ARGS="${ARGS} --join=${join}"
+elif [[ ! -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    until result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/new/${encoded_domain_url} 2>/dev/null); do
+        echo "waiting for discovery service to return start args ..."
+        sleep $((RANDOM % 5))
+    done
+    ARGS="${ARGS} ${result}"
+fi
+
+echo "starting pd-server ..."
+sleep $((RANDOM % 10))
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`,
+		},
+		{
+			name: "pd startup stagger",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.StartupStaggerSeconds = pointer.Int32Ptr(10)
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+# stagger the startup of the pods by their ordinals to avoid DNS and discovery storms on restarts
+startup_stagger=$(( ${PD_POD_NAME##*-} % 10 ))
+echo "delay the startup for ${startup_stagger}s"
+sleep ${startup_stagger}
+PD_DOMAIN=${PD_POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc
+
+elapseTime=0
+period=1
+threshold=30
+while true; do
+    sleep ${period}
+    elapseTime=$(( elapseTime+period ))
+
+    if [[ ${elapseTime} -ge ${threshold} ]]; then
+        echo "waiting for pd cluster ready timeout" >&2
+        exit 1
+    fi
+
+    digRes=$(dig ${PD_DOMAIN} A ${PD_DOMAIN} AAAA +search +short)
+    if [ $? -ne 0  ]; then
+        echo "domain resolve ${PD_DOMAIN} failed"
+        echo "$digRes"
+        continue
+    fi
+
+    if [ -z "${digRes}" ]
+    then
+        echo "domain resolve ${PD_DOMAIN} no record return"
+    else
+        echo "domain resolve ${PD_DOMAIN} success"
+        echo "$digRes"
+        break
+    fi
+done
+
+ARGS="--data-dir=/var/lib/pd \
+--name=${PD_POD_NAME} \
+--peer-urls=http://0.0.0.0:2380 \
+--advertise-peer-urls=http://${PD_DOMAIN}:2380 \
+--client-urls=http://0.0.0.0:2379 \
+--advertise-client-urls=http://${PD_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -126,6 +126,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -242,6 +252,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=https://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -358,6 +378,16 @@ ARGS="--data-dir=/var/lib/pd/pd-data \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/pd-data/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/pd-data/join ]]; then
     join=$(cat /var/lib/pd/pd-data/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -474,6 +504,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -591,6 +631,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -708,6 +758,16 @@ ARGS="--data-dir=/var/lib/pd \
 --advertise-client-urls=http://${PD_DOMAIN}:2379 \
 --config=/etc/pd/pd.toml"
 
+# the operator writes the force new cluster request of this member to the file named after the pod,
+# and the marker file makes sure that --force-new-cluster is applied at most once for each request
+if [[ -f /etc/pd-force-new-cluster/${PD_POD_NAME} ]]; then
+    marker=/var/lib/pd/force-new-cluster-$(cat /etc/pd-force-new-cluster/${PD_POD_NAME})
+    if [[ ! -f ${marker} ]]; then
+        touch ${marker}
+        ARGS="${ARGS} --force-new-cluster"
+    fi
+fi
+
 if [[ -f /var/lib/pd/join ]]; then
     join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
     join=${join%,}
@@ -2646,6 +2646,83 @@ func TestGetTiKVConfigMap(t *testing.T) {
 	}
 }
 
+func TestGetTiKVConfigMapWithPDHeartbeatIntervals(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name                   string
+		config                 map[string]interface{}
+		heartbeatInterval      *string
+		storeHeartbeatInterval *string
+		expectHeartbeat        interface{}
+		expectStoreHeartbeat   interface{}
+	}{
+		{
+			name: "heartbeat intervals are not set",
+		},
+		{
+			name:                   "heartbeat intervals are mapped into config",
+			heartbeatInterval:      pointer.StringPtr("2m"),
+			storeHeartbeatInterval: pointer.StringPtr("20s"),
+			expectHeartbeat:        "2m",
+			expectStoreHeartbeat:   "20s",
+		},
+		{
+			name: "explicit tikv config wins",
+			config: map[string]interface{}{
+				"raftstore.pd-heartbeat-tick-interval":       "1m",
+				"raftstore.pd-store-heartbeat-tick-interval": "10s",
+			},
+			heartbeatInterval:      pointer.StringPtr("2m"),
+			storeHeartbeatInterval: pointer.StringPtr("20s"),
+			expectHeartbeat:        "1m",
+			expectStoreHeartbeat:   "10s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiKV: &v1alpha1.TiKVSpec{
+						Config:                   v1alpha1.NewTiKVConfig(),
+						PDHeartbeatInterval:      tt.heartbeatInterval,
+						PDStoreHeartbeatInterval: tt.storeHeartbeatInterval,
+					},
+					PD:   &v1alpha1.PDSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			}
+			for k, v := range tt.config {
+				tc.Spec.TiKV.Config.Set(k, v)
+			}
+
+			cm, err := getTikVConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			config := v1alpha1.NewTiKVConfig()
+			g.Expect(config.UnmarshalTOML([]byte(cm.Data["config-file"]))).To(Succeed())
+			for key, expected := range map[string]interface{}{
+				"raftstore.pd-heartbeat-tick-interval":       tt.expectHeartbeat,
+				"raftstore.pd-store-heartbeat-tick-interval": tt.expectStoreHeartbeat,
+			} {
+				if expected == nil {
+					g.Expect(config.Get(key)).To(BeNil(), key)
+				} else {
+					g.Expect(config.Get(key).Interface()).To(Equal(expected), key)
+				}
+			}
+			// the config in the spec is not modified
+			if tt.config == nil {
+				g.Expect(tc.Spec.TiKV.Config.Get("raftstore.pd-heartbeat-tick-interval")).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestTransformTiKVConfigMap(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type testcase struct {
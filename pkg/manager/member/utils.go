@@ -107,6 +107,56 @@ func templateEqual(new *apps.StatefulSet, old *apps.StatefulSet) bool {
 	return false
 }
 
+// getLastAppliedPodSpec returns the last applied pod spec of the StatefulSet, which is read from the fields
+// applied by tidb-operator instead of the last applied annotation if it's committed with server-side apply.
+func getLastAppliedPodSpec(set *apps.StatefulSet, serverSideApply bool) (*corev1.PodSpec, error) {
+	if !serverSideApply {
+		_, podSpec, err := GetLastAppliedConfig(set)
+		return podSpec, err
+	}
+	spec, err := controller.GetAppliedStatefulSetSpec(set)
+	if err != nil {
+		return nil, err
+	}
+	return &spec.Template.Spec, nil
+}
+
+// appliedTemplateEqual is templateEqual for the StatefulSet that may be committed with server-side apply
+func appliedTemplateEqual(new *apps.StatefulSet, old *apps.StatefulSet, serverSideApply bool) bool {
+	if !serverSideApply {
+		return templateEqual(new, old)
+	}
+	podSpec, err := getLastAppliedPodSpec(old, true)
+	if err != nil {
+		klog.Errorf("get PodTemplate: [%s/%s]'s applied config failed,error: %v", old.GetNamespace(), old.GetName(), err)
+		return false
+	}
+	return apiequality.Semantic.DeepEqual(*podSpec, new.Spec.Template.Spec)
+}
+
+// getStsOrdinalsStart returns the start ordinal of the pods set by `.spec.ordinals.start` of the StatefulSet.
+// Note that the helpers of the advanced StatefulSet always count the ordinals from 0.
+func getStsOrdinalsStart(set *apps.StatefulSet) int32 {
+	if set.Spec.Ordinals == nil {
+		return 0
+	}
+	return set.Spec.Ordinals.Start
+}
+
+// getStsPodOrdinals returns the ordinals of the pods of the StatefulSet with the ordinals start taken into account
+func getStsPodOrdinals(set *apps.StatefulSet) sets.Int32 {
+	ordinals := helper.GetPodOrdinals(*set.Spec.Replicas, set)
+	start := getStsOrdinalsStart(set)
+	if start == 0 {
+		return ordinals
+	}
+	shifted := sets.NewInt32()
+	for ordinal := range ordinals {
+		shifted.Insert(ordinal + start)
+	}
+	return shifted
+}
+
 func MemberPodName(controllerName, controllerKind string, ordinal int32, memberType v1alpha1.MemberType) (string, error) {
 	switch controllerKind {
 	case v1alpha1.TiDBClusterKind:
@@ -271,6 +321,13 @@ func getTikVConfigMapForTiKVSpec(tikvSpec *v1alpha1.TiKVSpec, tc *v1alpha1.TidbC
 		config.Set("security.cert-path", path.Join(tikvClusterCertPath, corev1.TLSCertKey))
 		config.Set("security.key-path", path.Join(tikvClusterCertPath, corev1.TLSPrivateKeyKey))
 	}
+	// the heartbeat intervals set in .spec.tikv.config explicitly take precedence
+	if tikvSpec.PDHeartbeatInterval != nil {
+		config.SetIfNil("raftstore.pd-heartbeat-tick-interval", *tikvSpec.PDHeartbeatInterval)
+	}
+	if tikvSpec.PDStoreHeartbeatInterval != nil {
+		config.SetIfNil("raftstore.pd-store-heartbeat-tick-interval", *tikvSpec.PDStoreHeartbeatInterval)
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -46,12 +46,20 @@ func Sha256Sum(v interface{}) (string, error) {
 	return fmt.Sprintf("%x", sum), nil
 }
 
-// FindConfigMapVolume returns the configmap which's name matches the predicate in a PodSpec, empty indicates not found
+// FindConfigMapVolume returns the configmap which's name matches the predicate in a PodSpec, empty indicates not found,
+// the configmaps projected into a projected volume are also considered
 func FindConfigMapVolume(podSpec *corev1.PodSpec, pred func(string) bool) string {
 	for _, vol := range podSpec.Volumes {
 		if vol.ConfigMap != nil && pred(vol.ConfigMap.LocalObjectReference.Name) {
 			return vol.ConfigMap.LocalObjectReference.Name
 		}
+		if vol.Projected != nil {
+			for _, source := range vol.Projected.Sources {
+				if source.ConfigMap != nil && pred(source.ConfigMap.LocalObjectReference.Name) {
+					return source.ConfigMap.LocalObjectReference.Name
+				}
+			}
+		}
 	}
 	return ""
 }
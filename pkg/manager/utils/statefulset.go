@@ -108,6 +108,23 @@ func UpdateStatefulSetWithPrecheck(
 	return UpdateStatefulSet(deps.StatefulSetControl, tc, newTiDBSet, oldTiDBSet)
 }
 
+// ApplyStatefulSetWithPrecheck is like UpdateStatefulSetWithPrecheck, but commits the StatefulSet with server-side apply
+func ApplyStatefulSetWithPrecheck(
+	deps *controller.Dependencies,
+	tc *v1alpha1.TidbCluster,
+	reason string,
+	newSet *apps.StatefulSet,
+	oldSet *apps.StatefulSet,
+) error {
+	notExistMount := notExistMount(newSet, oldSet)
+	if len(notExistMount) > 0 {
+		deps.Recorder.Eventf(tc, corev1.EventTypeWarning, reason, "contains volumeMounts that do not have matched volume: %v", notExistMount)
+		return fmt.Errorf("contains volumeMounts that do not have matched volume: %v", notExistMount)
+	}
+
+	return ApplyStatefulSet(deps.StatefulSetControl, tc, newSet, oldSet)
+}
+
 // UpdateStatefulSet is a template function to update the statefulset of components
 func UpdateStatefulSet(setCtl controller.StatefulSetControlInterface, object runtime.Object, newSet, oldSet *apps.StatefulSet) error {
 	isOrphan := metav1.GetControllerOf(oldSet) == nil
@@ -176,6 +193,54 @@ func UpdateStatefulSet(setCtl controller.StatefulSetControlInterface, object run
 	return err
 }
 
+// ApplyStatefulSet is like UpdateStatefulSet, but commits the StatefulSet with server-side apply. The oldSet is nil
+// if the StatefulSet doesn't exist yet. Nothing is applied if the fields owned by tidb-operator are not changed.
+func ApplyStatefulSet(setCtl controller.StatefulSetControlInterface, object runtime.Object, newSet, oldSet *apps.StatefulSet) error {
+	controllerMo, ok := object.(metav1.Object)
+	if !ok {
+		return fmt.Errorf("%T is not a metav1.Object, cannot call ApplyStatefulSet", object)
+	}
+
+	set := newSet.DeepCopy()
+	if set.Annotations == nil {
+		set.Annotations = map[string]string{}
+	}
+	if oldSet != nil {
+		// the fields can't be updated are kept as the live ones, as what UpdateStatefulSet does
+		set.Spec.Selector = oldSet.Spec.Selector
+		set.Spec.ServiceName = oldSet.Spec.ServiceName
+		set.Spec.PodManagementPolicy = oldSet.Spec.PodManagementPolicy
+		set.Spec.VolumeClaimTemplates = oldSet.Spec.VolumeClaimTemplates
+		if v, ok := oldSet.Annotations[label.AnnStsLastSyncTimestamp]; ok {
+			set.Annotations[label.AnnStsLastSyncTimestamp] = v
+		}
+
+		// the generation of the owner alone doesn't change the StatefulSet
+		if v, ok := oldSet.Annotations[label.AnnoOwnerGeneration]; ok {
+			set.Annotations[label.AnnoOwnerGeneration] = v
+		}
+		ac, err := controller.NewStatefulSetApplyConfiguration(set)
+		if err != nil {
+			return err
+		}
+		applied, err := controller.StatefulSetApplied(ac, oldSet)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+	}
+	set.Annotations[label.AnnoOwnerGeneration] = strconv.FormatInt(controllerMo.GetGeneration(), 10)
+
+	ac, err := controller.NewStatefulSetApplyConfiguration(set)
+	if err != nil {
+		return err
+	}
+	_, err = setCtl.ApplyStatefulSet(object, ac)
+	return err
+}
+
 // SetUpgradePartition set statefulSet's rolling update partition
 func SetUpgradePartition(set *apps.StatefulSet, upgradeOrdinal int32) {
 	set.Spec.UpdateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{Partition: &upgradeOrdinal}
@@ -15,6 +15,7 @@ package pdapi
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
@@ -38,15 +39,29 @@ const (
 	DeleteMemberActionType                      ActionType = "DeleteMember "
 	SetStoreLabelsActionType                    ActionType = "SetStoreLabels"
 	UpdateReplicationActionType                 ActionType = "UpdateReplicationConfig"
+	UpdateScheduleActionType                    ActionType = "UpdateScheduleConfig"
 	BeginEvictLeaderActionType                  ActionType = "BeginEvictLeader"
 	EndEvictLeaderActionType                    ActionType = "EndEvictLeader"
 	GetEvictLeaderSchedulersActionType          ActionType = "GetEvictLeaderSchedulers"
 	GetEvictLeaderSchedulersForStoresActionType ActionType = "GetEvictLeaderSchedulersForStores"
 	GetPDLeaderActionType                       ActionType = "GetPDLeader"
 	TransferPDLeaderActionType                  ActionType = "TransferPDLeader"
+	SetMemberLeaderPriorityActionType           ActionType = "SetMemberLeaderPriority"
 	GetAutoscalingPlansActionType               ActionType = "GetAutoscalingPlans"
 	GetRecoveringMarkActionType                 ActionType = "GetRecoveringMark"
 	PDMSTransferPrimaryActionType               ActionType = "PDMSTransferPrimary"
+	GetStoresLimitActionType                    ActionType = "GetStoresLimit"
+	SetAllStoresLimitActionType                 ActionType = "SetAllStoresLimit"
+	GetRegionLabelRulesActionType               ActionType = "GetRegionLabelRules"
+	SetRegionLabelRuleActionType                ActionType = "SetRegionLabelRule"
+	DeleteRegionLabelRuleActionType             ActionType = "DeleteRegionLabelRule"
+	GetSchedulersActionType                     ActionType = "GetSchedulers"
+	AddSchedulerActionType                      ActionType = "AddScheduler"
+	RemoveSchedulerActionType                   ActionType = "RemoveScheduler"
+	GetClockOffsetActionType                    ActionType = "GetClockOffset"
+	GetLabelPropertiesActionType                ActionType = "GetLabelProperties"
+	SetLabelPropertyActionType                  ActionType = "SetLabelProperty"
+	DeleteLabelPropertyActionType               ActionType = "DeleteLabelProperty"
 )
 
 type NotFoundReaction struct {
@@ -62,6 +77,12 @@ type Action struct {
 	Name        string
 	Labels      map[string]string
 	Replication PDReplicationConfig
+	Schedule    PDScheduleConfig
+	LimitType   string
+	Rate        float64
+	LabelRule   *RegionLabelRule
+	Property    LabelProperty
+	Priority    int
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -230,6 +251,16 @@ func (c *FakePDClient) UpdateReplicationConfig(config PDReplicationConfig) error
 	return nil
 }
 
+// UpdateScheduleConfig updates the schedule config
+func (c *FakePDClient) UpdateScheduleConfig(config PDScheduleConfig) error {
+	if reaction, ok := c.reactions[UpdateScheduleActionType]; ok {
+		action := &Action{Schedule: config}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
 func (c *FakePDClient) BeginEvictLeader(storeID uint64) error {
 	if reaction, ok := c.reactions[BeginEvictLeaderActionType]; ok {
 		action := &Action{ID: storeID}
@@ -270,7 +301,8 @@ func (c *FakePDClient) GetPDLeader() (*pdpb.Member, error) {
 	if reaction, ok := c.reactions[GetPDLeaderActionType]; ok {
 		action := &Action{}
 		result, err := reaction(action)
-		return result.(*pdpb.Member), err
+		leader, _ := result.(*pdpb.Member)
+		return leader, err
 	}
 	return nil, nil
 }
@@ -284,6 +316,15 @@ func (c *FakePDClient) TransferPDLeader(memberName string) error {
 	return nil
 }
 
+func (c *FakePDClient) SetMemberLeaderPriority(name string, priority int) error {
+	if reaction, ok := c.reactions[SetMemberLeaderPriorityActionType]; ok {
+		action := &Action{Name: name, Priority: priority}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
 func (c *FakePDClient) GetAutoscalingPlans(strategy Strategy) ([]Plan, error) {
 	if reaction, ok := c.reactions[GetAutoscalingPlansActionType]; ok {
 		action := &Action{}
@@ -303,6 +344,114 @@ func (c *FakePDClient) GetRecoveringMark() (bool, error) {
 	return true, nil
 }
 
+func (c *FakePDClient) GetStoresLimit() (map[uint64]*StoreLimit, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetStoresLimitActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]*StoreLimit), nil
+}
+
+func (c *FakePDClient) SetAllStoresLimit(limitType string, rate float64) error {
+	if reaction, ok := c.reactions[SetAllStoresLimitActionType]; ok {
+		action := &Action{LimitType: limitType, Rate: rate}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetRegionLabelRules() ([]*RegionLabelRule, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetRegionLabelRulesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*RegionLabelRule), nil
+}
+
+func (c *FakePDClient) SetRegionLabelRule(rule *RegionLabelRule) error {
+	if reaction, ok := c.reactions[SetRegionLabelRuleActionType]; ok {
+		action := &Action{LabelRule: rule}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) DeleteRegionLabelRule(id string) error {
+	if reaction, ok := c.reactions[DeleteRegionLabelRuleActionType]; ok {
+		action := &Action{Name: id}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetSchedulers() ([]string, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetSchedulersActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+func (c *FakePDClient) AddScheduler(name string) error {
+	if reaction, ok := c.reactions[AddSchedulerActionType]; ok {
+		action := &Action{Name: name}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) RemoveScheduler(name string) error {
+	if reaction, ok := c.reactions[RemoveSchedulerActionType]; ok {
+		action := &Action{Name: name}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetLabelProperties() (PDLabelPropertyConfig, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetLabelPropertiesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(PDLabelPropertyConfig), nil
+}
+
+func (c *FakePDClient) SetLabelProperty(property LabelProperty) error {
+	if reaction, ok := c.reactions[SetLabelPropertyActionType]; ok {
+		action := &Action{Property: property}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) DeleteLabelProperty(property LabelProperty) error {
+	if reaction, ok := c.reactions[DeleteLabelPropertyActionType]; ok {
+		action := &Action{Property: property}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetClockOffset() (time.Duration, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetClockOffsetActionType, action)
+	if err != nil {
+		return 0, err
+	}
+	return result.(time.Duration), nil
+}
+
 // FakePDMSClient implements a fake version of PDMSClient.
 type FakePDMSClient struct {
 	reactions map[ActionType]Reaction
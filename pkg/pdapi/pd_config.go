@@ -74,6 +74,9 @@ type PDReplicationConfig struct {
 	// Immutable, change should be made through pd-ctl after cluster creation
 	// +k8s:openapi-gen=false
 	LocationLabels StringSlice `toml:"location-labels,omitempty" json:"location-labels,omitempty"`
+	// IsolationLevel is the location label at which the replicas of a region must be isolated.
+	// Imported from v4.0.0
+	IsolationLevel *string `toml:"isolation-level,omitempty" json:"isolation-level,omitempty"`
 	// StrictlyMatchLabel strictly checks if the label of TiKV is matched with LocaltionLabels.
 	// Immutable, change should be made through pd-ctl after cluster creation.
 	// Imported from v3.1.0
@@ -17,9 +17,11 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -69,6 +71,8 @@ type PDClient interface {
 	SetStoreLabels(storeID uint64, labels map[string]string) (bool, error)
 	// UpdateReplicationConfig updates the replication config
 	UpdateReplicationConfig(config PDReplicationConfig) error
+	// UpdateScheduleConfig updates the schedule config, only the non-nil fields are changed
+	UpdateScheduleConfig(config PDScheduleConfig) error
 	// DeleteStore deletes a TiKV store from cluster
 	DeleteStore(storeID uint64) error
 	// SetStoreState sets store to specified state.
@@ -90,6 +94,9 @@ type PDClient interface {
 	GetPDLeader() (*pdpb.Member, error)
 	// TransferPDLeader transfers pd leader to specified member
 	TransferPDLeader(name string) error
+	// SetMemberLeaderPriority sets the leader priority of the specified pd member,
+	// pd transfers the leader to the healthy member with the highest priority by itself
+	SetMemberLeaderPriority(name string, priority int) error
 	// GetAutoscalingPlans returns the scaling plan for the cluster
 	GetAutoscalingPlans(strategy Strategy) ([]Plan, error)
 	// GetRecoveringMark return the pd recovering mark
@@ -98,6 +105,31 @@ type PDClient interface {
 	GetMSMembers(service string) ([]string, error)
 	// GetMSPrimary returns the primary PDMS member service-addr from cluster by specific Micro Service
 	GetMSPrimary(service string) (string, error)
+	// GetStoresLimit returns the store limits of all stores, keyed by store ID
+	GetStoresLimit() (map[uint64]*StoreLimit, error)
+	// SetAllStoresLimit sets the store limit of the given type for all stores
+	SetAllStoresLimit(limitType string, rate float64) error
+	// GetRegionLabelRules returns all region label rules
+	GetRegionLabelRules() ([]*RegionLabelRule, error)
+	// SetRegionLabelRule creates or updates a region label rule
+	SetRegionLabelRule(rule *RegionLabelRule) error
+	// DeleteRegionLabelRule deletes the region label rule with the given ID
+	DeleteRegionLabelRule(id string) error
+	// GetSchedulers returns the names of all schedulers
+	GetSchedulers() ([]string, error)
+	// AddScheduler adds the scheduler with the given name
+	AddScheduler(name string) error
+	// RemoveScheduler removes the scheduler with the given name
+	RemoveScheduler(name string) error
+	// GetLabelProperties returns the label properties of PD, keyed by the type of the property
+	GetLabelProperties() (PDLabelPropertyConfig, error)
+	// SetLabelProperty sets the label property on the stores with the label
+	SetLabelProperty(property LabelProperty) error
+	// DeleteLabelProperty deletes the label property from the stores with the label
+	DeleteLabelProperty(property LabelProperty) error
+	// GetClockOffset returns the offset of the clock of the PD member serving the request against the
+	// local one, which is estimated by the Date header of the response and accurate to about one second
+	GetClockOffset() (time.Duration, error)
 }
 
 var (
@@ -111,11 +143,17 @@ var (
 	pdLeaderPrefix         = "pd/api/v1/leader"
 	pdLeaderTransferPrefix = "pd/api/v1/leader/transfer"
 	pdReplicationPrefix    = "pd/api/v1/config/replicate"
+	pdSchedulePrefix       = "pd/api/v1/config/schedule"
 	// evictLeaderSchedulerConfigPrefix is the prefix of evict-leader-scheduler
 	// config API, available since PD v3.1.0.
 	evictLeaderSchedulerConfigPrefix = "pd/api/v1/scheduler-config/evict-leader-scheduler/list"
 	autoscalingPrefix                = "autoscaling"
 	recoveringMarkPrefix             = "pd/api/v1/admin/cluster/markers/snapshot-recovering"
+	storesLimitPrefix                = "pd/api/v1/stores/limit"
+	regionLabelRulesPrefix           = "pd/api/v1/config/region-label/rules"
+	regionLabelRulePrefix            = "pd/api/v1/config/region-label/rule"
+	statusPrefix                     = "pd/api/v1/status"
+	labelPropertyPrefix              = "pd/api/v1/config/label-property"
 	// Micro Service
 	MicroServicePrefix = "pd/api/v2/ms"
 )
@@ -191,6 +229,58 @@ type StoresInfo struct {
 	Stores []*StoreInfo `json:"stores"`
 }
 
+const (
+	// StoreLimitTypeAddPeer is the store limit type of adding peers
+	StoreLimitTypeAddPeer = "add-peer"
+	// StoreLimitTypeRemovePeer is the store limit type of removing peers
+	StoreLimitTypeRemovePeer = "remove-peer"
+)
+
+// StoreLimit is the store limit of a single store returned from PD RESTful interface
+type StoreLimit struct {
+	AddPeer    float64 `json:"add-peer"`
+	RemovePeer float64 `json:"remove-peer"`
+}
+
+// storeLimitRequest is the request to set store limit of all stores
+type storeLimitRequest struct {
+	Rate float64 `json:"rate"`
+	Type string  `json:"type"`
+}
+
+// RegionLabelRuleTypeKeyRange is the type of region label rules applied to key ranges
+const RegionLabelRuleTypeKeyRange = "key-range"
+
+// RegionLabel is a label of regions returned from PD RESTful interface
+type RegionLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// KeyRange is a key range of regions, the keys are hex encoded
+type KeyRange struct {
+	StartKey string `json:"start_key"`
+	EndKey   string `json:"end_key"`
+}
+
+// RegionLabelRule is a region label rule returned from PD RESTful interface,
+// only the rules of type key-range are supported
+type RegionLabelRule struct {
+	ID       string        `json:"id"`
+	Index    int           `json:"index"`
+	Labels   []RegionLabel `json:"labels"`
+	RuleType string        `json:"rule_type"`
+	Data     []KeyRange    `json:"data"`
+}
+
+// LabelProperty is a label property of PD, which applies the property of the type to the stores with the label,
+// e.g. the stores with the label are rejected to have leaders if the type is `reject-leader`
+type LabelProperty struct {
+	Type  string
+	Key   string
+	Value string
+}
+
 // MembersInfo is PD members info returned from PD RESTful interface
 // type Members map[string][]*pdpb.Member
 type MembersInfo struct {
@@ -491,7 +581,7 @@ func (c *pdClient) DeleteMemberByID(memberID uint64) error {
 		return nil
 	}
 	err2 := httputil.ReadErrorBody(res.Body)
-	return fmt.Errorf("failed %v to delete member %d: %v", res.StatusCode, memberID, err2)
+	return StatusErrorf(res.StatusCode, "failed %v to delete member %d: %v", res.StatusCode, memberID, err2)
 }
 
 func (c *pdClient) DeleteMember(name string) error {
@@ -523,7 +613,7 @@ func (c *pdClient) DeleteMember(name string) error {
 		return nil
 	}
 	err2 := httputil.ReadErrorBody(res.Body)
-	return fmt.Errorf("failed %v to delete member %s: %v", res.StatusCode, name, err2)
+	return StatusErrorf(res.StatusCode, "failed %v to delete member %s: %v", res.StatusCode, name, err2)
 }
 
 func (c *pdClient) SetStoreLabels(storeID uint64, labels map[string]string) (bool, error) {
@@ -562,6 +652,24 @@ func (c *pdClient) UpdateReplicationConfig(config PDReplicationConfig) error {
 	return fmt.Errorf("failed %v to update replication: %v", res.StatusCode, err)
 }
 
+func (c *pdClient) UpdateScheduleConfig(config PDScheduleConfig) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, pdSchedulePrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to update schedule config: %v", res.StatusCode, err)
+}
+
 func (c *pdClient) BeginEvictLeader(storeID uint64) error {
 	leaderEvictInfo := getLeaderEvictSchedulerInfo(storeID)
 	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
@@ -777,6 +885,24 @@ func (c *pdClient) TransferPDLeader(memberName string) error {
 	return fmt.Errorf("failed %v to transfer pd leader to %s,error: %v", res.StatusCode, memberName, err2)
 }
 
+func (c *pdClient) SetMemberLeaderPriority(name string, priority int) error {
+	apiURL := fmt.Sprintf("%s/%s/name/%s", c.url, membersPrefix, name)
+	data, err := json.Marshal(map[string]int{"leader-priority": priority})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set leader priority of pd member %s: %v", res.StatusCode, name, err)
+}
+
 func (c *pdClient) GetAutoscalingPlans(strategy Strategy) ([]Plan, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, autoscalingPrefix)
 	data, err := json.Marshal(strategy)
@@ -795,6 +921,182 @@ func (c *pdClient) GetAutoscalingPlans(strategy Strategy) ([]Plan, error) {
 	return plans, nil
 }
 
+func (c *pdClient) GetStoresLimit() (map[uint64]*StoreLimit, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, storesLimitPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	limits := map[uint64]*StoreLimit{}
+	err = json.Unmarshal(body, &limits)
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (c *pdClient) SetAllStoresLimit(limitType string, rate float64) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, storesLimitPrefix)
+	data, err := json.Marshal(storeLimitRequest{Rate: rate, Type: limitType})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set %s limit of all stores: %v", res.StatusCode, limitType, err)
+}
+
+func (c *pdClient) GetRegionLabelRules() ([]*RegionLabelRule, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, regionLabelRulesPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	rules := []*RegionLabelRule{}
+	err = json.Unmarshal(body, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (c *pdClient) SetRegionLabelRule(rule *RegionLabelRule) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, regionLabelRulePrefix)
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set region label rule %s: %v", res.StatusCode, rule.ID, err)
+}
+
+func (c *pdClient) DeleteRegionLabelRule(id string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, regionLabelRulePrefix, url.PathEscape(id))
+	_, err := httputil.DeleteBodyOK(c.httpClient, apiURL)
+	return err
+}
+
+func (c *pdClient) GetSchedulers() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	schedulers := []string{}
+	err = json.Unmarshal(body, &schedulers)
+	if err != nil {
+		return nil, err
+	}
+	return schedulers, nil
+}
+
+func (c *pdClient) AddScheduler(name string) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
+	data, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to add scheduler %s: %v", res.StatusCode, name, err)
+}
+
+func (c *pdClient) RemoveScheduler(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, schedulersPrefix, url.PathEscape(name))
+	_, err := httputil.DeleteBodyOK(c.httpClient, apiURL)
+	return err
+}
+
+func (c *pdClient) GetLabelProperties() (PDLabelPropertyConfig, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, labelPropertyPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	properties := PDLabelPropertyConfig{}
+	err = json.Unmarshal(body, &properties)
+	if err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+func (c *pdClient) SetLabelProperty(property LabelProperty) error {
+	return c.updateLabelProperty("set", property)
+}
+
+func (c *pdClient) DeleteLabelProperty(property LabelProperty) error {
+	return c.updateLabelProperty("delete", property)
+}
+
+func (c *pdClient) updateLabelProperty(action string, property LabelProperty) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, labelPropertyPrefix)
+	data, err := json.Marshal(map[string]string{
+		"type":        property.Type,
+		"action":      action,
+		"label-key":   property.Key,
+		"label-value": property.Value,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to %s label property %s %s=%s: %v", res.StatusCode, action, property.Type, property.Key, property.Value, err)
+}
+
+func (c *pdClient) GetClockOffset() (time.Duration, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, statusPrefix)
+	start := time.Now()
+	res, err := c.httpClient.Get(apiURL)
+	if err != nil {
+		return 0, err
+	}
+	defer httputil.DeferClose(res.Body)
+	end := time.Now()
+	if res.StatusCode != http.StatusOK {
+		err = httputil.ReadErrorBody(res.Body)
+		return 0, fmt.Errorf("failed %v to get status: %v", res.StatusCode, err)
+	}
+	serverTime, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the Date header of %s: %v", apiURL, err)
+	}
+	// the Date header is truncated to seconds, so take the middle of the second as the time of PD
+	// and compare it with the middle of the request
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	return serverTime.Sub(start.Add(end.Sub(start) / 2)), nil
+}
+
 func getLeaderEvictSchedulerInfo(storeID uint64) *schedulerInfo {
 	return &schedulerInfo{"evict-leader-scheduler", storeID}
 }
@@ -822,3 +1124,28 @@ func IsTiKVNotBootstrappedError(err error) bool {
 	_, ok := err.(*TiKVNotBootstrappedError)
 	return ok
 }
+
+// StatusError represents that the PD API responds with an unexpected status code
+type StatusError struct {
+	StatusCode int
+	s          string
+}
+
+func (e *StatusError) Error() string {
+	return e.s
+}
+
+// StatusErrorf returns a StatusError with the status code
+func StatusErrorf(statusCode int, format string, a ...interface{}) error {
+	return &StatusError{StatusCode: statusCode, s: fmt.Sprintf(format, a...)}
+}
+
+// IsClientError returns whether err is a StatusError with a 4xx status code, i.e. the request is
+// rejected by PD and retrying it won't help
+func IsClientError(err error) bool {
+	serr := &StatusError{}
+	if !errors.As(err, &serr) {
+		return false
+	}
+	return serr.StatusCode >= http.StatusBadRequest && serr.StatusCode < http.StatusInternalServerError
+}
@@ -22,6 +22,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -77,6 +78,72 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestGetClockOffset(t *testing.T) {
+	tests := []struct {
+		name       string
+		skew       time.Duration
+		date       string
+		statusCode int
+		expectErr  bool
+	}{
+		{
+			name:       "clock is in sync",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "clock is ahead",
+			skew:       time.Minute,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "clock is behind",
+			skew:       -time.Minute,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid date header",
+			date:       "invalid",
+			statusCode: http.StatusOK,
+			expectErr:  true,
+		},
+		{
+			name:       "request failed",
+			statusCode: http.StatusInternalServerError,
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			server := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+				g.Expect(request.Method).To(Equal("GET"), "check method")
+				g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s", statusPrefix)), "check url")
+
+				date := tt.date
+				if date == "" {
+					date = time.Now().Add(tt.skew).UTC().Format(http.TimeFormat)
+				}
+				w.Header().Set("Date", date)
+				w.Header().Set("Content-Type", ContentTypeJSON)
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(`{}`))
+			})
+			defer server.Close()
+
+			pdClient := NewPDClient(server.URL, DefaultTimeout, &tls.Config{})
+			offset, err := pdClient.GetClockOffset()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			// the Date header is accurate to one second
+			g.Expect(offset).To(BeNumerically("~", tt.skew, time.Second))
+		})
+	}
+}
+
 func TestGetConfig(t *testing.T) {
 	g := NewGomegaWithT(t)
 	config := &PDConfigFromAPI{
@@ -443,6 +510,7 @@ func TestDeleteMember(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred(), "check result")
 		} else {
 			g.Expect(err).To(HaveOccurred(), "check result")
+			g.Expect(IsClientError(err)).To(BeFalse(), "check status code")
 		}
 	}
 }
@@ -798,6 +866,18 @@ func TestGeneric(t *testing.T) {
 			wantPath:    fmt.Sprintf("/%s", pdReplicationPrefix),
 			checkResult: checkNoError,
 		},
+		{
+			name:   "UpdateScheduleConfig",
+			method: "UpdateScheduleConfig",
+			args: []reflect.Value{
+				reflect.ValueOf(PDScheduleConfig{}),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", pdSchedulePrefix),
+			checkResult: checkNoError,
+		},
 		{
 			name:   "BeginEvictLeader",
 			method: "BeginEvictLeader",
@@ -866,6 +946,181 @@ func TestGeneric(t *testing.T) {
 			wantPath:    fmt.Sprintf("/%s/%s", pdLeaderTransferPrefix, "foo"),
 			checkResult: checkNoError,
 		},
+		{
+			name:   "SetMemberLeaderPriority",
+			method: "SetMemberLeaderPriority",
+			args: []reflect.Value{
+				reflect.ValueOf("foo"),
+				reflect.ValueOf(1),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s/name/%s", membersPrefix, "foo"),
+			checkResult: checkNoError,
+		},
+		{
+			name:   "GetStoresLimit",
+			method: "GetStoresLimit",
+			resp: []byte(`
+{
+	"1": {
+		"add-peer": 15,
+		"remove-peer": 15
+	}
+}
+`),
+			statusCode: http.StatusOK,
+			wantMethod: "GET",
+			wantPath:   fmt.Sprintf("/%s", storesLimitPrefix),
+			checkResult: func(t *testing.T, results []reflect.Value) {
+				checkNoError(t, results)
+				limits := results[0].Interface().(map[uint64]*StoreLimit)
+				if limit, ok := limits[1]; !ok || limit.AddPeer != 15 || limit.RemovePeer != 15 {
+					t.Errorf("unexpected store limits %v", limits)
+				}
+			},
+		},
+		{
+			name:   "SetAllStoresLimit",
+			method: "SetAllStoresLimit",
+			args: []reflect.Value{
+				reflect.ValueOf(StoreLimitTypeAddPeer),
+				reflect.ValueOf(float64(30)),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", storesLimitPrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:   "GetRegionLabelRules",
+			method: "GetRegionLabelRules",
+			resp: []byte(`
+[
+	{
+		"id": "tenant-a",
+		"index": 1,
+		"labels": [{"key": "tenant", "value": "a"}],
+		"rule_type": "key-range",
+		"data": [{"start_key": "7480", "end_key": "7490"}]
+	}
+]
+`),
+			statusCode: http.StatusOK,
+			wantMethod: "GET",
+			wantPath:   fmt.Sprintf("/%s", regionLabelRulesPrefix),
+			checkResult: func(t *testing.T, results []reflect.Value) {
+				checkNoError(t, results)
+				rules := results[0].Interface().([]*RegionLabelRule)
+				if len(rules) != 1 || rules[0].ID != "tenant-a" || len(rules[0].Data) != 1 || rules[0].Data[0].StartKey != "7480" {
+					t.Errorf("unexpected region label rules %v", rules)
+				}
+			},
+		},
+		{
+			name:   "SetRegionLabelRule",
+			method: "SetRegionLabelRule",
+			args: []reflect.Value{
+				reflect.ValueOf(&RegionLabelRule{ID: "tenant-a", RuleType: RegionLabelRuleTypeKeyRange}),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", regionLabelRulePrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:   "DeleteRegionLabelRule",
+			method: "DeleteRegionLabelRule",
+			args: []reflect.Value{
+				reflect.ValueOf("tenant-a"),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "DELETE",
+			wantPath:    fmt.Sprintf("/%s/tenant-a", regionLabelRulePrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:       "GetLabelProperties",
+			method:     "GetLabelProperties",
+			resp:       []byte(`{"reject-leader":[{"key":"zone","value":"z1"}]}`),
+			statusCode: http.StatusOK,
+			wantMethod: "GET",
+			wantPath:   fmt.Sprintf("/%s", labelPropertyPrefix),
+			checkResult: func(t *testing.T, results []reflect.Value) {
+				checkNoError(t, results)
+				properties := results[0].Interface().(PDLabelPropertyConfig)
+				if len(properties["reject-leader"]) != 1 || properties["reject-leader"][0].Key != "zone" || properties["reject-leader"][0].Value != "z1" {
+					t.Errorf("unexpected label properties %v", properties)
+				}
+			},
+		},
+		{
+			name:   "SetLabelProperty",
+			method: "SetLabelProperty",
+			args: []reflect.Value{
+				reflect.ValueOf(LabelProperty{Type: "reject-leader", Key: "zone", Value: "z1"}),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", labelPropertyPrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:   "DeleteLabelProperty",
+			method: "DeleteLabelProperty",
+			args: []reflect.Value{
+				reflect.ValueOf(LabelProperty{Type: "reject-leader", Key: "zone", Value: "z1"}),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", labelPropertyPrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:       "GetSchedulers",
+			method:     "GetSchedulers",
+			resp:       []byte(`["balance-leader-scheduler","balance-region-scheduler"]`),
+			statusCode: http.StatusOK,
+			wantMethod: "GET",
+			wantPath:   fmt.Sprintf("/%s", schedulersPrefix),
+			checkResult: func(t *testing.T, results []reflect.Value) {
+				checkNoError(t, results)
+				schedulers := results[0].Interface().([]string)
+				if len(schedulers) != 2 || schedulers[0] != "balance-leader-scheduler" || schedulers[1] != "balance-region-scheduler" {
+					t.Errorf("unexpected schedulers %v", schedulers)
+				}
+			},
+		},
+		{
+			name:   "AddScheduler",
+			method: "AddScheduler",
+			args: []reflect.Value{
+				reflect.ValueOf("balance-hot-region-scheduler"),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", schedulersPrefix),
+			checkResult: checkNoError,
+		},
+		{
+			name:   "RemoveScheduler",
+			method: "RemoveScheduler",
+			args: []reflect.Value{
+				reflect.ValueOf("balance-leader-scheduler"),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "DELETE",
+			wantPath:    fmt.Sprintf("/%s/balance-leader-scheduler", schedulersPrefix),
+			checkResult: checkNoError,
+		},
 	}
 
 	for _, tt := range tests {